@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"reservations-api/config"
+	"reservations-api/consumers"
 	"reservations-api/controllers"
+	"reservations-api/domain"
 	"reservations-api/events"
+	"reservations-api/jobs"
+	"reservations-api/payments"
 	"reservations-api/repositories"
 	"reservations-api/services"
 
@@ -18,40 +23,177 @@ func main() {
 		log.Fatal("Error inicializando la base de datos")
 	}
 
-	// Inicializar RabbitMQ
-	rabbitConn := config.InitRabbitMQ()
-	if rabbitConn == nil {
-		log.Fatal("Error inicializando RabbitMQ")
-	}
-	defer rabbitConn.Close()
+	// Inicializar event publisher; se conecta a RabbitMQ en background
+	rabbitConfig := config.LoadRabbitMQConfig()
+	publisher := events.NewEventPublisher(rabbitConfig)
+	defer publisher.Close()
+
+	holdConfig := config.LoadHoldConfig()
+	paymentConfig := config.LoadPaymentConfig()
+	cancellationConfig := config.LoadCancellationConfig()
+	outboxConfig := config.LoadOutboxConfig()
+	icalConfig := config.LoadICalConfig()
+	waitlistConfig := config.LoadWaitlistConfig()
+	schedulerConfig := config.LoadSchedulerConfig()
+	invoiceConfig := config.LoadInvoiceConfig()
+	authConfig := config.LoadAuthConfig()
 
-	// Inicializar event publisher
-	publisher, err := events.NewEventPublisher(rabbitConn)
+	// Inicializar gateway de pagos
+	gateway, err := payments.NewGateway(paymentConfig.Provider, paymentConfig.WebhookSecret)
 	if err != nil {
-		log.Fatalf("Error inicializando event publisher: %v", err)
+		log.Fatalf("Error inicializando gateway de pagos: %v", err)
 	}
-	defer publisher.Close()
+	log.Printf("Gateway de pagos: %s", gateway.Name())
+
+	roomsClient := config.NewRoomsAPIClient()
 
 	// Inicializar capas
 	reservationRepo := repositories.NewReservationRepository(db)
-	reservationService := services.NewReservationService(reservationRepo, publisher)
+	paymentRepo := repositories.NewPaymentRepository(db)
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	outboxRepo := repositories.NewOutboxRepository(db)
+	txRunner, err := repositories.NewTxRunner(db, outboxConfig.AllowStandalone)
+	if err != nil {
+		log.Fatalf("Error inicializando transacciones de MongoDB: %v", err)
+	}
+	waitlistRepo := repositories.NewWaitlistRepository(db)
+	groupRepo := repositories.NewGroupRepository(db)
+	reportRepo := repositories.NewReportRepository(db)
+	lockRepo := repositories.NewLockRepository(db)
+	promotionRepo := repositories.NewPromotionRepository(db)
+	jobRunRepo := repositories.NewJobRunRepository(db)
+	folioRepo := repositories.NewFolioRepository(db)
+	invoiceRepo := repositories.NewInvoiceRepository(db)
+	historyRepo := repositories.NewHistoryRepository(db)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo)
+	historyService := services.NewHistoryService(historyRepo, reservationRepo)
+	paymentService := services.NewPaymentService(paymentRepo, reservationRepo, gateway, roomsClient, paymentConfig)
+	cancellationService := services.NewCancellationService(paymentService, roomsClient, cancellationConfig)
+	promotionService := services.NewPromotionService(promotionRepo)
+	pricingService := services.NewPricingService(roomsClient, promotionService, paymentConfig.Currency)
+	reservationService := services.NewReservationService(reservationRepo, outboxRepo, historyService, txRunner, paymentService, cancellationService, pricingService, promotionService, holdConfig.TTL)
+	outboxService := services.NewOutboxService(outboxRepo)
+	relocationService := services.NewRelocationService(reservationRepo, outboxRepo, historyService, txRunner, roomsClient)
+	groupService := services.NewGroupService(groupRepo, reservationRepo, reservationService, pricingService, cancellationService, outboxRepo, historyService, txRunner)
+	waitlistService := services.NewWaitlistService(waitlistRepo, reservationService, outboxRepo, txRunner, roomsClient, waitlistConfig)
+	availabilityService := services.NewAvailabilityService(reservationRepo, roomsClient)
+	reportService := services.NewReportService(reportRepo, roomsClient, paymentConfig.Currency)
+	icalService := services.NewICalService(reservationRepo, outboxRepo, historyService, txRunner, roomsClient, icalConfig)
+	folioService := services.NewFolioService(folioRepo, reservationRepo, paymentService, roomsClient, paymentConfig.Currency, invoiceConfig)
+	invoiceService := services.NewInvoiceService(invoiceRepo, reservationRepo, folioService, txRunner, invoiceConfig)
 	reservationController := controllers.NewReservationController(reservationService)
+	availabilityController := controllers.NewAvailabilityController(availabilityService)
+	paymentController := controllers.NewPaymentController(paymentService)
+	outboxController := controllers.NewOutboxController(outboxService)
+	waitlistController := controllers.NewWaitlistController(waitlistService)
+	groupController := controllers.NewGroupController(groupService)
+	reportController := controllers.NewReportController(reportService)
+	icalController := controllers.NewICalController(icalService)
+	promotionController := controllers.NewPromotionController(promotionService)
+	invoiceController := controllers.NewInvoiceController(folioService, invoiceService)
+	historyController := controllers.NewHistoryController(historyService)
+
+	// Jobs periódicos: solo los corre la réplica que tiene el lock del scheduler
+	scheduler := jobs.NewScheduler(lockRepo, jobRunRepo, schedulerConfig.InstanceID, schedulerConfig.LeaseTTL)
+	// Los holds se expiran con un job en lugar de un índice TTL de Mongo porque
+	// el índice borra los documentos sin avisar y necesitamos publicar reservation.expired
+	scheduler.Register("expire-holds", holdConfig.ExpiryInterval, reservationService.ExpireHolds)
+	scheduler.Register("mark-no-shows", schedulerConfig.NoShowInterval, func(ctx context.Context) (int, error) {
+		return reservationService.MarkNoShows(ctx, schedulerConfig.NoShowGrace)
+	})
+	scheduler.Register("complete-stays", schedulerConfig.StayCompletionInterval, func(ctx context.Context) (int, error) {
+		return reservationService.CompleteStays(ctx, schedulerConfig.StayCompletionGrace)
+	})
+	scheduler.Register("purge-idempotency-keys", schedulerConfig.IdempotencyPurgeInterval, idempotencyService.PurgeExpired)
+	if len(icalConfig.Feeds) > 0 {
+		scheduler.Register("import-ical-feeds", icalConfig.ImportInterval, jobs.ImportICalFeeds(icalService, icalConfig.Feeds))
+	}
+	jobService := services.NewJobService(lockRepo, jobRunRepo, scheduler.Definitions(), schedulerConfig.InstanceID)
+	jobController := controllers.NewJobController(jobService)
+
+	// Jobs en background
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go scheduler.Start(jobsCtx)
+
+	outboxRelay := events.NewOutboxRelay(outboxRepo, publisher, outboxConfig.RelayInterval)
+	go outboxRelay.Start(jobsCtx)
+
+	roomsConsumer := consumers.NewRoomsConsumer(rabbitConfig, relocationService)
+	go roomsConsumer.Start(jobsCtx)
+
+	reservationsConsumer := consumers.NewReservationsConsumer(rabbitConfig, waitlistService)
+	go reservationsConsumer.Start(jobsCtx)
+
 	// Configurar router
 	router := gin.Default()
 	// --- ACA agregás el health check ---
 	router.POST("/health", func(c *gin.Context) {
+		status, rabbitStatus := "ok", "connected"
+		if !publisher.Connected() {
+			// Sin broker el servicio sigue atendiendo; los eventos quedan en el outbox
+			status, rabbitStatus = "degraded", "disconnected"
+		}
 		c.JSON(200, gin.H{
-			"status": "ok",
+			"status":   status,
+			"rabbitmq": rabbitStatus,
 		})
 	})
 	// Rutas
-	api := router.Group("/api")
+	// El actor del JWT queda en el contexto para el historial de cambios
+	api := router.Group("/api", controllers.ActorMiddleware(authConfig.JWTSecret))
 	{
 		api.GET("/reservations/users/:user_id/myreservations", reservationController.GetmyReservations)
 		api.GET("/reservations", reservationController.GetAllReservations)
-		api.POST("/reservations", reservationController.CreateReservation)
+		api.POST("/reservations", controllers.IdempotencyMiddleware(idempotencyService), reservationController.CreateReservation)
+		api.POST("/reservations/holds", reservationController.CreateHold)
+		api.POST("/reservations/quote", reservationController.QuoteReservation)
+		api.POST("/reservations/groups", groupController.CreateGroup)
+		api.GET("/reservations/groups/:id", groupController.GetGroup)
+		api.PATCH("/reservations/groups/:id", groupController.UpdateGroup)
+		api.DELETE("/reservations/groups/:id", groupController.CancelGroup)
+		api.POST("/reservations/:id/confirm", reservationController.ConfirmReservation)
+		api.POST("/reservations/:id/check-in", reservationController.CheckIn)
+		api.POST("/reservations/:id/check-out", reservationController.CheckOut)
+		api.POST("/reservations/:id/payments", paymentController.AuthorizePayment)
+		api.GET("/reservations/:id/payments", paymentController.GetReservationPayments)
+		api.GET("/reservations/:id/folio", invoiceController.GetFolio)
+		api.GET("/reservations/:id/invoice", invoiceController.GetInvoice)
+		api.GET("/reservations/:id/invoices", invoiceController.ListInvoices)
+		api.POST("/payments/webhooks", paymentController.HandleWebhook)
+		api.GET("/availability", availabilityController.GetAvailability)
+		api.GET("/rooms/:room_id/calendar.ics", icalController.GetRoomCalendar)
 		api.GET("/reservations/:id", reservationController.GetReservationByID)
+		api.GET("/reservations/:id/history", historyController.GetHistory)
+		api.GET("/reservations/:id/cancellation-preview", reservationController.GetCancellationPreview)
 		api.DELETE("/reservations/:id", reservationController.DeleteReservation)
+		api.GET("/outbox/stats", outboxController.GetStats)
+		api.POST("/waitlist", waitlistController.JoinWaitlist)
+		api.GET("/waitlist/:id", waitlistController.GetWaitlistEntry)
+		api.DELETE("/waitlist/:id", waitlistController.CancelWaitlistEntry)
+	}
+	// Operaciones del personal que mueven dinero o facturan: requieren JWT
+	// con rol staff o admin. El webhook queda abierto porque se verifica con HMAC.
+	staff := api.Group("", controllers.RoleMiddleware(domain.RoleStaff, domain.RoleAdmin))
+	{
+		staff.POST("/reservations/:id/folio/charges", invoiceController.AddCharge)
+		staff.POST("/reservations/:id/invoice/credit-note", invoiceController.CreateCreditNote)
+		staff.POST("/payments/:payment_id/capture", paymentController.CapturePayment)
+		staff.POST("/payments/:payment_id/refund", paymentController.RefundPayment)
+		staff.POST("/payments/:payment_id/void", paymentController.VoidPayment)
+	}
+	// Administración: requiere JWT válido con rol admin
+	admin := api.Group("/admin", controllers.RoleMiddleware(domain.RoleAdmin))
+	{
+		admin.GET("/reports/performance", reportController.GetPerformance)
+		admin.GET("/reports/revenue", reportController.GetRevenue)
+		admin.GET("/jobs", jobController.GetJobs)
+		admin.POST("/promotions", promotionController.CreatePromotion)
+		admin.GET("/promotions", promotionController.ListPromotions)
+		admin.GET("/promotions/:id", promotionController.GetPromotion)
+		admin.PATCH("/promotions/:id", promotionController.UpdatePromotion)
+		admin.DELETE("/promotions/:id", promotionController.DeactivatePromotion)
 	}
 	// Iniciar servidor
 	log.Println("Reservations API running on port 8080")
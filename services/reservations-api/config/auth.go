@@ -0,0 +1,18 @@
+package config
+
+import "log"
+
+// AuthConfig configura la validación de los JWT que emite users-api. Los
+// endpoints de huéspedes no exigen token; si viene, identifica al actor del
+// historial. Los de administración exigen un token válido con el rol.
+type AuthConfig struct {
+	JWTSecret string
+}
+
+func LoadAuthConfig() AuthConfig {
+	secret := getenvOrDefault("JWT_SECRET", "")
+	if secret == "" {
+		log.Println("JWT_SECRET no configurado: los cambios se registran como anónimos y los endpoints de administración responden 401")
+	}
+	return AuthConfig{JWTSecret: secret}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"reservations-api/domain"
+)
+
+// CancellationConfig define qué política aplica a cada tipo de habitación
+type CancellationConfig struct {
+	DefaultPolicy domain.CancellationPolicyType
+	ByRoomType    map[string]domain.CancellationPolicyType
+	// CheckInHour es la hora local de check-in desde la que se cuenta la anticipación
+	CheckInHour int
+}
+
+// LoadCancellationConfig lee CANCELLATION_POLICIES con el formato
+// "suite=moderate,deluxe=non_refundable"; los tipos no listados usan
+// CANCELLATION_DEFAULT_POLICY.
+func LoadCancellationConfig() CancellationConfig {
+	cfg := CancellationConfig{
+		DefaultPolicy: domain.CancellationPolicyFlexible,
+		ByRoomType:    map[string]domain.CancellationPolicyType{},
+		CheckInHour:   15,
+	}
+
+	if def := domain.CancellationPolicyType(getenvOrDefault("CANCELLATION_DEFAULT_POLICY", "")); def != "" {
+		if _, ok := domain.DefaultCancellationPolicies[def]; ok {
+			cfg.DefaultPolicy = def
+		} else {
+			log.Printf("Política de cancelación por defecto desconocida: %q", def)
+		}
+	}
+
+	for _, pair := range strings.Split(getenvOrDefault("CANCELLATION_POLICIES", ""), ",") {
+		roomType, policy, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			continue
+		}
+		policyType := domain.CancellationPolicyType(strings.TrimSpace(policy))
+		if _, ok := domain.DefaultCancellationPolicies[policyType]; !ok {
+			log.Printf("Política de cancelación desconocida para %s: %q", roomType, policy)
+			continue
+		}
+		cfg.ByRoomType[strings.TrimSpace(roomType)] = policyType
+	}
+
+	if hourRaw := getenvOrDefault("CHECK_IN_HOUR", ""); hourRaw != "" {
+		if hour, err := strconv.Atoi(hourRaw); err == nil && hour >= 0 && hour < 24 {
+			cfg.CheckInHour = hour
+		} else {
+			log.Printf("Valor inválido para CHECK_IN_HOUR (%q), usando %d", hourRaw, cfg.CheckInHour)
+		}
+	}
+
+	return cfg
+}
+
+// PolicyFor devuelve la política configurada para el tipo de habitación
+func (c CancellationConfig) PolicyFor(roomType string) domain.CancellationPolicy {
+	if policyType, ok := c.ByRoomType[roomType]; ok {
+		return domain.DefaultCancellationPolicies[policyType]
+	}
+	return domain.DefaultCancellationPolicies[c.DefaultPolicy]
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"time"
 
+	"reservations-api/domain"
+
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -115,12 +117,53 @@ func createIndexes(db *mongo.Database) error {
 		},
 	}
 
+	// Índice para que el expirer encuentre rápido los holds vencidos
+	holdExpiryIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "expires_at", Value: 1},
+		},
+	}
+
+	// Índices para la paginación por cursor de los listados
+	userListIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	}
+	// Índice para obtener las reservas de un grupo
+	groupIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "group_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
+	// Índice para reconciliar los bloqueos importados de feeds iCal
+	externalIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "external.feed_url", Value: 1},
+			{Key: "room_id", Value: 1},
+		},
+		Options: options.Index().SetSparse(true),
+	}
+	listIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	}
+
 	indexes := []mongo.IndexModel{
 		userStatusIndex,
+		userListIndex,
+		listIndex,
+		groupIndex,
+		externalIndex,
 		roomDatesIndex,
 		statusIndex,
 		deletedAtIndex,
 		createdAtIndex,
+		holdExpiryIndex,
 	}
 
 	_, err := collection.Indexes().CreateMany(ctx, indexes)
@@ -128,6 +171,194 @@ func createIndexes(db *mongo.Database) error {
 		return err
 	}
 
+	if err := createPaymentIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	if err := createIdempotencyIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	if err := createOutboxIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	if err := createWaitlistIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	if err := createJobRunIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	if err := createPromotionIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	if err := createInvoiceIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	if err := createHistoryIndexes(ctx, db); err != nil {
+		return err
+	}
+
 	log.Println("Índices de MongoDB creados exitosamente")
 	return nil
 }
+
+// createPaymentIndexes crea los índices de la colección de pagos
+func createPaymentIndexes(ctx context.Context, db *mongo.Database) error {
+	payments := db.Collection("payments")
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "reservation_id", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			// La referencia del proveedor identifica al pago en los webhooks
+			Keys:    bson.D{{Key: "gateway_ref", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{
+			// Una sola autorización en curso o vigente por reserva: dos
+			// requests concurrentes no pueden autorizar ambos
+			Keys: bson.D{{Key: "reservation_id", Value: 1}},
+			Options: options.Index().
+				SetName("reservation_id_active_unique").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": bson.M{"$in": bson.A{
+					domain.PaymentStatusPending,
+					domain.PaymentStatusAuthorized,
+					domain.PaymentStatusCaptured,
+				}}}),
+		},
+	}
+
+	_, err := payments.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// createIdempotencyIndexes crea el índice TTL que borra las claves vencidas
+func createIdempotencyIndexes(ctx context.Context, db *mongo.Database) error {
+	ttlIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	_, err := db.Collection("idempotency_keys").Indexes().CreateOne(ctx, ttlIndex)
+	return err
+}
+
+// createOutboxIndexes crea los índices que usa el relay del outbox
+func createOutboxIndexes(ctx context.Context, db *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+		},
+		{
+			// Los mensajes enviados se borran a los 7 días
+			Keys: bson.D{{Key: "sent_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(int32((7 * 24 * time.Hour).Seconds())),
+		},
+	}
+
+	_, err := db.Collection("outbox").Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// createWaitlistIndexes crea los índices para buscar entradas en espera
+// por habitación o tipo y para cerrar ofertas por hold
+func createWaitlistIndexes(ctx context.Context, db *mongo.Database) error {
+	waitlist := db.Collection("waitlist")
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "room_id", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "room_type", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "reservation_id", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+	}
+
+	_, err := waitlist.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// createJobRunIndexes crea los índices del historial de corridas del scheduler
+func createJobRunIndexes(ctx context.Context, db *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "job", Value: 1}, {Key: "started_at", Value: -1}},
+		},
+		{
+			// El historial se conserva 7 días
+			Keys: bson.D{{Key: "finished_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(int32((7 * 24 * time.Hour).Seconds())),
+		},
+	}
+
+	_, err := db.Collection("job_runs").Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// createPromotionIndexes hace único el código promocional
+func createPromotionIndexes(ctx context.Context, db *mongo.Database) error {
+	codeIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := db.Collection("promotions").Indexes().CreateOne(ctx, codeIndex)
+	return err
+}
+
+// createInvoiceIndexes garantiza una factura y una nota de crédito por
+// revisión y números únicos dentro de cada serie
+func createInvoiceIndexes(ctx context.Context, db *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "reservation_id", Value: 1},
+				{Key: "type", Value: 1},
+				{Key: "revision", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "type", Value: 1}, {Key: "number", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	if _, err := db.Collection("invoices").Indexes().CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	chargesIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "reservation_id", Value: 1}, {Key: "created_at", Value: 1}},
+	}
+	_, err := db.Collection("folio_charges").Indexes().CreateOne(ctx, chargesIndex)
+	return err
+}
+
+// createHistoryIndexes ordena el historial por reserva; la secuencia única
+// evita que dos cambios concurrentes ocupen la misma posición
+func createHistoryIndexes(ctx context.Context, db *mongo.Database) error {
+	sequenceIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "reservation_id", Value: 1}, {Key: "sequence", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := db.Collection("reservation_history").Indexes().CreateOne(ctx, sequenceIndex)
+	return err
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"log"
+	"time"
+)
+
+const (
+	defaultHoldTTL            = 15 * time.Minute
+	defaultHoldExpiryInterval = 30 * time.Second
+)
+
+// HoldConfig agrupa la configuración de los holds temporales de checkout
+type HoldConfig struct {
+	// TTL es cuánto tiempo queda retenida la habitación sin confirmar
+	TTL time.Duration
+	// ExpiryInterval es cada cuánto corre el expirer de holds vencidos
+	ExpiryInterval time.Duration
+}
+
+func LoadHoldConfig() HoldConfig {
+	return HoldConfig{
+		TTL:            getDurationOrDefault("RESERVATION_HOLD_TTL", defaultHoldTTL),
+		ExpiryInterval: getDurationOrDefault("RESERVATION_HOLD_EXPIRY_INTERVAL", defaultHoldExpiryInterval),
+	}
+}
+
+func getDurationOrDefault(key string, def time.Duration) time.Duration {
+	raw := getenvOrDefault(key, "")
+	if raw == "" {
+		return def
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		log.Printf("Valor inválido para %s (%q), usando %s", key, raw, def)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"reservations-api/domain"
+)
+
+const (
+	defaultICalImportInterval = 15 * time.Minute
+	defaultICalExportDays     = 365
+)
+
+// ICalConfig agrupa la configuración de exportación e importación de calendarios
+type ICalConfig struct {
+	// Feeds externos a importar, en ICAL_FEEDS como "room_id=url,room_id=url".
+	// La url puede ser http(s), file:// o una ruta local.
+	Feeds          []domain.ICalFeed
+	ImportInterval time.Duration
+	// ExportDays es cuántos días hacia adelante publica el feed de cada habitación
+	ExportDays int
+}
+
+func LoadICalConfig() ICalConfig {
+	exportDays := defaultICalExportDays
+	if raw := getenvOrDefault("ICAL_EXPORT_DAYS", ""); raw != "" {
+		if days, err := strconv.Atoi(raw); err == nil && days > 0 {
+			exportDays = days
+		} else {
+			log.Printf("Valor inválido para ICAL_EXPORT_DAYS (%q), usando %d", raw, defaultICalExportDays)
+		}
+	}
+
+	return ICalConfig{
+		Feeds:          parseICalFeeds(getenvOrDefault("ICAL_FEEDS", "")),
+		ImportInterval: getDurationOrDefault("ICAL_IMPORT_INTERVAL", defaultICalImportInterval),
+		ExportDays:     exportDays,
+	}
+}
+
+func parseICalFeeds(raw string) []domain.ICalFeed {
+	var feeds []domain.ICalFeed
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		roomIDStr, url, found := strings.Cut(item, "=")
+		roomID, err := strconv.ParseUint(strings.TrimSpace(roomIDStr), 10, 32)
+		if !found || err != nil || strings.TrimSpace(url) == "" {
+			log.Printf("Feed iCal inválido en ICAL_FEEDS, se ignora: %q", item)
+			continue
+		}
+		feeds = append(feeds, domain.ICalFeed{RoomID: uint(roomID), URL: strings.TrimSpace(url)})
+	}
+	return feeds
+}
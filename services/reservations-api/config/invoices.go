@@ -0,0 +1,34 @@
+package config
+
+import (
+	"log"
+	"strconv"
+)
+
+const defaultTaxRate = 21
+
+// InvoiceConfig agrupa los datos fiscales de las facturas. Las tarifas y
+// los extras se cargan con impuestos incluidos; la factura los desglosa.
+type InvoiceConfig struct {
+	IssuerName  string
+	IssuerTaxID string
+	TaxName     string
+	// TaxRate es la alícuota por defecto, en porcentaje
+	TaxRate float64
+}
+
+func LoadInvoiceConfig() InvoiceConfig {
+	rateRaw := getenvOrDefault("TAX_RATE", strconv.Itoa(defaultTaxRate))
+	rate, err := strconv.ParseFloat(rateRaw, 64)
+	if err != nil || rate < 0 || rate > 100 {
+		log.Printf("Valor inválido para TAX_RATE (%q), usando %d", rateRaw, defaultTaxRate)
+		rate = defaultTaxRate
+	}
+
+	return InvoiceConfig{
+		IssuerName:  getenvOrDefault("INVOICE_ISSUER_NAME", "Hotel"),
+		IssuerTaxID: getenvOrDefault("INVOICE_ISSUER_TAX_ID", ""),
+		TaxName:     getenvOrDefault("TAX_NAME", "IVA"),
+		TaxRate:     rate,
+	}
+}
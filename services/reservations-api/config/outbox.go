@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+const defaultOutboxRelayInterval = 2 * time.Second
+
+// OutboxConfig agrupa la configuración del relay del outbox de eventos
+type OutboxConfig struct {
+	// RelayInterval es cada cuánto el relay busca mensajes pendientes
+	RelayInterval time.Duration
+	// AllowStandalone permite arrancar contra un MongoDB sin replica set; el
+	// outbox deja de escribirse en la misma transacción que la reserva
+	AllowStandalone bool
+}
+
+func LoadOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		RelayInterval:   getDurationOrDefault("OUTBOX_RELAY_INTERVAL", defaultOutboxRelayInterval),
+		AllowStandalone: getenvOrDefault("MONGO_ALLOW_STANDALONE", "") == "true",
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"log"
+	"strconv"
+)
+
+// PaymentConfig agrupa la configuración del subsistema de pagos
+type PaymentConfig struct {
+	Provider      string
+	WebhookSecret string
+	Currency      string
+	// DepositPercent es el porcentaje del total que se autoriza con la política deposit
+	DepositPercent float64
+}
+
+func LoadPaymentConfig() PaymentConfig {
+	depositRaw := getenvOrDefault("PAYMENT_DEPOSIT_PERCENT", "30")
+	deposit, err := strconv.ParseFloat(depositRaw, 64)
+	if err != nil || deposit <= 0 || deposit > 100 {
+		log.Printf("Valor inválido para PAYMENT_DEPOSIT_PERCENT (%q), usando 30", depositRaw)
+		deposit = 30
+	}
+
+	return PaymentConfig{
+		Provider:       getenvOrDefault("PAYMENT_PROVIDER", "mock"),
+		WebhookSecret:  getenvOrDefault("PAYMENT_WEBHOOK_SECRET", ""),
+		Currency:       getenvOrDefault("PAYMENT_CURRENCY", "USD"),
+		DepositPercent: deposit,
+	}
+}
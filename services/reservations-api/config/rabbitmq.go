@@ -2,32 +2,67 @@ package config
 
 import (
 	"log"
+	"strconv"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-func InitRabbitMQ() *amqp.Connection {
-	rabbitURL := getenvOrDefault("RABBITMQ_URL", "amqp://rabbitmq:5672/")
-
-	var (
-		conn *amqp.Connection
-		err  error
-	)
-
-	// Backoff exponencial para conectar a RabbitMQ
-	for attempt := 1; attempt <= 10; attempt++ {
-		conn, err = amqp.Dial(rabbitURL)
-		if err == nil {
-			log.Printf("Conectado a RabbitMQ en %s (intento %d)", rabbitURL, attempt)
-			return conn
-		}
-
-		wait := time.Duration(attempt*2) * time.Second
-		log.Printf("RabbitMQ no listo (intento %d): %v. Reintentando en %s...", attempt, err, wait)
-		time.Sleep(wait)
+const (
+	defaultRabbitReconnectMin = 1 * time.Second
+	defaultRabbitReconnectMax = 30 * time.Second
+	defaultRabbitBufferSize   = 1000
+	rabbitDialTimeout         = 10 * time.Second
+)
+
+// RabbitMQConfig agrupa la configuración de la conexión a RabbitMQ. La
+// conexión se establece en background: el servicio arranca aunque el broker
+// no esté disponible y se reconecta solo.
+type RabbitMQConfig struct {
+	URL string
+	// ReconnectMin y ReconnectMax acotan el back-off exponencial de reconexión
+	ReconnectMin time.Duration
+	ReconnectMax time.Duration
+	// BufferSize es la cantidad máxima de mensajes retenidos mientras no hay conexión
+	BufferSize int
+	// RoomsQueue es la cola durable donde se reciben los eventos de rooms-api
+	RoomsQueue string
+	// WaitlistQueue recibe los eventos de reservas que liberan inventario
+	WaitlistQueue string
+}
+
+func LoadRabbitMQConfig() RabbitMQConfig {
+	bufferRaw := getenvOrDefault("RABBITMQ_PUBLISH_BUFFER", strconv.Itoa(defaultRabbitBufferSize))
+	buffer, err := strconv.Atoi(bufferRaw)
+	if err != nil || buffer <= 0 {
+		log.Printf("Valor inválido para RABBITMQ_PUBLISH_BUFFER (%q), usando %d", bufferRaw, defaultRabbitBufferSize)
+		buffer = defaultRabbitBufferSize
 	}
 
-	log.Fatalf("No se pudo conectar a RabbitMQ tras reintentos: %v", err)
-	return nil
+	cfg := RabbitMQConfig{
+		URL:           getenvOrDefault("RABBITMQ_URL", "amqp://rabbitmq:5672/"),
+		ReconnectMin:  getDurationOrDefault("RABBITMQ_RECONNECT_MIN", defaultRabbitReconnectMin),
+		ReconnectMax:  getDurationOrDefault("RABBITMQ_RECONNECT_MAX", defaultRabbitReconnectMax),
+		BufferSize:    buffer,
+		RoomsQueue:    getenvOrDefault("RABBITMQ_ROOMS_QUEUE", "reservations-api-rooms-queue"),
+		WaitlistQueue: getenvOrDefault("RABBITMQ_WAITLIST_QUEUE", "reservations-api-waitlist-queue"),
+	}
+	if cfg.ReconnectMax < cfg.ReconnectMin {
+		cfg.ReconnectMax = cfg.ReconnectMin
+	}
+	return cfg
+}
+
+// DialRabbitMQ abre una conexión sin reintentos; quien la usa decide el back-off
+func DialRabbitMQ(cfg RabbitMQConfig) (*amqp.Connection, error) {
+	return amqp.DialConfig(cfg.URL, amqp.Config{Dial: amqp.DefaultDial(rabbitDialTimeout)})
+}
+
+// NextBackoff duplica la espera de reconexión sin pasar de ReconnectMax
+func (c RabbitMQConfig) NextBackoff(wait time.Duration) time.Duration {
+	wait *= 2
+	if wait > c.ReconnectMax {
+		return c.ReconnectMax
+	}
+	return wait
 }
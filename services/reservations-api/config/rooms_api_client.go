@@ -0,0 +1,162 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"reservations-api/domain"
+)
+
+// ErrRoomNotFound indica que rooms-api no conoce la habitación (o fue dada de baja)
+var ErrRoomNotFound = errors.New("room not found")
+
+// RoomsAPIClient es el cliente HTTP para rooms-api
+type RoomsAPIClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewRoomsAPIClient crea un nuevo cliente para rooms-api
+func NewRoomsAPIClient() *RoomsAPIClient {
+	return &RoomsAPIClient{
+		BaseURL: getenvOrDefault("ROOMS_API_BASE_URL", "http://rooms-api:8080"),
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// GetRoomByID obtiene una habitación por ID desde rooms-api
+func (c *RoomsAPIClient) GetRoomByID(ctx context.Context, id uint) (*domain.RoomInfo, error) {
+	url := fmt.Sprintf("%s/api/v1/rooms/%d", c.BaseURL, id)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rooms-api request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request rooms-api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("room %d: %w", id, ErrRoomNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("rooms-api returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// rooms-api devuelve directamente el objeto room sin wrapper
+	var room domain.RoomInfo
+	if err := json.NewDecoder(resp.Body).Decode(&room); err != nil {
+		return nil, fmt.Errorf("failed to decode rooms-api response: %w", err)
+	}
+
+	return &room, nil
+}
+
+// GetRoomRates obtiene el precio de cada noche de la estadía según el plan
+// de tarifas de la habitación
+func (c *RoomsAPIClient) GetRoomRates(ctx context.Context, id uint, startDate, endDate string) (*domain.RoomRates, error) {
+	params := url.Values{}
+	params.Add("from", startDate)
+	params.Add("to", endDate)
+	ratesURL := fmt.Sprintf("%s/api/v1/rooms/%d/rates?%s", c.BaseURL, id, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ratesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rooms-api request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request rooms-api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("room %d: %w", id, ErrRoomNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("rooms-api returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var rates domain.RoomRates
+	if err := json.NewDecoder(resp.Body).Decode(&rates); err != nil {
+		return nil, fmt.Errorf("failed to decode rooms-api response: %w", err)
+	}
+
+	return &rates, nil
+}
+
+type roomListResponse struct {
+	Rooms []domain.RoomInfo `json:"rooms"`
+	Total int64             `json:"total"`
+	Page  int               `json:"page"`
+	Limit int               `json:"limit"`
+}
+
+// ListRoomsByType obtiene todas las habitaciones de un tipo recorriendo las páginas de rooms-api
+func (c *RoomsAPIClient) ListRoomsByType(ctx context.Context, roomType string) ([]domain.RoomInfo, error) {
+	return c.listRooms(ctx, url.Values{"type": {roomType}})
+}
+
+// ListRooms obtiene todas las habitaciones del hotel
+func (c *RoomsAPIClient) ListRooms(ctx context.Context) ([]domain.RoomInfo, error) {
+	return c.listRooms(ctx, url.Values{})
+}
+
+func (c *RoomsAPIClient) listRooms(ctx context.Context, filters url.Values) ([]domain.RoomInfo, error) {
+	const pageSize = 100
+
+	var rooms []domain.RoomInfo
+	for page := 1; ; page++ {
+		params := url.Values{}
+		for key, values := range filters {
+			params[key] = values
+		}
+		params.Add("page", fmt.Sprintf("%d", page))
+		params.Add("limit", fmt.Sprintf("%d", pageSize))
+
+		listURL := fmt.Sprintf("%s/api/v1/rooms?%s", c.BaseURL, params.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build rooms-api request: %w", err)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to request rooms-api: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("rooms-api returned status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var list roomListResponse
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode rooms-api response: %w", err)
+		}
+
+		rooms = append(rooms, list.Rooms...)
+		if len(list.Rooms) < pageSize || int64(len(rooms)) >= list.Total {
+			return rooms, nil
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	defaultSchedulerLeaseTTL        = 30 * time.Second
+	defaultNoShowGrace              = 24 * time.Hour
+	defaultNoShowInterval           = 15 * time.Minute
+	defaultStayCompletionGrace      = 12 * time.Hour
+	defaultStayCompletionInterval   = 15 * time.Minute
+	defaultIdempotencyPurgeInterval = time.Hour
+)
+
+// SchedulerConfig agrupa la configuración del scheduler de jobs
+type SchedulerConfig struct {
+	// InstanceID identifica a la réplica en el lock de líder
+	InstanceID string
+	// LeaseTTL es cuánto dura el lock si el líder deja de renovarlo
+	LeaseTTL time.Duration
+	// NoShowGrace es el margen desde la medianoche de start_date para hacer check-in
+	NoShowGrace    time.Duration
+	NoShowInterval time.Duration
+	// StayCompletionGrace es el margen desde la medianoche de end_date para cerrar la estadía
+	StayCompletionGrace      time.Duration
+	StayCompletionInterval   time.Duration
+	IdempotencyPurgeInterval time.Duration
+}
+
+func LoadSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		InstanceID:               getenvOrDefault("SCHEDULER_INSTANCE_ID", defaultInstanceID()),
+		LeaseTTL:                 getDurationOrDefault("SCHEDULER_LEASE_TTL", defaultSchedulerLeaseTTL),
+		NoShowGrace:              getDurationOrDefault("NO_SHOW_GRACE", defaultNoShowGrace),
+		NoShowInterval:           getDurationOrDefault("NO_SHOW_INTERVAL", defaultNoShowInterval),
+		StayCompletionGrace:      getDurationOrDefault("STAY_COMPLETION_GRACE", defaultStayCompletionGrace),
+		StayCompletionInterval:   getDurationOrDefault("STAY_COMPLETION_INTERVAL", defaultStayCompletionInterval),
+		IdempotencyPurgeInterval: getDurationOrDefault("IDEMPOTENCY_PURGE_INTERVAL", defaultIdempotencyPurgeInterval),
+	}
+}
+
+// defaultInstanceID usa el hostname (el id del contenedor en Docker) y el pid
+func defaultInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "reservations-api"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
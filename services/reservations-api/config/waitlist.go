@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+const defaultWaitlistOfferTTL = 2 * time.Hour
+
+// WaitlistConfig agrupa la configuración de la lista de espera
+type WaitlistConfig struct {
+	// OfferTTL es cuánto dura el hold ofrecido a un huésped en espera
+	OfferTTL time.Duration
+}
+
+func LoadWaitlistConfig() WaitlistConfig {
+	return WaitlistConfig{
+		OfferTTL: getDurationOrDefault("WAITLIST_OFFER_TTL", defaultWaitlistOfferTTL),
+	}
+}
@@ -0,0 +1,165 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/utils"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Espera antes de devolver a la cola un mensaje que falló, para no girar en caliente
+const retryDelay = 2 * time.Second
+
+// handlerFunc procesa un mensaje; si devuelve error el mensaje se reencola.
+// Los mensajes que nunca van a poder procesarse (JSON inválido) se
+// descartan devolviendo errDiscard.
+type handlerFunc func(ctx context.Context, body []byte) error
+
+var errDiscard = errors.New("discard message")
+
+// queueConsumer consume una cola durable bindeada a un exchange topic y se
+// reconecta con back-off cada vez que se pierde la conexión con el broker
+type queueConsumer struct {
+	cfg         config.RabbitMQConfig
+	name        string
+	exchange    string
+	queue       string
+	routingKeys []string
+	handle      handlerFunc
+}
+
+// run consume hasta que se cancele el contexto
+func (c *queueConsumer) run(ctx context.Context) {
+	wait := c.cfg.ReconnectMin
+	for {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			log.Printf("Consumer de %s detenido", c.name)
+			return
+		}
+		if err != nil {
+			log.Printf("Consumer de %s desconectado: %v. Reintentando en %s...", c.name, err, wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		if err != nil {
+			wait = c.cfg.NextBackoff(wait)
+		} else {
+			wait = c.cfg.ReconnectMin
+		}
+	}
+}
+
+// consume abre conexión y channel, declara la topología y procesa
+// mensajes hasta que se cierre la conexión o el contexto
+func (c *queueConsumer) consume(ctx context.Context) error {
+	conn, err := config.DialRabbitMQ(c.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := c.setup(ch); err != nil {
+		return err
+	}
+
+	// Procesar un mensaje a la vez: los handlers compiten por las mismas habitaciones
+	if err := ch.Qos(1, 0, false); err != nil {
+		return fmt.Errorf("failed to set qos: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		c.queue,                    // queue
+		"reservations-api-"+c.name, // consumer tag
+		false,                      // auto-ack
+		false,                      // exclusive
+		false,                      // no-local
+		false,                      // no-wait
+		nil,                        // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to consume: %w", err)
+	}
+
+	log.Printf("Consumer de %s escuchando en %s", c.name, c.queue)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return fmt.Errorf("delivery channel closed")
+			}
+			c.handleMessage(ctx, msg)
+		}
+	}
+}
+
+func (c *queueConsumer) setup(ch *amqp.Channel) error {
+	err := ch.ExchangeDeclare(
+		c.exchange, // name
+		"topic",    // type
+		true,       // durable
+		false,      // auto-deleted
+		false,      // internal
+		false,      // no-wait
+		nil,        // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	queue, err := ch.QueueDeclare(
+		c.queue, // name
+		true,    // durable
+		false,   // delete when unused
+		false,   // exclusive
+		false,   // no-wait
+		nil,     // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	for _, routingKey := range c.routingKeys {
+		if err := ch.QueueBind(queue.Name, routingKey, c.exchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind queue with routing key %s: %w", routingKey, err)
+		}
+	}
+	return nil
+}
+
+func (c *queueConsumer) handleMessage(ctx context.Context, msg amqp.Delivery) {
+	ctx = utils.WithActor(ctx, domain.Actor{Type: domain.ActorTypeSystem, Process: "consumer:" + c.name})
+	err := c.handle(ctx, msg.Body)
+	switch {
+	case err == nil:
+		msg.Ack(false)
+	case errors.Is(err, errDiscard):
+		msg.Nack(false, false)
+	default:
+		log.Printf("Error procesando mensaje %s: %v", msg.RoutingKey, err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryDelay):
+		}
+		msg.Nack(false, true)
+	}
+}
@@ -0,0 +1,57 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/events"
+	"reservations-api/services"
+)
+
+// ReservationsConsumer escucha los eventos propios de reservas que liberan
+// inventario o cierran ofertas para alimentar la lista de espera
+type ReservationsConsumer struct {
+	consumer queueConsumer
+	waitlist services.WaitlistService
+}
+
+func NewReservationsConsumer(cfg config.RabbitMQConfig, waitlist services.WaitlistService) *ReservationsConsumer {
+	c := &ReservationsConsumer{waitlist: waitlist}
+	c.consumer = queueConsumer{
+		cfg:      cfg,
+		name:     "waitlist",
+		exchange: events.ExchangeName,
+		queue:    cfg.WaitlistQueue,
+		routingKeys: []string{
+			string(domain.EventReservationCanceled),
+			string(domain.EventReservationExpired),
+			string(domain.EventReservationConfirmed),
+			string(domain.EventReservationNoShow),
+		},
+		handle: c.handle,
+	}
+	return c
+}
+
+// Start consume hasta que se cancele el contexto
+func (c *ReservationsConsumer) Start(ctx context.Context) {
+	c.consumer.run(ctx)
+}
+
+func (c *ReservationsConsumer) handle(ctx context.Context, body []byte) error {
+	envelope, err := events.DecodeCloudEvent(body, domain.ReservationEventSchemaV1)
+	if err != nil {
+		log.Printf("Evento de reserva inválido: %v", err)
+		return errDiscard // No requeue si el envelope es inválido
+	}
+
+	var event domain.ReservationEvent
+	if err := json.Unmarshal(envelope.Data, &event); err != nil {
+		log.Printf("Evento de reserva inválido: %v", err)
+		return errDiscard // No requeue si el JSON es inválido
+	}
+	return c.waitlist.HandleReservationEvent(ctx, event)
+}
@@ -0,0 +1,52 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/events"
+	"reservations-api/services"
+)
+
+// RoomsConsumer escucha las bajas y cambios de habitaciones de rooms-api
+// para reubicar las reservas afectadas
+type RoomsConsumer struct {
+	consumer queueConsumer
+	service  services.RelocationService
+}
+
+func NewRoomsConsumer(cfg config.RabbitMQConfig, service services.RelocationService) *RoomsConsumer {
+	c := &RoomsConsumer{service: service}
+	c.consumer = queueConsumer{
+		cfg:         cfg,
+		name:        "rooms",
+		exchange:    "rooms",
+		queue:       cfg.RoomsQueue,
+		routingKeys: []string{"room.updated", "room.deleted", "room.status_changed"},
+		handle:      c.handle,
+	}
+	return c
+}
+
+// Start consume hasta que se cancele el contexto
+func (c *RoomsConsumer) Start(ctx context.Context) {
+	c.consumer.run(ctx)
+}
+
+func (c *RoomsConsumer) handle(ctx context.Context, body []byte) error {
+	envelope, err := events.DecodeCloudEvent(body, domain.RoomEventSchemaV1, domain.RoomEventSchemaV2)
+	if err != nil {
+		log.Printf("Evento de rooms inválido: %v", err)
+		return errDiscard // No requeue si el envelope es inválido
+	}
+
+	var event domain.RoomEvent
+	if err := json.Unmarshal(envelope.Data, &event); err != nil {
+		log.Printf("Evento de rooms inválido: %v", err)
+		return errDiscard // No requeue si el JSON es inválido
+	}
+	return c.service.HandleRoomEvent(ctx, event)
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"net/http"
+	"reservations-api/domain"
+	"reservations-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorMiddleware identifica al actor del request a partir del JWT. Sin
+// header el request sigue como anónimo; un token presente pero inválido se
+// rechaza para no atribuir cambios a quien no corresponde.
+func ActorMiddleware(secret string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		actor := domain.Actor{Type: domain.ActorTypeAnonymous}
+
+		if header := ctx.GetHeader("Authorization"); header != "" && secret != "" {
+			token, err := utils.ParseBearerToken(header)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			}
+			claims, err := utils.ValidateToken(token, secret)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token inválido"})
+				return
+			}
+			actor = domain.Actor{
+				Type:     domain.ActorTypeUser,
+				UserID:   claims.UserID,
+				Username: claims.Username,
+				Role:     claims.Role,
+			}
+		}
+
+		ctx.Set(utils.ActorContextKey, actor)
+		ctx.Next()
+	}
+}
+
+// RoleMiddleware exige un usuario autenticado con alguno de los roles
+// indicados: sin token responde 401 y con otro rol 403. Va después de
+// ActorMiddleware, que es quien deja el actor en el contexto.
+func RoleMiddleware(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		actor := utils.ActorFromContext(ctx)
+		if actor.Type != domain.ActorTypeUser {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "autenticación requerida"})
+			return
+		}
+		for _, role := range roles {
+			if actor.Role == role {
+				ctx.Next()
+				return
+			}
+		}
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "permisos insuficientes"})
+	}
+}
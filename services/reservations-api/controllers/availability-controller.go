@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"reservations-api/services"
+	"reservations-api/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AvailabilityController struct {
+	service services.AvailabilityService
+}
+
+func NewAvailabilityController(service services.AvailabilityService) *AvailabilityController {
+	return &AvailabilityController{service: service}
+}
+
+// GetAvailability devuelve el calendario por noche de una habitación
+// (room_id) o los conteos por noche de un tipo de habitación (type).
+func (c *AvailabilityController) GetAvailability(ctx *gin.Context) {
+	roomIDStr := strings.TrimSpace(ctx.Query("room_id"))
+	roomType := strings.TrimSpace(ctx.Query("type"))
+	from := strings.TrimSpace(ctx.Query("from"))
+	to := strings.TrimSpace(ctx.Query("to"))
+
+	if from == "" || to == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "from y to son requeridos"})
+		return
+	}
+	if (roomIDStr == "") == (roomType == "") {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "indicar room_id o type (solo uno)"})
+		return
+	}
+
+	if roomIDStr != "" {
+		roomID, err := strconv.ParseUint(roomIDStr, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "room_id invalido"})
+			return
+		}
+		calendar, err := c.service.RoomCalendar(ctx, uint(roomID), from, to)
+		if err != nil {
+			writeAvailabilityError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"availability": calendar})
+		return
+	}
+
+	calendar, err := c.service.TypeCalendar(ctx, roomType, from, to)
+	if err != nil {
+		writeAvailabilityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"availability": calendar})
+}
+
+func writeAvailabilityError(ctx *gin.Context, err error) {
+	if errors.Is(err, utils.ErrInvalidReservationData) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
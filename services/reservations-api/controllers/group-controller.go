@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"reservations-api/domain"
+	"reservations-api/services"
+	"reservations-api/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GroupController struct {
+	service services.GroupService
+}
+
+func NewGroupController(service services.GroupService) *GroupController {
+	return &GroupController{service: service}
+}
+
+func (c *GroupController) CreateGroup(ctx *gin.Context) {
+	var req domain.CreateGroupReservationDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	detail, err := c.service.Create(ctx, req)
+	if err != nil {
+		writeGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, detail)
+}
+
+func (c *GroupController) GetGroup(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	detail, err := c.service.Get(ctx, id)
+	if err != nil {
+		writeGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, detail)
+}
+
+func (c *GroupController) UpdateGroup(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	var req domain.UpdateGroupReservationDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	detail, err := c.service.Update(ctx, id, req)
+	if err != nil {
+		writeGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, detail)
+}
+
+func (c *GroupController) CancelGroup(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	var body domain.CancelReservationDTO
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.Cancel(ctx, id, body.Reason); err != nil {
+		writeGroupError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func writeGroupError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, utils.ErrInvalidReservationData):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrGroupNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrReservationConflict), errors.Is(err, utils.ErrGroupNotActive):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrCancelPolicyUnknown):
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
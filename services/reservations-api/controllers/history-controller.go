@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"net/http"
+	"reservations-api/services"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HistoryController struct {
+	service services.HistoryService
+}
+
+func NewHistoryController(service services.HistoryService) *HistoryController {
+	return &HistoryController{service: service}
+}
+
+// GetHistory devuelve el historial de cambios; con ?verify=true además
+// reconstruye la reserva desde el historial y la compara con la actual
+func (c *HistoryController) GetHistory(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+
+	history, err := c.service.List(ctx, id)
+	if err != nil {
+		writeHistoryError(ctx, err)
+		return
+	}
+
+	response := gin.H{"history": history}
+	if ctx.Query("verify") == "true" {
+		verification, err := c.service.Verify(ctx, id)
+		if err != nil {
+			writeHistoryError(ctx, err)
+			return
+		}
+		response["verification"] = verification
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func writeHistoryError(ctx *gin.Context, err error) {
+	switch {
+	case strings.Contains(err.Error(), "invalid id format"):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+	case strings.Contains(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "reserva no encontrada"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
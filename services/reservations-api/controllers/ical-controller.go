@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reservations-api/config"
+	"reservations-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ICalController struct {
+	service services.ICalService
+}
+
+func NewICalController(service services.ICalService) *ICalController {
+	return &ICalController{service: service}
+}
+
+// GetRoomCalendar devuelve las noches reservadas de la habitación como feed iCal (RFC 5545)
+func (c *ICalController) GetRoomCalendar(ctx *gin.Context) {
+	roomID, err := strconv.ParseUint(ctx.Param("room_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "room_id invalido"})
+		return
+	}
+
+	calendar, err := c.service.ExportRoom(ctx, uint(roomID))
+	if err != nil {
+		if errors.Is(err, config.ErrRoomNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "habitación no encontrada"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"room-%d.ics\"", roomID))
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
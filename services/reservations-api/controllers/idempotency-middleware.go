@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reservations-api/domain"
+	"reservations-api/services"
+	"reservations-api/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// bodyCapturingWriter copia la respuesta para poder guardarla
+type bodyCapturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyCapturingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCapturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware hace que los reintentos con el mismo header
+// Idempotency-Key devuelvan la respuesta original en lugar de repetir la
+// operación. Misma clave con otro payload => 422; request en curso => 409.
+func IdempotencyMiddleware(service services.IdempotencyService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := strings.TrimSpace(ctx.GetHeader(IdempotencyKeyHeader))
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key demasiado larga"})
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "payload invalido"})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// La clave se limita a la ruta y a quién llama, para que no choque
+		// entre endpoints ni un cliente reciba la respuesta guardada de otro
+		scopedKey := ctx.Request.Method + " " + ctx.FullPath() + " " + callerScope(ctx, body) + ":" + key
+		fingerprint := requestFingerprint(ctx.Request.Method, ctx.FullPath(), body)
+
+		record, replay, err := service.Begin(ctx, scopedKey, fingerprint)
+		if err != nil {
+			switch {
+			case errors.Is(err, utils.ErrIdempotencyKeyReused):
+				ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			case errors.Is(err, utils.ErrIdempotencyInProgress):
+				ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			default:
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		if replay {
+			ctx.Header(idempotentReplayedHeader, "true")
+			ctx.Data(record.ResponseStatus, "application/json; charset=utf-8", record.ResponseBody)
+			ctx.Abort()
+			return
+		}
+
+		writer := &bodyCapturingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+
+		// El cliente pudo haber cortado por timeout: guardar igual el resultado
+		storeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			// Los errores del servidor no se memorizan para permitir reintentar
+			if err := service.Release(storeCtx, scopedKey); err != nil {
+				log.Printf("Error liberando Idempotency-Key %s: %v", key, err)
+			}
+			return
+		}
+		if err := service.Complete(storeCtx, scopedKey, status, writer.body.Bytes()); err != nil {
+			log.Printf("Error guardando respuesta de Idempotency-Key %s: %v", key, err)
+		}
+	}
+}
+
+// callerScope identifica a quién llama: el usuario del JWT o, en requests
+// anónimos, el user_id del payload
+func callerScope(ctx *gin.Context, body []byte) string {
+	actor := utils.ActorFromContext(ctx)
+	if actor.Type == domain.ActorTypeUser {
+		return fmt.Sprintf("user:%d", actor.UserID)
+	}
+
+	var payload struct {
+		UserID uint `json:"user_id"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	return fmt.Sprintf("anonymous:%d", payload.UserID)
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte(path))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reservations-api/domain"
+	"reservations-api/services"
+	"reservations-api/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceController struct {
+	folios   services.FolioService
+	invoices services.InvoiceService
+}
+
+func NewInvoiceController(folios services.FolioService, invoices services.InvoiceService) *InvoiceController {
+	return &InvoiceController{folios: folios, invoices: invoices}
+}
+
+func (c *InvoiceController) GetFolio(ctx *gin.Context) {
+	folio, err := c.folios.Get(ctx, strings.TrimSpace(ctx.Param("id")))
+	if err != nil {
+		writeInvoiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"folio": folio})
+}
+
+func (c *InvoiceController) AddCharge(ctx *gin.Context) {
+	var req domain.AddFolioChargeDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	charge, err := c.folios.AddCharge(ctx, strings.TrimSpace(ctx.Param("id")), req)
+	if err != nil {
+		writeInvoiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"charge": charge})
+}
+
+// GetInvoice devuelve la factura vigente (emitiéndola la primera vez) en
+// JSON o PDF según ?format= o el header Accept
+func (c *InvoiceController) GetInvoice(ctx *gin.Context) {
+	format := strings.ToLower(ctx.DefaultQuery("format", ""))
+	if format == "" {
+		format = "json"
+		if strings.Contains(ctx.GetHeader("Accept"), "application/pdf") {
+			format = "pdf"
+		}
+	}
+	if format != "json" && format != "pdf" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "format debe ser json o pdf"})
+		return
+	}
+
+	invoice, err := c.invoices.Current(ctx, strings.TrimSpace(ctx.Param("id")))
+	if err != nil {
+		writeInvoiceError(ctx, err)
+		return
+	}
+
+	if format == "pdf" {
+		cfg := c.invoices.Config()
+		ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", invoice.Number+".pdf"))
+		ctx.Data(http.StatusOK, "application/pdf", utils.RenderInvoicePDF(invoice, cfg.IssuerName, cfg.IssuerTaxID))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"invoice": invoice})
+}
+
+func (c *InvoiceController) ListInvoices(ctx *gin.Context) {
+	invoices, err := c.invoices.List(ctx, strings.TrimSpace(ctx.Param("id")))
+	if err != nil {
+		writeInvoiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"invoices": invoices})
+}
+
+func (c *InvoiceController) CreateCreditNote(ctx *gin.Context) {
+	var req domain.CancelReservationDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	creditNote, err := c.invoices.CreditNote(ctx, strings.TrimSpace(ctx.Param("id")), req.Reason)
+	if err != nil {
+		writeInvoiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"credit_note": creditNote})
+}
+
+func writeInvoiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, utils.ErrInvalidReservationData):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrInvoiceNotAvailable),
+		errors.Is(err, utils.ErrFolioClosed):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrInvoiceNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "reserva no encontrada"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
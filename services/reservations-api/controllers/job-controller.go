@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"net/http"
+	"reservations-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobController struct {
+	service services.JobService
+}
+
+func NewJobController(service services.JobService) *JobController {
+	return &JobController{service: service}
+}
+
+// GetJobs expone la réplica líder del scheduler y la última corrida de cada job
+func (c *JobController) GetJobs(ctx *gin.Context) {
+	status, err := c.service.Status(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, status)
+}
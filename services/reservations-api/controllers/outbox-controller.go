@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"net/http"
+	"reservations-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OutboxController struct {
+	service services.OutboxService
+}
+
+func NewOutboxController(service services.OutboxService) *OutboxController {
+	return &OutboxController{service: service}
+}
+
+// GetStats expone el backlog del outbox (pendientes, con fallos y el más antiguo)
+func (c *OutboxController) GetStats(ctx *gin.Context) {
+	stats, err := c.service.Stats(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reservations-api/domain"
+	"reservations-api/payments"
+	"reservations-api/services"
+	"reservations-api/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentController struct {
+	service services.PaymentService
+}
+
+func NewPaymentController(service services.PaymentService) *PaymentController {
+	return &PaymentController{service: service}
+}
+
+func (c *PaymentController) AuthorizePayment(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	var req domain.AuthorizePaymentDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment, err := c.service.Authorize(ctx, id, req)
+	if err != nil {
+		writePaymentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"payment": payment})
+}
+
+func (c *PaymentController) GetReservationPayments(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	list, err := c.service.GetByReservation(ctx, id)
+	if err != nil {
+		writePaymentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"payments": list})
+}
+
+func (c *PaymentController) CapturePayment(ctx *gin.Context) {
+	c.applyAmount(ctx, c.service.Capture)
+}
+
+func (c *PaymentController) RefundPayment(ctx *gin.Context) {
+	c.applyAmount(ctx, c.service.Refund)
+}
+
+func (c *PaymentController) VoidPayment(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("payment_id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "payment_id invalido"})
+		return
+	}
+
+	payment, err := c.service.Void(ctx, id)
+	if err != nil {
+		writePaymentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"payment": payment})
+}
+
+// HandleWebhook recibe los callbacks del proveedor de pagos. Responde 200
+// también para eventos duplicados para que el proveedor no reintente.
+func (c *PaymentController) HandleWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "payload invalido"})
+		return
+	}
+
+	processed, err := c.service.HandleWebhook(ctx, payload, ctx.GetHeader("X-Payment-Signature"))
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		writePaymentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"processed": processed})
+}
+
+func (c *PaymentController) applyAmount(ctx *gin.Context, apply func(ctx context.Context, id string, amount float64) (domain.Payment, error)) {
+	id := strings.TrimSpace(ctx.Param("payment_id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "payment_id invalido"})
+		return
+	}
+
+	// El body es opcional: sin monto se usa el saldo disponible
+	var req domain.PaymentAmountDTO
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	payment, err := apply(ctx, id, req.Amount)
+	if err != nil {
+		writePaymentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"payment": payment})
+}
+
+func writePaymentError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, utils.ErrPaymentDeclined):
+		ctx.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrReservationNotPayable),
+		errors.Is(err, utils.ErrPaymentConflict),
+		errors.Is(err, utils.ErrPaymentInProgress),
+		errors.Is(err, payments.ErrInvalidState):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, payments.ErrInvalidAmount):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrPaymentNotFound),
+		errors.Is(err, payments.ErrUnknownReference):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "reserva no encontrada"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
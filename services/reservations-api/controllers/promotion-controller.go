@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"reservations-api/domain"
+	"reservations-api/services"
+	"reservations-api/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionController struct {
+	service services.PromotionService
+}
+
+func NewPromotionController(service services.PromotionService) *PromotionController {
+	return &PromotionController{service: service}
+}
+
+func (c *PromotionController) CreatePromotion(ctx *gin.Context) {
+	var req domain.CreatePromotionDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promotion, err := c.service.Create(ctx, req)
+	if err != nil {
+		writePromotionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"promotion": promotion})
+}
+
+func (c *PromotionController) ListPromotions(ctx *gin.Context) {
+	promotions, err := c.service.List(ctx)
+	if err != nil {
+		writePromotionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"promotions": promotions})
+}
+
+func (c *PromotionController) GetPromotion(ctx *gin.Context) {
+	promotion, err := c.service.Get(ctx, strings.TrimSpace(ctx.Param("id")))
+	if err != nil {
+		writePromotionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"promotion": promotion})
+}
+
+func (c *PromotionController) UpdatePromotion(ctx *gin.Context) {
+	var req domain.UpdatePromotionDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promotion, err := c.service.Update(ctx, strings.TrimSpace(ctx.Param("id")), req)
+	if err != nil {
+		writePromotionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"promotion": promotion})
+}
+
+// DeactivatePromotion da de baja el código; los descuentos ya aplicados no cambian
+func (c *PromotionController) DeactivatePromotion(ctx *gin.Context) {
+	if err := c.service.Deactivate(ctx, strings.TrimSpace(ctx.Param("id"))); err != nil {
+		writePromotionError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func writePromotionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, utils.ErrInvalidReservationData):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrPromotionNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrPromoCodeTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"reservations-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController struct {
+	service services.ReportService
+}
+
+func NewReportController(service services.ReportService) *ReportController {
+	return &ReportController{service: service}
+}
+
+// GetPerformance devuelve ocupación, ADR, RevPAR, lead time y tasa de
+// cancelación para [from, to) en JSON o CSV (format=csv)
+func (c *ReportController) GetPerformance(ctx *gin.Context) {
+	from, to, format, ok := parseReportQuery(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := c.service.Performance(ctx, from, to)
+	if err != nil {
+		writeAvailabilityError(ctx, err)
+		return
+	}
+
+	if format == "json" {
+		ctx.JSON(http.StatusOK, report)
+		return
+	}
+	writeCSV(ctx, fmt.Sprintf("performance_%s_%s.csv", from, to), [][]string{
+		{"from", "to", "currency", "rooms", "available_room_nights", "sold_room_nights", "revenue",
+			"occupancy_rate", "adr", "revpar", "bookings", "cancellations", "cancellation_rate", "avg_lead_time_days"},
+		{report.From, report.To, report.Currency, strconv.Itoa(report.Rooms),
+			strconv.Itoa(report.AvailableRoomNights), strconv.Itoa(report.SoldRoomNights), formatFloat(report.Revenue),
+			formatFloat(report.OccupancyRate), formatFloat(report.ADR), formatFloat(report.RevPAR),
+			strconv.FormatInt(report.Bookings, 10), strconv.FormatInt(report.Cancellations, 10),
+			formatFloat(report.CancellationRate), formatFloat(report.AvgLeadTimeDays)},
+	})
+}
+
+// GetRevenue devuelve los indicadores agrupados por tipo (group_by=type) o piso (group_by=floor)
+func (c *ReportController) GetRevenue(ctx *gin.Context) {
+	from, to, format, ok := parseReportQuery(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := c.service.Revenue(ctx, from, to, strings.TrimSpace(ctx.Query("group_by")))
+	if err != nil {
+		writeAvailabilityError(ctx, err)
+		return
+	}
+
+	if format == "json" {
+		ctx.JSON(http.StatusOK, report)
+		return
+	}
+	records := [][]string{{report.GroupBy, "rooms", "available_room_nights", "sold_room_nights",
+		"revenue", "occupancy_rate", "adr", "revpar", "currency"}}
+	for _, row := range report.Rows {
+		records = append(records, []string{row.Key, strconv.Itoa(row.Rooms),
+			strconv.Itoa(row.AvailableRoomNights), strconv.Itoa(row.SoldRoomNights), formatFloat(row.Revenue),
+			formatFloat(row.OccupancyRate), formatFloat(row.ADR), formatFloat(row.RevPAR), report.Currency})
+	}
+	writeCSV(ctx, fmt.Sprintf("revenue_by_%s_%s_%s.csv", report.GroupBy, from, to), records)
+}
+
+func parseReportQuery(ctx *gin.Context) (from, to, format string, ok bool) {
+	from = strings.TrimSpace(ctx.Query("from"))
+	to = strings.TrimSpace(ctx.Query("to"))
+	if from == "" || to == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "from y to son requeridos"})
+		return "", "", "", false
+	}
+
+	format = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("format", "json")))
+	if format != "json" && format != "csv" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "format debe ser json o csv"})
+		return "", "", "", false
+	}
+	return from, to, format, true
+}
+
+func writeCSV(ctx *gin.Context, filename string, records [][]string) {
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Status(http.StatusOK)
+
+	writer := csv.NewWriter(ctx.Writer)
+	if err := writer.WriteAll(records); err != nil {
+		ctx.Error(err)
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
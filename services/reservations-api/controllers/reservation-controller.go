@@ -1,13 +1,17 @@
 package controllers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"reservations-api/config"
 	"reservations-api/domain"
 	"reservations-api/services"
 	"reservations-api/utils"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,21 +35,102 @@ func (c *ReservationController) GetmyReservations(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "user_id invalido"})
 		return
 	}
-	reservations, err := c.service.GetmyReservations(ctx, uint(userID))
+
+	query, err := parseListQuery(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"reservations": reservations})
+	uid := uint(userID)
+	query.UserID = &uid
+
+	c.listReservations(ctx, query)
 }
 
 func (c *ReservationController) GetAllReservations(ctx *gin.Context) {
-	reservations, err := c.service.GetAllReservations(ctx)
+	query, err := parseListQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.listReservations(ctx, query)
+}
+
+func (c *ReservationController) listReservations(ctx *gin.Context, query domain.ReservationListQuery) {
+	page, err := c.service.ListReservations(ctx, query)
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidReservationData) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"reservations": reservations})
+	ctx.JSON(http.StatusOK, page)
+}
+
+// parseListQuery lee los filtros de listado: status (separados por coma),
+// room_id, from, to, created_after (RFC3339), sort, limit, cursor e include_total
+func parseListQuery(ctx *gin.Context) (domain.ReservationListQuery, error) {
+	var query domain.ReservationListQuery
+
+	if raw := strings.TrimSpace(ctx.Query("status")); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			status := domain.ReservationStatus(strings.TrimSpace(part))
+			switch status {
+			case domain.ReservationStatusPending, domain.ReservationStatusActive,
+				domain.ReservationStatusCanceled, domain.ReservationStatusExpired,
+				domain.ReservationStatusNoShow, domain.ReservationStatusCompleted:
+				query.Statuses = append(query.Statuses, status)
+			default:
+				return query, fmt.Errorf("status invalido: %s", status)
+			}
+		}
+	}
+
+	if raw := strings.TrimSpace(ctx.Query("room_id")); raw != "" {
+		roomID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return query, fmt.Errorf("room_id invalido")
+		}
+		id := uint(roomID)
+		query.RoomID = &id
+	}
+
+	query.From = strings.TrimSpace(ctx.Query("from"))
+	if query.From != "" {
+		if _, err := time.Parse(utils.DateLayout, query.From); err != nil {
+			return query, fmt.Errorf("from invalida, formato esperado %s", utils.DateLayout)
+		}
+	}
+	query.To = strings.TrimSpace(ctx.Query("to"))
+	if query.To != "" {
+		if _, err := time.Parse(utils.DateLayout, query.To); err != nil {
+			return query, fmt.Errorf("to invalida, formato esperado %s", utils.DateLayout)
+		}
+	}
+
+	if raw := strings.TrimSpace(ctx.Query("created_after")); raw != "" {
+		createdAfter, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return query, fmt.Errorf("created_after invalido, formato esperado RFC3339")
+		}
+		query.CreatedAfter = &createdAfter
+	}
+
+	if raw := strings.TrimSpace(ctx.Query("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return query, fmt.Errorf("limit invalido")
+		}
+		query.Limit = limit
+	}
+
+	query.Sort = strings.TrimSpace(ctx.Query("sort"))
+	query.Cursor = strings.TrimSpace(ctx.Query("cursor"))
+	query.IncludeTotal = ctx.Query("include_total") == "true"
+
+	return query, nil
 }
 
 func (c *ReservationController) CreateReservation(ctx *gin.Context) {
@@ -57,7 +142,11 @@ func (c *ReservationController) CreateReservation(ctx *gin.Context) {
 	created, err := c.service.CreateReservation(ctx, req)
 	if err != nil {
 		if errors.Is(err, utils.ErrReservationConflict) {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			// Sin lugar: indicar que el huésped puede anotarse en la lista de espera
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "waitlist": "/api/waitlist"})
+			return
+		}
+		if writePromoCodeError(ctx, err) {
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -80,6 +169,10 @@ func (c *ReservationController) DeleteReservation(ctx *gin.Context) {
 
 	err := c.service.DeleteReservation(ctx, id, body.Reason)
 	if err != nil {
+		if errors.Is(err, utils.ErrCancelPolicyUnknown) {
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "reserva no encontrada"})
 			return
@@ -108,3 +201,147 @@ func (c *ReservationController) GetReservationByID(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, gin.H{"reservation": dto})
 }
+
+// QuoteReservation devuelve el precio de la estadía con el descuento del
+// promo_code aplicado, sin reservar ni consumir usos del código
+func (c *ReservationController) QuoteReservation(ctx *gin.Context) {
+	var req domain.CreateReservationDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	quote, err := c.service.QuoteReservation(ctx, req)
+	if err != nil {
+		if errors.Is(err, config.ErrRoomNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "habitación no encontrada"})
+			return
+		}
+		if writePromoCodeError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"quote": quote})
+}
+
+// writePromoCodeError responde los errores de fechas y de código promocional;
+// devuelve false si el error es de otro tipo
+func writePromoCodeError(ctx *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, utils.ErrInvalidReservationData):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrPromoCodeNotApplicable):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrPromoCodeExhausted):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+func (c *ReservationController) CreateHold(ctx *gin.Context) {
+	var req domain.CreateReservationDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	hold, err := c.service.CreateHold(ctx, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrReservationConflict) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if writePromoCodeError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"reservation": hold})
+}
+
+func (c *ReservationController) ConfirmReservation(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	dto, err := c.service.ConfirmReservation(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrHoldExpired):
+			ctx.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		case errors.Is(err, utils.ErrPaymentRequired):
+			ctx.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+		case errors.Is(err, utils.ErrReservationNotPending):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "reserva no encontrada"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"reservation": dto})
+}
+
+func (c *ReservationController) CheckIn(ctx *gin.Context) {
+	c.recordStay(ctx, c.service.CheckIn, utils.ErrCheckInNotAllowed)
+}
+
+func (c *ReservationController) CheckOut(ctx *gin.Context) {
+	c.recordStay(ctx, c.service.CheckOut, utils.ErrCheckOutNotAllowed)
+}
+
+func (c *ReservationController) recordStay(
+	ctx *gin.Context,
+	record func(context.Context, string) (domain.ReservationResponseDTO, error),
+	notAllowed error,
+) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	dto, err := record(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, notAllowed):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "reserva no encontrada"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"reservation": dto})
+}
+
+func (c *ReservationController) GetCancellationPreview(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	quote, err := c.service.CancellationPreview(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrReservationNotCancelable):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, utils.ErrCancelPolicyUnknown):
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "reserva no encontrada"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"cancellation": quote})
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"reservations-api/domain"
+	"reservations-api/services"
+	"reservations-api/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistController struct {
+	service services.WaitlistService
+}
+
+func NewWaitlistController(service services.WaitlistService) *WaitlistController {
+	return &WaitlistController{service: service}
+}
+
+func (c *WaitlistController) JoinWaitlist(ctx *gin.Context) {
+	var req domain.CreateWaitlistDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := c.service.Join(ctx, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidReservationData) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"waitlist_entry": entry})
+}
+
+func (c *WaitlistController) GetWaitlistEntry(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	entry, err := c.service.Get(ctx, id)
+	if err != nil {
+		writeWaitlistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"waitlist_entry": entry})
+}
+
+func (c *WaitlistController) CancelWaitlistEntry(ctx *gin.Context) {
+	id := strings.TrimSpace(ctx.Param("id"))
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	if err := c.service.Cancel(ctx, id); err != nil {
+		writeWaitlistError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func writeWaitlistError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, utils.ErrWaitlistEntryNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrWaitlistEntryNotActive):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
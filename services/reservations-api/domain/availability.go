@@ -0,0 +1,40 @@
+package domain
+
+type NightStatus string
+
+const (
+	NightStatusFree   NightStatus = "free"
+	NightStatusBooked NightStatus = "booked"
+	NightStatusHeld   NightStatus = "held"
+)
+
+// RoomNight es el estado de una noche (fecha de check-in de esa noche)
+type RoomNight struct {
+	Date          string      `json:"date"`
+	Status        NightStatus `json:"status"`
+	ReservationID string      `json:"reservation_id,omitempty"`
+}
+
+type RoomAvailability struct {
+	RoomID uint        `json:"room_id"`
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Nights []RoomNight `json:"nights"`
+}
+
+// TypeNight cuenta las habitaciones de un tipo en cada estado para una noche
+type TypeNight struct {
+	Date   string `json:"date"`
+	Total  int    `json:"total"`
+	Free   int    `json:"free"`
+	Booked int    `json:"booked"`
+	Held   int    `json:"held"`
+}
+
+type TypeAvailability struct {
+	Type    string      `json:"type"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	RoomIDs []uint      `json:"room_ids"`
+	Nights  []TypeNight `json:"nights"`
+}
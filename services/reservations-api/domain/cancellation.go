@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+type CancellationPolicyType string
+
+const (
+	CancellationPolicyFlexible      CancellationPolicyType = "flexible"
+	CancellationPolicyModerate      CancellationPolicyType = "moderate"
+	CancellationPolicyNonRefundable CancellationPolicyType = "non_refundable"
+)
+
+// CancellationTier indica qué porcentaje se reembolsa si se cancela con al
+// menos MinHoursBefore horas de anticipación al check-in
+type CancellationTier struct {
+	MinHoursBefore float64 `json:"min_hours_before"`
+	RefundPercent  float64 `json:"refund_percent"`
+}
+
+type CancellationPolicy struct {
+	Type  CancellationPolicyType `json:"type"`
+	Tiers []CancellationTier     `json:"tiers"`
+}
+
+// DefaultCancellationPolicies son las políticas disponibles para asignar por tipo de habitación
+var DefaultCancellationPolicies = map[CancellationPolicyType]CancellationPolicy{
+	CancellationPolicyFlexible: {
+		Type: CancellationPolicyFlexible,
+		Tiers: []CancellationTier{
+			{MinHoursBefore: 24, RefundPercent: 100},
+			{MinHoursBefore: 0, RefundPercent: 50},
+		},
+	},
+	CancellationPolicyModerate: {
+		Type: CancellationPolicyModerate,
+		Tiers: []CancellationTier{
+			{MinHoursBefore: 5 * 24, RefundPercent: 100},
+			{MinHoursBefore: 24, RefundPercent: 50},
+		},
+	},
+	CancellationPolicyNonRefundable: {
+		Type:  CancellationPolicyNonRefundable,
+		Tiers: []CancellationTier{},
+	},
+}
+
+// RefundPercent devuelve el porcentaje reembolsable según la anticipación.
+// Si no aplica ningún tramo (por ejemplo, la estadía ya empezó) es 0.
+func (p CancellationPolicy) RefundPercent(hoursBefore float64) float64 {
+	tiers := make([]CancellationTier, len(p.Tiers))
+	copy(tiers, p.Tiers)
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinHoursBefore > tiers[j].MinHoursBefore
+	})
+
+	for _, tier := range tiers {
+		if hoursBefore >= tier.MinHoursBefore {
+			return tier.RefundPercent
+		}
+	}
+	return 0
+}
+
+// CancellationQuote es el cálculo de reembolso y penalidad de una cancelación
+type CancellationQuote struct {
+	ReservationID      string                 `bson:"reservation_id" json:"reservation_id"`
+	Policy             CancellationPolicyType `bson:"policy" json:"policy"`
+	HoursBeforeCheckIn float64                `bson:"hours_before_check_in" json:"hours_before_check_in"`
+	RefundPercent      float64                `bson:"refund_percent" json:"refund_percent"`
+	Currency           string                 `bson:"currency" json:"currency"`
+	ReservationTotal   float64                `bson:"reservation_total" json:"reservation_total"`
+	AmountPaid         float64                `bson:"amount_paid" json:"amount_paid"`
+	PenaltyAmount      float64                `bson:"penalty_amount" json:"penalty_amount"`
+	RefundAmount       float64                `bson:"refund_amount" json:"refund_amount"`
+	CalculatedAt       time.Time              `bson:"calculated_at" json:"calculated_at"`
+}
+
+// PaymentSummary resume lo cobrado de una reserva
+type PaymentSummary struct {
+	Currency         string  `json:"currency"`
+	ReservationTotal float64 `json:"reservation_total"`
+	AmountPaid       float64 `json:"amount_paid"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+	// Modo estructurado del binding AMQP: el envelope completo va en el body
+	CloudEventsContentType = "application/cloudevents+json"
+)
+
+// Esquemas versionados de `data` (ver contracts/events). La versión 1 de
+// cada esquema es el payload que se publicaba antes del envelope.
+const (
+	ReservationEventSchemaV1 = "urn:hotel:events:reservation:v1"
+	WaitlistEventSchemaV1    = "urn:hotel:events:waitlist:v1"
+	RoomEventSchemaV1        = "urn:hotel:events:room:v1"
+	RoomEventSchemaV2        = "urn:hotel:events:room:v2"
+)
+
+// CloudEvent es el envelope CloudEvents 1.0 de todos los eventos del sistema
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema"`
+	Data            json.RawMessage `json:"data"`
+}
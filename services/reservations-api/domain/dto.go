@@ -1,22 +1,120 @@
 package domain
 
+import "time"
+
 // Gin parsea strings JSON a time.Time usando time_format
 type CreateReservationDTO struct {
 	UserID    uint   `json:"user_id"    binding:"required"`
 	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
 	EndDate   string `json:"end_date"   binding:"required,datetime=2006-01-02"`
 	RoomID    uint   `json:"room_id"    binding:"required"`
+	PromoCode string `json:"promo_code"`
+}
+
+// CreateWaitlistDTO anota a un huésped en espera por una habitación
+// puntual (room_id) o por cualquiera de un tipo (room_type)
+type CreateWaitlistDTO struct {
+	UserID    uint   `json:"user_id"    binding:"required"`
+	RoomID    *uint  `json:"room_id"`
+	RoomType  string `json:"room_type"`
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date"   binding:"required,datetime=2006-01-02"`
+}
+
+// CreateGroupReservationDTO reserva varias habitaciones para las mismas
+// fechas; se crean todas o ninguna
+type CreateGroupReservationDTO struct {
+	UserID    uint           `json:"user_id"    binding:"required"`
+	Name      string         `json:"name"       binding:"required"`
+	StartDate string         `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string         `json:"end_date"   binding:"required,datetime=2006-01-02"`
+	Rooms     []GroupRoomDTO `json:"rooms"      binding:"required,min=1,dive"`
+}
+
+type GroupRoomDTO struct {
+	RoomID    uint   `json:"room_id"    binding:"required"`
+	GuestName string `json:"guest_name" binding:"required"`
+}
+
+// UpdateGroupReservationDTO cambia las fechas de todo el grupo y/o el
+// huésped de algunas habitaciones
+type UpdateGroupReservationDTO struct {
+	StartDate *string        `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   *string        `json:"end_date"   binding:"omitempty,datetime=2006-01-02"`
+	Rooms     []GroupRoomDTO `json:"rooms"      binding:"omitempty,dive"`
 }
 
 type ReservationResponseDTO struct {
-	ID        string `json:"id"`
-	UserID    uint   `json:"user_id"`
-	StartDate string `json:"start_date"`
-	EndDate   string `json:"end_date"`
-	RoomID    uint   `json:"room_id"`
-	Status    string `json:"status"`
+	ID        string     `json:"id"`
+	UserID    uint       `json:"user_id"`
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	RoomID    uint       `json:"room_id"`
+	Status    string     `json:"status"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CheckedInAt  *time.Time `json:"checked_in_at,omitempty"`
+	CheckedOutAt *time.Time `json:"checked_out_at,omitempty"`
+
+	NeedsRelocation bool `json:"needs_relocation,omitempty"`
+
+	GroupID   *string `json:"group_id,omitempty"`
+	GuestName string  `json:"guest_name,omitempty"`
+
+	Pricing            *ReservationPricing    `json:"pricing,omitempty"`
+	CancellationPolicy CancellationPolicyType `json:"cancellation_policy,omitempty"`
+}
+
+// CreatePromotionDTO da de alta un código de descuento; los límites en 0
+// significan "sin límite"
+type CreatePromotionDTO struct {
+	Code           string       `json:"code"              binding:"required,min=3,max=32,alphanum"`
+	Description    string       `json:"description"`
+	DiscountType   DiscountType `json:"discount_type"     binding:"required,oneof=percentage fixed"`
+	Value          float64      `json:"value"             binding:"required,gt=0"`
+	ValidFrom      time.Time    `json:"valid_from"        binding:"required"`
+	ValidUntil     time.Time    `json:"valid_until"       binding:"required"`
+	MinNights      int          `json:"min_nights"        binding:"min=0"`
+	RoomTypes      []string     `json:"room_types"`
+	MaxUses        int          `json:"max_uses"          binding:"min=0"`
+	MaxUsesPerUser int          `json:"max_uses_per_user" binding:"min=0"`
+}
+
+// UpdatePromotionDTO cambia los campos enviados; el código no se puede cambiar
+type UpdatePromotionDTO struct {
+	Description    *string       `json:"description"`
+	DiscountType   *DiscountType `json:"discount_type"     binding:"omitempty,oneof=percentage fixed"`
+	Value          *float64      `json:"value"             binding:"omitempty,gt=0"`
+	ValidFrom      *time.Time    `json:"valid_from"`
+	ValidUntil     *time.Time    `json:"valid_until"`
+	MinNights      *int          `json:"min_nights"        binding:"omitempty,min=0"`
+	RoomTypes      *[]string     `json:"room_types"`
+	MaxUses        *int          `json:"max_uses"          binding:"omitempty,min=0"`
+	MaxUsesPerUser *int          `json:"max_uses_per_user" binding:"omitempty,min=0"`
+	Active         *bool         `json:"active"`
+}
+
+// AddFolioChargeDTO carga un extra a la cuenta de la reserva. Date por
+// defecto es hoy y TaxRate la alícuota configurada.
+type AddFolioChargeDTO struct {
+	Description string   `json:"description" binding:"required"`
+	Date        string   `json:"date"        binding:"omitempty,datetime=2006-01-02"`
+	Quantity    int      `json:"quantity"    binding:"required,min=1"`
+	UnitPrice   float64  `json:"unit_price"  binding:"required,gt=0"`
+	TaxRate     *float64 `json:"tax_rate"    binding:"omitempty,min=0,max=100"`
 }
 
 type CancelReservationDTO struct {
 	Reason string `json:"reason" binding:"required"`
 }
+
+type AuthorizePaymentDTO struct {
+	Policy       PaymentPolicy `json:"policy"        binding:"required,oneof=deposit full"`
+	PaymentToken string        `json:"payment_token" binding:"required"`
+}
+
+// PaymentAmountDTO permite capturar o reembolsar un monto parcial;
+// si Amount es 0 se usa el saldo disponible
+type PaymentAmountDTO struct {
+	Amount float64 `json:"amount" binding:"min=0"`
+}
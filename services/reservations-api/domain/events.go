@@ -5,18 +5,44 @@ import "time"
 type EventType string
 
 const (
-	EventReservationCreated  EventType = "reservation.created"
-	EventReservationCanceled EventType = "reservation.canceled"
+	EventReservationCreated    EventType = "reservation.created"
+	EventReservationCanceled   EventType = "reservation.canceled"
+	EventReservationConfirmed  EventType = "reservation.confirmed"
+	EventReservationExpired    EventType = "reservation.expired"
+	EventReservationCheckedIn  EventType = "reservation.checked_in"
+	EventReservationCheckedOut EventType = "reservation.checked_out"
+	// Cierres automáticos del scheduler
+	EventReservationNoShow    EventType = "reservation.no_show"
+	EventReservationCompleted EventType = "reservation.completed"
+	// Cambio de fechas o de huésped de una reserva existente
+	EventReservationModified EventType = "reservation.modified"
+	// La habitación reservada ya no está disponible; requiere intervención si no se reubica
+	EventReservationNeedsRelocation EventType = "reservation.needs_relocation"
+	EventReservationRelocated       EventType = "reservation.relocated"
 )
 
 type ReservationEvent struct {
-	EventType     EventType `json:"event_type"`
-	ReservationID string    `json:"reservation_id"`
-	UserID        uint      `json:"user_id"`
-	RoomID        uint      `json:"room_id"`
-	StartDate     string    `json:"start_date"`
-	EndDate       string    `json:"end_date"`
-	Status        string    `json:"status"`
-	CancelReason  *string   `json:"cancel_reason,omitempty"`
-	Timestamp     time.Time `json:"timestamp"`
+	EventType     EventType   `json:"event_type"`
+	ReservationID string      `json:"reservation_id"`
+	UserID        uint        `json:"user_id"`
+	RoomID        uint        `json:"room_id"`
+	StartDate     string      `json:"start_date"`
+	EndDate       string      `json:"end_date"`
+	Status        string      `json:"status"`
+	GroupID       *string     `json:"group_id,omitempty"`
+	CancelReason  *string     `json:"cancel_reason,omitempty"`
+	Refund        *RefundInfo `json:"refund,omitempty"`
+	// Solo en eventos de reubicación
+	PreviousRoomID   *uint             `json:"previous_room_id,omitempty"`
+	RelocationReason *RelocationReason `json:"relocation_reason,omitempty"`
+	Timestamp        time.Time         `json:"timestamp"`
+}
+
+// RefundInfo resume el reembolso calculado al cancelar una reserva
+type RefundInfo struct {
+	Policy        CancellationPolicyType `json:"policy"`
+	Currency      string                 `json:"currency"`
+	RefundPercent float64                `json:"refund_percent"`
+	RefundAmount  float64                `json:"refund_amount"`
+	PenaltyAmount float64                `json:"penalty_amount"`
 }
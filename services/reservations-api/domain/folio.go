@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FolioLineType string
+
+const (
+	FolioLineRoomNights FolioLineType = "room_nights"
+	FolioLineDiscount   FolioLineType = "discount"
+	FolioLineExtra      FolioLineType = "extra"
+	FolioLinePayment    FolioLineType = "payment"
+	FolioLineRefund     FolioLineType = "refund"
+)
+
+// FolioCharge es un consumo extra cargado a la reserva (minibar, lavandería, etc.)
+type FolioCharge struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ReservationID string             `bson:"reservation_id" json:"reservation_id"`
+	Description   string             `bson:"description" json:"description"`
+	Date          string             `bson:"date" json:"date"`
+	Quantity      int                `bson:"quantity" json:"quantity"`
+	UnitPrice     float64            `bson:"unit_price" json:"unit_price"`
+	Amount        float64            `bson:"amount" json:"amount"`
+	TaxRate       float64            `bson:"tax_rate" json:"tax_rate"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// FolioLine es un movimiento de la cuenta del huésped. Los cargos son
+// positivos; descuentos, pagos y reembolsos llevan el signo que corresponde.
+// Los montos incluyen impuestos.
+type FolioLine struct {
+	Type        FolioLineType `json:"type"`
+	Description string        `json:"description"`
+	Date        string        `json:"date,omitempty"`
+	Quantity    int           `json:"quantity,omitempty"`
+	UnitPrice   float64       `json:"unit_price,omitempty"`
+	Amount      float64       `json:"amount"`
+	TaxRate     float64       `json:"tax_rate,omitempty"`
+}
+
+// Folio es la cuenta de la reserva: alojamiento, extras y pagos
+type Folio struct {
+	ReservationID string      `json:"reservation_id"`
+	Currency      string      `json:"currency"`
+	Lines         []FolioLine `json:"lines"`
+	Charges       float64     `json:"charges"`
+	Paid          float64     `json:"paid"`
+	Balance       float64     `json:"balance"`
+}
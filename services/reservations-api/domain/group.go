@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GroupStatus string
+
+const (
+	GroupStatusActive   GroupStatus = "active"
+	GroupStatusCanceled GroupStatus = "canceled"
+)
+
+// GroupBooking agrupa varias reservas (una por habitación) hechas en una
+// sola operación para las mismas fechas. Las habitaciones se crean, se
+// modifican y se cancelan siempre a través del grupo.
+type GroupBooking struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID       uint               `bson:"user_id" json:"user_id"`
+	Name         string             `bson:"name" json:"name"`
+	StartDate    string             `bson:"start_date" json:"start_date"`
+	EndDate      string             `bson:"end_date" json:"end_date"`
+	RoomIDs      []uint             `bson:"room_ids" json:"room_ids"`
+	Status       GroupStatus        `bson:"status" json:"status"`
+	CancelReason *string            `bson:"cancel_reason,omitempty" json:"cancel_reason,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// GroupBookingDetail es el grupo con la reserva de cada habitación
+type GroupBookingDetail struct {
+	Group        GroupBooking             `json:"group"`
+	Reservations []ReservationResponseDTO `json:"reservations"`
+}
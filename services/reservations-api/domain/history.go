@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ActorType string
+
+const (
+	ActorTypeUser ActorType = "user"
+	// Requests sin token
+	ActorTypeAnonymous ActorType = "anonymous"
+	// Jobs, consumers y procesos internos
+	ActorTypeSystem ActorType = "system"
+)
+
+// Roles del JWT que emite users-api
+const (
+	RoleAdmin = "admin"
+	// Personal del hotel (recepción): opera pagos y folios sin ser admin
+	RoleStaff = "staff"
+)
+
+// Actor identifica quién hizo un cambio; para usuarios sale del JWT
+type Actor struct {
+	Type     ActorType `bson:"type" json:"type"`
+	UserID   uint      `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	Username string    `bson:"username,omitempty" json:"username,omitempty"`
+	Role     string    `bson:"role,omitempty" json:"role,omitempty"`
+	// Proceso interno que originó el cambio (p. ej. el job del scheduler)
+	Process string `bson:"process,omitempty" json:"process,omitempty"`
+}
+
+// FieldChange es el cambio de un campo de la reserva; Field es el path con
+// puntos en notación JSON ("pricing.total") y un valor nil indica ausencia
+type FieldChange struct {
+	Field  string      `bson:"field" json:"field"`
+	Before interface{} `bson:"before" json:"before"`
+	After  interface{} `bson:"after" json:"after"`
+}
+
+// HistoryEntry es una entrada del historial de una reserva. Las entradas no
+// se modifican: aplicar los Changes en orden de Sequence reconstruye el estado.
+type HistoryEntry struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ReservationID string             `bson:"reservation_id" json:"reservation_id"`
+	Sequence      int                `bson:"sequence" json:"sequence"`
+	Action        string             `bson:"action" json:"action"`
+	Actor         Actor              `bson:"actor" json:"actor"`
+	Changes       []FieldChange      `bson:"changes" json:"changes"`
+	At            time.Time          `bson:"at" json:"at"`
+}
+
+// HistoryVerification compara el estado reconstruido desde el historial con
+// el documento actual; Mismatches lleva el valor reconstruido en Before y el
+// actual en After
+type HistoryVerification struct {
+	Consistent bool          `json:"consistent"`
+	Entries    int           `json:"entries"`
+	Mismatches []FieldChange `json:"mismatches,omitempty"`
+}
+
+// HistoryActionFor deriva la acción del historial del tipo de evento
+// ("reservation.canceled" → "canceled")
+func HistoryActionFor(eventType EventType) string {
+	return strings.TrimPrefix(string(eventType), "reservation.")
+}
@@ -0,0 +1,24 @@
+package domain
+
+// ICalFeed es un calendario externo cuyos eventos bloquean una habitación
+type ICalFeed struct {
+	RoomID uint
+	URL    string
+}
+
+// ICalEvent es un VEVENT reducido a lo que necesitamos: noches en [StartDate, EndDate)
+type ICalEvent struct {
+	UID       string
+	StartDate string
+	EndDate   string
+	Summary   string
+	Canceled  bool
+}
+
+// ICalImportResult resume la reconciliación de un feed
+type ICalImportResult struct {
+	Added     int
+	Updated   int
+	Removed   int
+	Conflicts int
+}
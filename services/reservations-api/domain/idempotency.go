@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type IdempotencyStatus string
+
+const (
+	IdempotencyStatusInProgress IdempotencyStatus = "in_progress"
+	IdempotencyStatusCompleted  IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord guarda el resultado de un request con Idempotency-Key
+// para devolver la misma respuesta si el cliente reintenta.
+type IdempotencyRecord struct {
+	Key            string            `bson:"_id"`
+	Fingerprint    string            `bson:"fingerprint"`
+	Status         IdempotencyStatus `bson:"status"`
+	ResponseStatus int               `bson:"response_status,omitempty"`
+	ResponseBody   []byte            `bson:"response_body,omitempty"`
+	// LockedUntil evita que un request colgado bloquee la clave para siempre
+	LockedUntil time.Time `bson:"locked_until"`
+	CreatedAt   time.Time `bson:"created_at"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+}
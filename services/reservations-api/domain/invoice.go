@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type InvoiceType string
+
+const (
+	InvoiceTypeInvoice InvoiceType = "invoice"
+	// La nota de crédito anula una factura emitida; las correcciones se
+	// hacen emitiendo una nota de crédito y una factura nueva
+	InvoiceTypeCreditNote InvoiceType = "credit_note"
+)
+
+type InvoiceLine struct {
+	Description string  `bson:"description" json:"description"`
+	Quantity    int     `bson:"quantity" json:"quantity"`
+	UnitPrice   float64 `bson:"unit_price" json:"unit_price"`
+	Amount      float64 `bson:"amount" json:"amount"`
+	TaxRate     float64 `bson:"tax_rate" json:"tax_rate"`
+}
+
+// TaxLine desglosa la base imponible y el impuesto de una alícuota
+type TaxLine struct {
+	Name   string  `bson:"name" json:"name"`
+	Rate   float64 `bson:"rate" json:"rate"`
+	Base   float64 `bson:"base" json:"base"`
+	Amount float64 `bson:"amount" json:"amount"`
+}
+
+// Invoice es una factura o nota de crédito emitida. No se modifica nunca:
+// Revision numera las facturas de la reserva y la nota de crédito de una
+// factura comparte su revisión.
+type Invoice struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Number        string             `bson:"number" json:"number"`
+	Type          InvoiceType        `bson:"type" json:"type"`
+	ReservationID string             `bson:"reservation_id" json:"reservation_id"`
+	Revision      int                `bson:"revision" json:"revision"`
+
+	UserID    uint   `bson:"user_id" json:"user_id"`
+	GuestName string `bson:"guest_name,omitempty" json:"guest_name,omitempty"`
+	RoomID    uint   `bson:"room_id" json:"room_id"`
+	StartDate string `bson:"start_date" json:"start_date"`
+	EndDate   string `bson:"end_date" json:"end_date"`
+
+	Currency   string        `bson:"currency" json:"currency"`
+	Lines      []InvoiceLine `bson:"lines" json:"lines"`
+	TaxLines   []TaxLine     `bson:"tax_lines" json:"tax_lines"`
+	Net        float64       `bson:"net" json:"net"`
+	TaxTotal   float64       `bson:"tax_total" json:"tax_total"`
+	Total      float64       `bson:"total" json:"total"`
+	AmountPaid float64       `bson:"amount_paid" json:"amount_paid"`
+	BalanceDue float64       `bson:"balance_due" json:"balance_due"`
+
+	// Solo en notas de crédito
+	CreditedInvoiceID     *primitive.ObjectID `bson:"credited_invoice_id,omitempty" json:"credited_invoice_id,omitempty"`
+	CreditedInvoiceNumber string              `bson:"credited_invoice_number,omitempty" json:"credited_invoice_number,omitempty"`
+	Reason                string              `bson:"reason,omitempty" json:"reason,omitempty"`
+
+	IssuedAt time.Time `bson:"issued_at" json:"issued_at"`
+}
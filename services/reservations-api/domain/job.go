@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SchedulerLockName es el lock que define qué réplica corre los jobs
+const SchedulerLockName = "scheduler"
+
+type JobRunStatus string
+
+const (
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
+
+// JobRun registra una ejecución de un job del scheduler
+type JobRun struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Job        string             `bson:"job" json:"job"`
+	Owner      string             `bson:"owner" json:"owner"`
+	Status     JobRunStatus       `bson:"status" json:"status"`
+	Processed  int                `bson:"processed" json:"processed"`
+	Error      string             `bson:"error,omitempty" json:"error,omitempty"`
+	StartedAt  time.Time          `bson:"started_at" json:"started_at"`
+	FinishedAt time.Time          `bson:"finished_at" json:"finished_at"`
+	DurationMs int64              `bson:"duration_ms" json:"duration_ms"`
+}
+
+// JobDefinition describe un job registrado en el scheduler
+type JobDefinition struct {
+	Name     string
+	Interval time.Duration
+}
+
+// JobStatus resume el estado de un job para el endpoint de administración
+type JobStatus struct {
+	Name          string     `json:"name"`
+	Interval      string     `json:"interval"`
+	LastRun       *JobRun    `json:"last_run,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+}
+
+// SchedulerLease es el documento de lock que elige a la réplica líder
+type SchedulerLease struct {
+	Name       string    `bson:"_id" json:"name"`
+	Owner      string    `bson:"owner" json:"owner"`
+	AcquiredAt time.Time `bson:"acquired_at" json:"acquired_at"`
+	ExpiresAt  time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+type SchedulerStatus struct {
+	Instance string          `json:"instance"`
+	Leader   *SchedulerLease `json:"leader"`
+	Jobs     []JobStatus     `json:"jobs"`
+}
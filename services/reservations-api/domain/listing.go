@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Campos por los que se puede ordenar un listado; el prefijo "-" indica
+// orden descendente
+const (
+	SortCreatedAtAsc  = "created_at"
+	SortCreatedAtDesc = "-created_at"
+	SortStartDateAsc  = "start_date"
+	SortStartDateDesc = "-start_date"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ReservationListQuery son los filtros y la paginación de un listado de
+// reservas. From/To seleccionan las reservas que se solapan con [From, To).
+type ReservationListQuery struct {
+	UserID       *uint
+	Statuses     []ReservationStatus
+	RoomID       *uint
+	From         string
+	To           string
+	CreatedAfter *time.Time
+	Sort         string
+	Limit        int
+	Cursor       string
+	IncludeTotal bool
+}
+
+// ReservationPage es una página de resultados. NextCursor es nil en la
+// última página y Total solo se calcula si se pidió explícitamente.
+type ReservationPage struct {
+	Reservations []Reservation `json:"reservations"`
+	NextCursor   *string       `json:"next_cursor"`
+	Total        *int64        `json:"total,omitempty"`
+}
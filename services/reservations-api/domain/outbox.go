@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+)
+
+// OutboxMessage es un evento pendiente de publicar. Se escribe en la misma
+// transacción que el cambio de la reserva y lo publica el relay. Subject es el
+// subject del envelope: el id de la reserva en sus eventos.
+type OutboxMessage struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	RoutingKey string             `bson:"routing_key" json:"routing_key"`
+	Subject    string             `bson:"subject,omitempty" json:"subject,omitempty"`
+	Payload    []byte             `bson:"payload" json:"-"`
+	Status     OutboxStatus       `bson:"status" json:"status"`
+	Attempts   int                `bson:"attempts" json:"attempts"`
+	LastError  *string            `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	// NextAttemptAt sirve tanto para el back-off como de lease mientras un relay lo publica
+	NextAttemptAt time.Time  `bson:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	SentAt        *time.Time `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+}
+
+// OutboxStats resume el backlog de eventos sin publicar
+type OutboxStats struct {
+	Pending         int64      `json:"pending"`
+	Failing         int64      `json:"failing"`
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PaymentStatus: una autorización capturada en parte sigue en authorized
+// hasta que CapturedAmount alcanza Amount
+type PaymentStatus string
+
+const (
+	// PaymentStatusPending reserva el pago mientras el gateway responde; a
+	// lo sumo un pago por reserva puede estar pending, authorized o captured
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+// PaymentPolicy define cuánto se cobra al confirmar la reserva
+type PaymentPolicy string
+
+const (
+	// PaymentPolicyDeposit autoriza solo un porcentaje del total como seña
+	PaymentPolicyDeposit PaymentPolicy = "deposit"
+	// PaymentPolicyFull autoriza el total de la estadía
+	PaymentPolicyFull PaymentPolicy = "full"
+)
+
+type Payment struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ReservationID string             `bson:"reservation_id" json:"reservation_id"`
+	UserID        uint               `bson:"user_id" json:"user_id"`
+	Provider      string             `bson:"provider" json:"provider"`
+	GatewayRef    string             `bson:"gateway_ref,omitempty" json:"gateway_ref,omitempty"`
+	Policy        PaymentPolicy      `bson:"policy" json:"policy"`
+	Status        PaymentStatus      `bson:"status" json:"status"`
+	Currency      string             `bson:"currency" json:"currency"`
+
+	// Total de la estadía y monto autorizado según la política
+	ReservationTotal float64 `bson:"reservation_total" json:"reservation_total"`
+	Amount           float64 `bson:"amount" json:"amount"`
+	CapturedAmount   float64 `bson:"captured_amount" json:"captured_amount"`
+	RefundedAmount   float64 `bson:"refunded_amount" json:"refunded_amount"`
+
+	FailureReason *string `bson:"failure_reason,omitempty" json:"failure_reason,omitempty"`
+
+	// Version aumenta en cada actualización; Update solo escribe si no
+	// cambió desde la lectura
+	Version int64 `bson:"version" json:"-"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// WebhookReceipt registra un callback ya procesado para garantizar idempotencia
+type WebhookReceipt struct {
+	EventID    string    `bson:"_id" json:"event_id"`
+	Provider   string    `bson:"provider" json:"provider"`
+	Type       string    `bson:"type" json:"type"`
+	Reference  string    `bson:"reference" json:"reference"`
+	ReceivedAt time.Time `bson:"received_at" json:"received_at"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+// ReservationPricing es el precio acordado al reservar; una vez guardado no
+// cambia aunque cambie la tarifa de la habitación. NightlyRate es el
+// promedio de NightlyRates y Total descuenta la estadía larga y el código.
+type ReservationPricing struct {
+	Currency     string           `bson:"currency" json:"currency"`
+	NightlyRate  float64          `bson:"nightly_rate" json:"nightly_rate"`
+	Nights       int              `bson:"nights" json:"nights"`
+	NightlyRates []NightlyRate    `bson:"nightly_rates,omitempty" json:"nightly_rates,omitempty"`
+	Subtotal     float64          `bson:"subtotal" json:"subtotal"`
+	StayDiscount *StayDiscount    `bson:"stay_discount,omitempty" json:"stay_discount,omitempty"`
+	Discount     *AppliedDiscount `bson:"discount,omitempty" json:"discount,omitempty"`
+	Total        float64          `bson:"total" json:"total"`
+}
+
+// NightlyRate es el precio de una noche según el plan de tarifas de rooms-api
+type NightlyRate struct {
+	Date    string  `bson:"date" json:"date"`
+	Rate    float64 `bson:"rate" json:"rate"`
+	Weekend bool    `bson:"weekend" json:"weekend"`
+	Season  string  `bson:"season,omitempty" json:"season,omitempty"`
+	// Dynamic indica que el precio viene de los precios dinámicos por ocupación
+	Dynamic bool `bson:"dynamic,omitempty" json:"dynamic,omitempty"`
+}
+
+// StayDiscount es el descuento por duración de la estadía del plan de tarifas
+type StayDiscount struct {
+	Percent float64 `bson:"percent" json:"percent"`
+	Amount  float64 `bson:"amount" json:"amount"`
+}
+
+// RoomRates es la respuesta de GET /api/v1/rooms/:id/rates de rooms-api
+type RoomRates struct {
+	RoomID          uint          `json:"room_id"`
+	RoomType        string        `json:"room_type"`
+	Nights          []NightlyRate `json:"nights"`
+	MinStay         int           `json:"min_stay"`
+	MinStayMet      bool          `json:"min_stay_met"`
+	Subtotal        float64       `json:"subtotal"`
+	DiscountPercent float64       `json:"discount_percent"`
+	Discount        float64       `json:"discount"`
+	Total           float64       `json:"total"`
+}
+
+// PriceQuote es la cotización de una estadía antes de reservar
+type PriceQuote struct {
+	RoomID    uint   `json:"room_id"`
+	RoomType  string `json:"room_type"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	ReservationPricing
+}
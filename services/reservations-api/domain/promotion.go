@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+)
+
+// Promotion es un código de descuento. Los límites en 0 significan "sin límite".
+type Promotion struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Code         string             `bson:"code" json:"code"`
+	Description  string             `bson:"description,omitempty" json:"description,omitempty"`
+	DiscountType DiscountType       `bson:"discount_type" json:"discount_type"`
+	// Value es el porcentaje (0-100] o el monto fijo en la moneda del hotel
+	Value float64 `bson:"value" json:"value"`
+
+	// Ventana en la que se puede canjear el código
+	ValidFrom  time.Time `bson:"valid_from" json:"valid_from"`
+	ValidUntil time.Time `bson:"valid_until" json:"valid_until"`
+
+	MinNights int      `bson:"min_nights" json:"min_nights"`
+	RoomTypes []string `bson:"room_types,omitempty" json:"room_types,omitempty"`
+
+	MaxUses        int `bson:"max_uses" json:"max_uses"`
+	MaxUsesPerUser int `bson:"max_uses_per_user" json:"max_uses_per_user"`
+	// Uses se incrementa atómicamente en cada canje
+	Uses int `bson:"uses" json:"uses"`
+
+	Active    bool      `bson:"active" json:"active"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// AppliedDiscount es el descuento que quedó registrado en la reserva
+type AppliedDiscount struct {
+	PromotionID  primitive.ObjectID `bson:"promotion_id" json:"promotion_id"`
+	Code         string             `bson:"code" json:"code"`
+	DiscountType DiscountType       `bson:"discount_type" json:"discount_type"`
+	Value        float64            `bson:"value" json:"value"`
+	Amount       float64            `bson:"amount" json:"amount"`
+}
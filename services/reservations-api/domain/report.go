@@ -0,0 +1,63 @@
+package domain
+
+// Agrupaciones posibles del reporte de ingresos
+const (
+	ReportGroupByType  = "type"
+	ReportGroupByFloor = "floor"
+)
+
+// RoomNights son las noches vendidas de una habitación dentro del rango.
+// Revenue es la parte del precio acordado que corresponde a esas noches;
+// UnpricedNights son las de reservas sin precio guardado.
+type RoomNights struct {
+	RoomID         uint    `bson:"_id" json:"room_id"`
+	Nights         int     `bson:"nights" json:"nights"`
+	Revenue        float64 `bson:"revenue" json:"revenue"`
+	UnpricedNights int     `bson:"unpriced_nights" json:"unpriced_nights"`
+}
+
+// BookingStats resume las reservas creadas en un rango
+type BookingStats struct {
+	Bookings        int64   `bson:"bookings" json:"bookings"`
+	Cancellations   int64   `bson:"cancellations" json:"cancellations"`
+	AvgLeadTimeDays float64 `bson:"avg_lead_time_days" json:"avg_lead_time_days"`
+}
+
+// PerformanceReport son los indicadores del hotel para [From, To).
+// Las tasas están expresadas en porcentaje.
+type PerformanceReport struct {
+	From                string  `json:"from"`
+	To                  string  `json:"to"`
+	Currency            string  `json:"currency"`
+	Rooms               int     `json:"rooms"`
+	AvailableRoomNights int     `json:"available_room_nights"`
+	SoldRoomNights      int     `json:"sold_room_nights"`
+	Revenue             float64 `json:"revenue"`
+	OccupancyRate       float64 `json:"occupancy_rate"`
+	ADR                 float64 `json:"adr"`
+	RevPAR              float64 `json:"revpar"`
+	Bookings            int64   `json:"bookings"`
+	Cancellations       int64   `json:"cancellations"`
+	CancellationRate    float64 `json:"cancellation_rate"`
+	AvgLeadTimeDays     float64 `json:"avg_lead_time_days"`
+}
+
+// RevenueRow son los indicadores de un tipo de habitación o de un piso
+type RevenueRow struct {
+	Key                 string  `json:"key"`
+	Rooms               int     `json:"rooms"`
+	AvailableRoomNights int     `json:"available_room_nights"`
+	SoldRoomNights      int     `json:"sold_room_nights"`
+	Revenue             float64 `json:"revenue"`
+	OccupancyRate       float64 `json:"occupancy_rate"`
+	ADR                 float64 `json:"adr"`
+	RevPAR              float64 `json:"revpar"`
+}
+
+type RevenueReport struct {
+	From     string       `json:"from"`
+	To       string       `json:"to"`
+	GroupBy  string       `json:"group_by"`
+	Currency string       `json:"currency"`
+	Rows     []RevenueRow `json:"rows"`
+}
@@ -9,8 +9,14 @@ import (
 type ReservationStatus string
 
 const (
+	ReservationStatusPending  ReservationStatus = "pending"
 	ReservationStatusActive   ReservationStatus = "active"
 	ReservationStatusCanceled ReservationStatus = "canceled"
+	ReservationStatusExpired  ReservationStatus = "expired"
+	// El huésped no llegó dentro del margen posterior a start_date
+	ReservationStatusNoShow ReservationStatus = "no_show"
+	// La estadía terminó (con o sin check-out explícito)
+	ReservationStatusCompleted ReservationStatus = "completed"
 )
 
 type Reservation struct {
@@ -21,10 +27,59 @@ type Reservation struct {
 	RoomID    uint               `bson:"room_id" json:"room_id"`
 	Status    ReservationStatus  `bson:"status" json:"status"`
 
+	// Reservas de grupo: cada habitación es una reserva con el mismo GroupID
+	GroupID   *primitive.ObjectID `bson:"group_id,omitempty" json:"group_id,omitempty"`
+	GuestName string              `bson:"guest_name,omitempty" json:"guest_name,omitempty"`
+
+	// Precio acordado al reservar, con el descuento del código promocional si hubo
+	Pricing *ReservationPricing `bson:"pricing,omitempty" json:"pricing,omitempty"`
+	// Política de cancelación vigente al reservar; no cambia si después se
+	// borra o se reubica la habitación
+	CancellationPolicy CancellationPolicyType `bson:"cancellation_policy,omitempty" json:"cancellation_policy,omitempty"`
+
+	// Bloqueo importado de un calendario externo (iCal); no tiene huésped ni pago
+	External *ExternalBlock `bson:"external,omitempty" json:"external,omitempty"`
+
+	// Hold temporal: una reserva pending bloquea la habitación hasta ExpiresAt
+	ExpiresAt   *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	ConfirmedAt *time.Time `bson:"confirmed_at,omitempty" json:"confirmed_at,omitempty"`
+
+	// Estadía: el huésped llegó (check-in) y dejó la habitación (check-out)
+	CheckedInAt  *time.Time `bson:"checked_in_at,omitempty" json:"checked_in_at,omitempty"`
+	CheckedOutAt *time.Time `bson:"checked_out_at,omitempty" json:"checked_out_at,omitempty"`
+
+	// La habitación dejó de estar disponible (baja o mantenimiento) y hay que reubicar al huésped
+	NeedsRelocation bool            `bson:"needs_relocation,omitempty" json:"needs_relocation,omitempty"`
+	Relocation      *RelocationInfo `bson:"relocation,omitempty" json:"relocation,omitempty"`
+
 	//Soft Delete de cancelación
-	CancelReason *string    `bson:"cancel_reason,omitempty" json:"cancel_reason,omitempty"`
-	DeletedAt    *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	CancelReason *string            `bson:"cancel_reason,omitempty" json:"cancel_reason,omitempty"`
+	Cancellation *CancellationQuote `bson:"cancellation,omitempty" json:"cancellation,omitempty"`
+	DeletedAt    *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
 
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
 }
+
+// ExternalBlock identifica el evento del feed que originó el bloqueo
+type ExternalBlock struct {
+	FeedURL string `bson:"feed_url" json:"feed_url"`
+	UID     string `bson:"uid" json:"uid"`
+}
+
+type RelocationReason string
+
+const (
+	RelocationReasonRoomDeleted     RelocationReason = "room_deleted"
+	RelocationReasonRoomMaintenance RelocationReason = "room_maintenance"
+)
+
+// RelocationInfo registra por qué se marcó la reserva y, si se pudo,
+// a qué habitación se la movió
+type RelocationInfo struct {
+	Reason      RelocationReason `bson:"reason" json:"reason"`
+	FromRoomID  uint             `bson:"from_room_id" json:"from_room_id"`
+	FlaggedAt   time.Time        `bson:"flagged_at" json:"flagged_at"`
+	ToRoomID    *uint            `bson:"to_room_id,omitempty" json:"to_room_id,omitempty"`
+	RelocatedAt *time.Time       `bson:"relocated_at,omitempty" json:"relocated_at,omitempty"`
+}
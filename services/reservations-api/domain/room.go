@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Estado operativo de una habitación en rooms-api
+const RoomStatusMaintenance = "maintenance"
+
+// Tipos de evento que publica rooms-api en el exchange "rooms"
+const (
+	RoomEventUpdated       = "updated"
+	RoomEventDeleted       = "deleted"
+	RoomEventStatusChanged = "status_changed"
+)
+
+// RoomEvent es el mensaje que publica rooms-api. En v1 Type y Capacity solo
+// vienen en las bajas, cuando la habitación ya no se puede consultar; desde
+// v2 todos los eventos traen el snapshot en Room y los cambios de estado
+// llegan como status_changed.
+type RoomEvent struct {
+	EventType string    `json:"event_type"`
+	RoomID    uint      `json:"room_id"`
+	Type      string    `json:"type,omitempty"`
+	Capacity  int       `json:"capacity,omitempty"`
+	Version   uint64    `json:"version,omitempty"`
+	Room      *RoomInfo `json:"room,omitempty"`
+	NewStatus string    `json:"new_status,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// RoomInfo es la vista mínima de una habitación de rooms-api que necesita
+// reservations-api (precio, tipo y estado operativo)
+type RoomInfo struct {
+	ID       uint    `json:"id"`
+	Number   string  `json:"number"`
+	Type     string  `json:"type"`
+	Status   string  `json:"status"`
+	Price    float64 `json:"price"`
+	Capacity int     `json:"capacity"`
+	Floor    int     `json:"floor"`
+}
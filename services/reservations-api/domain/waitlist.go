@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WaitlistStatus string
+
+const (
+	// Esperando que se libere inventario
+	WaitlistStatusWaiting WaitlistStatus = "waiting"
+	// Se ofreció un hold que el huésped todavía puede confirmar
+	WaitlistStatusOffered WaitlistStatus = "offered"
+	// El huésped confirmó el hold ofrecido
+	WaitlistStatusAccepted WaitlistStatus = "accepted"
+	// La oferta venció sin confirmarse
+	WaitlistStatusExpired  WaitlistStatus = "expired"
+	WaitlistStatusCanceled WaitlistStatus = "canceled"
+)
+
+const EventWaitlistOffered EventType = "waitlist.offered"
+
+// WaitlistEntry es un pedido de habitación para fechas sin disponibilidad.
+// Se pide una habitación puntual (RoomID) o cualquiera de un tipo (RoomType).
+type WaitlistEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    uint               `bson:"user_id" json:"user_id"`
+	RoomID    *uint              `bson:"room_id,omitempty" json:"room_id,omitempty"`
+	RoomType  string             `bson:"room_type" json:"room_type"`
+	StartDate string             `bson:"start_date" json:"start_date"`
+	EndDate   string             `bson:"end_date" json:"end_date"`
+	Status    WaitlistStatus     `bson:"status" json:"status"`
+
+	// Oferta: hold creado sobre la habitación liberada
+	OfferedRoomID  *uint      `bson:"offered_room_id,omitempty" json:"offered_room_id,omitempty"`
+	ReservationID  *string    `bson:"reservation_id,omitempty" json:"reservation_id,omitempty"`
+	OfferExpiresAt *time.Time `bson:"offer_expires_at,omitempty" json:"offer_expires_at,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// WaitlistEvent se publica cuando se ofrece un hold a un huésped en espera
+type WaitlistEvent struct {
+	EventType      EventType `json:"event_type"`
+	EntryID        string    `json:"entry_id"`
+	UserID         uint      `json:"user_id"`
+	RoomID         uint      `json:"room_id"`
+	ReservationID  string    `json:"reservation_id"`
+	StartDate      string    `json:"start_date"`
+	EndDate        string    `json:"end_date"`
+	OfferExpiresAt time.Time `json:"offer_expires_at"`
+	Timestamp      time.Time `json:"timestamp"`
+}
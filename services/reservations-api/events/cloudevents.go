@@ -0,0 +1,118 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventSource identifica a reservations-api como origen de sus eventos
+const EventSource = "/reservations-api"
+
+var ErrInvalidCloudEvent = errors.New("invalid cloud event")
+
+// NewCloudEvent envuelve `data` en un envelope CloudEvents; el id es
+// estable entre reintentos del relay porque se genera al encolar
+func NewCloudEvent(eventType, subject, schema string, data interface{}) (domain.CloudEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	return domain.CloudEvent{
+		SpecVersion:     domain.CloudEventsSpecVersion,
+		ID:              primitive.NewObjectID().Hex(),
+		Source:          EventSource,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		DataSchema:      schema,
+		Data:            payload,
+	}, nil
+}
+
+// DecodeCloudEvent valida el envelope, que `dataschema` sea uno de los
+// esquemas aceptados y que `data` tenga los campos requeridos por ese
+// esquema. Los mensajes sin envelope (publicados antes de la migración)
+// se leen como la versión 1 del primer esquema.
+func DecodeCloudEvent(body []byte, schemas ...string) (domain.CloudEvent, error) {
+	var probe struct {
+		SpecVersion *string `json:"specversion"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+	}
+	if probe.SpecVersion == nil {
+		if err := validateData(schemas[0], body); err != nil {
+			return domain.CloudEvent{}, err
+		}
+		return domain.CloudEvent{
+			SpecVersion: domain.CloudEventsSpecVersion,
+			DataSchema:  schemas[0],
+			Data:        body,
+		}, nil
+	}
+
+	var event domain.CloudEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+	}
+	switch {
+	case event.SpecVersion != domain.CloudEventsSpecVersion:
+		return domain.CloudEvent{}, fmt.Errorf("%w: specversion %q no soportada", ErrInvalidCloudEvent, event.SpecVersion)
+	case event.ID == "" || event.Source == "" || event.Type == "":
+		return domain.CloudEvent{}, fmt.Errorf("%w: id, source y type son requeridos", ErrInvalidCloudEvent)
+	case len(event.Data) == 0:
+		return domain.CloudEvent{}, fmt.Errorf("%w: data es requerido", ErrInvalidCloudEvent)
+	}
+	for _, schema := range schemas {
+		if event.DataSchema == schema {
+			if err := validateData(schema, event.Data); err != nil {
+				return domain.CloudEvent{}, err
+			}
+			return event, nil
+		}
+	}
+	return domain.CloudEvent{}, fmt.Errorf("%w: dataschema %q no soportado", ErrInvalidCloudEvent, event.DataSchema)
+}
+
+// requiredDataFields son los campos obligatorios de `data` en cada esquema
+// de contracts/events
+var requiredDataFields = map[string][]string{
+	domain.ReservationEventSchemaV1: {"event_type", "reservation_id", "user_id", "room_id", "start_date", "end_date", "status", "timestamp"},
+	domain.WaitlistEventSchemaV1:    {"event_type", "entry_id", "user_id", "room_id", "reservation_id", "start_date", "end_date", "offer_expires_at", "timestamp"},
+	domain.RoomEventSchemaV1:        {"event_type", "room_id", "timestamp"},
+	domain.RoomEventSchemaV2:        {"event_type", "room_id", "version", "room", "timestamp"},
+}
+
+// validateData verifica que `data` sea un objeto con los campos requeridos
+// por el esquema; no valida tipos ni campos opcionales
+func validateData(schema string, data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%w: data no es un objeto JSON: %v", ErrInvalidCloudEvent, err)
+	}
+	for _, field := range requiredDataFields[schema] {
+		if value, ok := fields[field]; !ok || string(value) == "null" {
+			return fmt.Errorf("%w: falta %q en data (%s)", ErrInvalidCloudEvent, field, schema)
+		}
+	}
+	return nil
+}
+
+// contentTypeFor distingue los envelopes de los payloads que quedaron en el
+// outbox desde antes de la migración
+func contentTypeFor(body []byte) string {
+	var probe struct {
+		SpecVersion string `json:"specversion"`
+	}
+	if json.Unmarshal(body, &probe) == nil && probe.SpecVersion != "" {
+		return domain.CloudEventsContentType
+	}
+	return "application/json"
+}
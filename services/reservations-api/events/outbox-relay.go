@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+)
+
+const (
+	relayLease      = 30 * time.Second
+	relayMaxBackoff = 5 * time.Minute
+)
+
+// OutboxRelay publica los mensajes del outbox en RabbitMQ. Garantiza
+// entrega at-least-once: un mensaje se marca como enviado solo después de
+// que el broker lo confirmó; si falla se reintenta con back-off exponencial.
+type OutboxRelay struct {
+	repository repositories.OutboxRepository
+	publisher  EventPublisher
+	interval   time.Duration
+}
+
+func NewOutboxRelay(repository repositories.OutboxRepository, publisher EventPublisher, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		repository: repository,
+		publisher:  publisher,
+		interval:   interval,
+	}
+}
+
+// Start corre el relay hasta que se cancele el contexto
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	log.Printf("Outbox relay iniciado (cada %s)", r.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Outbox relay detenido")
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+// drain publica todos los mensajes listos hasta vaciar el backlog
+func (r *OutboxRelay) drain(ctx context.Context) {
+	// Sin broker los mensajes esperan en el outbox, no consumen intentos
+	if !r.publisher.Connected() {
+		return
+	}
+	for ctx.Err() == nil {
+		message, found, err := r.repository.ClaimNext(ctx, time.Now(), relayLease)
+		if err != nil {
+			log.Printf("Error leyendo outbox: %v", err)
+			return
+		}
+		if !found {
+			return
+		}
+		r.publish(ctx, message)
+	}
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, message domain.OutboxMessage) {
+	pubCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := r.publisher.Publish(pubCtx, message.RoutingKey, message.Payload); err != nil {
+		next := time.Now().Add(backoff(message.Attempts))
+		log.Printf("Error publicando evento %s (intento %d), reintento a las %s: %v",
+			message.RoutingKey, message.Attempts+1, next.Format(time.RFC3339), err)
+		if markErr := r.repository.MarkFailed(ctx, message.ID, err.Error(), next); markErr != nil {
+			log.Printf("Error registrando fallo del outbox: %v", markErr)
+		}
+		return
+	}
+
+	if err := r.repository.MarkSent(ctx, message.ID, time.Now()); err != nil {
+		// El mensaje se volverá a publicar al vencer el lease (at-least-once)
+		log.Printf("Error marcando evento %s como enviado: %v", message.ID.Hex(), err)
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	wait := time.Second << uint(attempts)
+	if wait <= 0 || wait > relayMaxBackoff {
+		return relayMaxBackoff
+	}
+	return wait
+}
@@ -2,10 +2,13 @@ package events
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"reservations-api/domain"
+	"reservations-api/config"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -15,24 +18,143 @@ const (
 	ExchangeType = "topic"
 )
 
+var (
+	ErrPublisherClosed   = errors.New("publisher closed")
+	ErrPublishBufferFull = errors.New("publish buffer full")
+)
+
+// EventPublisher publica mensajes ya serializados en el exchange de
+// reservas. Publish vuelve recién cuando el broker confirmó el mensaje.
 type EventPublisher interface {
-	PublishReservationCreated(ctx context.Context, event domain.ReservationEvent) error
-	PublishReservationCanceled(ctx context.Context, event domain.ReservationEvent) error
+	Publish(ctx context.Context, routingKey string, body []byte) error
+	// Connected indica si hay una conexión activa con el broker
+	Connected() bool
 	Close() error
 }
 
+type publishRequest struct {
+	ctx        context.Context
+	routingKey string
+	body       []byte
+	result     chan error
+}
+
+// rabbitMQPublisher mantiene la conexión en una goroutine propia: se
+// reconecta con back-off cuando el broker cierra la conexión o el channel y
+// retiene los mensajes en un buffer acotado mientras no hay conexión.
 type rabbitMQPublisher struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
+	cfg       config.RabbitMQConfig
+	queue     chan publishRequest
+	connected atomic.Bool
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewEventPublisher no bloquea esperando al broker: el servicio puede
+// arrancar degradado y el publisher se conecta en cuanto RabbitMQ esté listo.
+func NewEventPublisher(cfg config.RabbitMQConfig) EventPublisher {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &rabbitMQPublisher{
+		cfg:    cfg,
+		queue:  make(chan publishRequest, cfg.BufferSize),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go p.run(ctx)
+	return p
+}
+
+func (p *rabbitMQPublisher) Publish(ctx context.Context, routingKey string, body []byte) error {
+	req := publishRequest{
+		ctx:        ctx,
+		routingKey: routingKey,
+		body:       body,
+		result:     make(chan error, 1),
+	}
+
+	select {
+	case <-p.done:
+		return ErrPublisherClosed
+	default:
+	}
+
+	// Si el buffer está lleno se rechaza en lugar de bloquear al llamador
+	select {
+	case p.queue <- req:
+	default:
+		return ErrPublishBufferFull
+	}
+
+	select {
+	case err := <-req.result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("failed to publish event %s: %w", routingKey, ctx.Err())
+	case <-p.done:
+		return ErrPublisherClosed
+	}
 }
 
-func NewEventPublisher(conn *amqp.Connection) (EventPublisher, error) {
+func (p *rabbitMQPublisher) Connected() bool {
+	return p.connected.Load()
+}
+
+func (p *rabbitMQPublisher) Close() error {
+	p.closeOnce.Do(func() {
+		p.cancel()
+		<-p.done
+	})
+	return nil
+}
+
+// run conecta, publica hasta que se pierda la conexión y vuelve a conectar
+func (p *rabbitMQPublisher) run(ctx context.Context) {
+	defer close(p.done)
+
+	wait := p.cfg.ReconnectMin
+	for {
+		conn, channel, err := p.connect()
+		if err != nil {
+			log.Printf("RabbitMQ no disponible: %v. Reintentando en %s...", err, wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+			wait = p.cfg.NextBackoff(wait)
+			continue
+		}
+
+		wait = p.cfg.ReconnectMin
+		p.connected.Store(true)
+		log.Printf("Publisher conectado a RabbitMQ, exchange '%s' declarado", ExchangeName)
+
+		p.serve(ctx, conn, channel)
+
+		p.connected.Store(false)
+		channel.Close()
+		conn.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Println("Conexión con RabbitMQ perdida, reconectando...")
+	}
+}
+
+func (p *rabbitMQPublisher) connect() (*amqp.Connection, *amqp.Channel, error) {
+	conn, err := config.DialRabbitMQ(p.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
 	channel, err := conn.Channel()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open channel: %w", err)
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
-	// Declarar el exchange
+	// Declarar el exchange en cada conexión por si el broker se recreó
 	err = channel.ExchangeDeclare(
 		ExchangeName, // name
 		ExchangeType, // type
@@ -43,41 +165,60 @@ func NewEventPublisher(conn *amqp.Connection) (EventPublisher, error) {
 		nil,          // arguments
 	)
 	if err != nil {
-		channel.Close()
-		return nil, fmt.Errorf("failed to declare exchange: %w", err)
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	log.Printf("Exchange '%s' declarado exitosamente", ExchangeName)
+	// Modo confirm: el broker confirma cada mensaje aceptado
+	if err := channel.Confirm(false); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
 
-	return &rabbitMQPublisher{
-		conn:    conn,
-		channel: channel,
-	}, nil
+	return conn, channel, nil
 }
 
-func (p *rabbitMQPublisher) PublishReservationCreated(ctx context.Context, event domain.ReservationEvent) error {
-	return p.publish(ctx, "reservation.created", event)
-}
+// serve publica los mensajes del buffer hasta que se cierre la conexión
+// o el publisher. Un channel no admite publicaciones concurrentes, por eso
+// todas pasan por esta goroutine.
+func (p *rabbitMQPublisher) serve(ctx context.Context, conn *amqp.Connection, channel *amqp.Channel) {
+	connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	channelClosed := channel.NotifyClose(make(chan *amqp.Error, 1))
 
-func (p *rabbitMQPublisher) PublishReservationCanceled(ctx context.Context, event domain.ReservationEvent) error {
-	return p.publish(ctx, "reservation.canceled", event)
-}
-
-func (p *rabbitMQPublisher) publish(ctx context.Context, routingKey string, event domain.ReservationEvent) error {
-	body, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-connClosed:
+			log.Printf("Conexión con RabbitMQ cerrada: %v", err)
+			return
+		case err := <-channelClosed:
+			log.Printf("Channel de RabbitMQ cerrado: %v", err)
+			return
+		case req := <-p.queue:
+			// El llamador ya abandonó la espera: no tiene sentido publicar
+			if err := req.ctx.Err(); err != nil {
+				req.result <- err
+				continue
+			}
+			req.result <- p.publish(channel, req)
+			if channel.IsClosed() {
+				return
+			}
+		}
 	}
+}
 
-	err = p.channel.PublishWithContext(
-		ctx,
-		ExchangeName, // exchange
-		routingKey,   // routing key
-		false,        // mandatory
-		false,        // immediate
+func (p *rabbitMQPublisher) publish(channel *amqp.Channel, req publishRequest) error {
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(
+		req.ctx,
+		ExchangeName,   // exchange
+		req.routingKey, // routing key
+		false,          // mandatory
+		false,          // immediate
 		amqp.Publishing{
-			ContentType:  "application/json",
-			Body:         body,
+			ContentType:  contentTypeFor(req.body),
+			Body:         req.body,
 			DeliveryMode: amqp.Persistent,
 		},
 	)
@@ -85,15 +226,14 @@ func (p *rabbitMQPublisher) publish(ctx context.Context, routingKey string, even
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
-	log.Printf("Evento publicado: %s - Reservation ID: %s", routingKey, event.ReservationID)
-	return nil
-}
-
-func (p *rabbitMQPublisher) Close() error {
-	if p.channel != nil {
-		if err := p.channel.Close(); err != nil {
-			return err
-		}
+	acked, err := confirmation.WaitContext(req.ctx)
+	if err != nil {
+		return fmt.Errorf("failed waiting for publisher confirm: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("broker rejected event %s", req.routingKey)
 	}
+
+	log.Printf("Evento publicado: %s", req.routingKey)
 	return nil
 }
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"reservations-api/domain"
+	"reservations-api/services"
+)
+
+// ImportICalFeeds reconcilia cada feed iCal configurado. Un feed caído no
+// frena la importación de los demás; el job falla si alguno dio error.
+func ImportICalFeeds(service services.ICalService, feeds []domain.ICalFeed) JobFunc {
+	return func(ctx context.Context) (int, error) {
+		processed := 0
+		var firstErr error
+		for _, feed := range feeds {
+			result, err := service.ImportFeed(ctx, feed)
+			if err != nil {
+				log.Printf("Error importando el feed iCal %s (habitación %d): %v", feed.URL, feed.RoomID, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			if result != (domain.ICalImportResult{}) {
+				log.Printf("Feed iCal %s (habitación %d): %d nuevos, %d modificados, %d eliminados, %d en conflicto",
+					feed.URL, feed.RoomID, result.Added, result.Updated, result.Removed, result.Conflicts)
+			}
+			processed += result.Added + result.Updated + result.Removed
+		}
+		return processed, firstErr
+	}
+}
@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+// JobFunc ejecuta una corrida del job y devuelve cuántos elementos procesó
+type JobFunc func(ctx context.Context) (int, error)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Scheduler corre los jobs registrados solo en la réplica líder. El líder es
+// quien tiene el lock "scheduler" en Mongo y lo renueva cada LeaseTTL/3; si
+// la réplica muere, otra lo toma cuando vence.
+type Scheduler struct {
+	locks    repositories.LockRepository
+	runs     repositories.JobRunRepository
+	owner    string
+	leaseTTL time.Duration
+	jobs     []job
+	leader   atomic.Bool
+}
+
+func NewScheduler(locks repositories.LockRepository, runs repositories.JobRunRepository, owner string, leaseTTL time.Duration) *Scheduler {
+	return &Scheduler{
+		locks:    locks,
+		runs:     runs,
+		owner:    owner,
+		leaseTTL: leaseTTL,
+	}
+}
+
+// Register agrega un job; debe llamarse antes de Start
+func (s *Scheduler) Register(name string, interval time.Duration, run JobFunc) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Definitions devuelve los jobs registrados, para el endpoint de administración
+func (s *Scheduler) Definitions() []domain.JobDefinition {
+	definitions := make([]domain.JobDefinition, len(s.jobs))
+	for i, job := range s.jobs {
+		definitions[i] = domain.JobDefinition{Name: job.name, Interval: job.interval}
+	}
+	return definitions
+}
+
+// Start mantiene el lock de líder y corre los jobs hasta que se cancele el contexto
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.runJob(ctx, job)
+	}
+
+	log.Printf("Scheduler iniciado (instancia %s, %d jobs)", s.owner, len(s.jobs))
+	ticker := time.NewTicker(s.leaseTTL / 3)
+	defer ticker.Stop()
+
+	s.renewLease(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			s.releaseLease()
+			log.Println("Scheduler detenido")
+			return
+		case <-ticker.C:
+			s.renewLease(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) renewLease(ctx context.Context) {
+	acquired, err := s.locks.Acquire(ctx, domain.SchedulerLockName, s.owner, s.leaseTTL)
+	if err != nil {
+		// Sin poder renovar no hay garantía de ser el único líder
+		log.Printf("Error renovando el lock del scheduler: %v", err)
+		acquired = false
+	}
+
+	if was := s.leader.Swap(acquired); was != acquired {
+		if acquired {
+			log.Printf("Scheduler: la instancia %s es líder", s.owner)
+		} else {
+			log.Printf("Scheduler: la instancia %s dejó de ser líder", s.owner)
+		}
+	}
+}
+
+func (s *Scheduler) releaseLease() {
+	if !s.leader.Swap(false) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.locks.Release(ctx, domain.SchedulerLockName, s.owner); err != nil {
+		log.Printf("Error liberando el lock del scheduler: %v", err)
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job job) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.leader.Load() {
+				s.execute(ctx, job)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, job job) {
+	runCtx, cancel := context.WithTimeout(ctx, job.interval)
+	defer cancel()
+	runCtx = utils.WithActor(runCtx, domain.Actor{Type: domain.ActorTypeSystem, Process: "job:" + job.name})
+
+	started := time.Now()
+	processed, err := job.run(runCtx)
+	finished := time.Now()
+
+	run := domain.JobRun{
+		Job:        job.name,
+		Owner:      s.owner,
+		Status:     domain.JobRunStatusSucceeded,
+		Processed:  processed,
+		StartedAt:  started,
+		FinishedAt: finished,
+		DurationMs: finished.Sub(started).Milliseconds(),
+	}
+	if err != nil {
+		run.Status = domain.JobRunStatusFailed
+		run.Error = err.Error()
+		log.Printf("Job %s falló: %v", job.name, err)
+	} else if processed > 0 {
+		log.Printf("Job %s: %d procesados", job.name, processed)
+	}
+
+	if err := s.runs.Record(ctx, run); err != nil {
+		log.Printf("Error registrando la corrida del job %s: %v", job.name, err)
+	}
+}
@@ -0,0 +1,71 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownReference = errors.New("unknown payment reference")
+	ErrInvalidAmount    = errors.New("invalid payment amount")
+	ErrInvalidState     = errors.New("operation not allowed in current payment state")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// PaymentGateway abstrae al proveedor de pagos. Cada proveedor real
+// (Stripe, MercadoPago, etc.) implementa esta interfaz.
+type PaymentGateway interface {
+	Name() string
+	Authorize(ctx context.Context, req AuthorizeRequest) (Result, error)
+	Capture(ctx context.Context, reference string, amount float64) (Result, error)
+	Refund(ctx context.Context, reference string, amount float64) (Result, error)
+	Void(ctx context.Context, reference string) (Result, error)
+	// ParseWebhook valida la firma del callback y lo decodifica
+	ParseWebhook(payload []byte, signature string) (WebhookEvent, error)
+}
+
+type AuthorizeRequest struct {
+	ReservationID string
+	Amount        float64
+	Currency      string
+	PaymentToken  string
+	// IdempotencyKey evita autorizaciones duplicadas en el proveedor; es
+	// única por intento (el id del pago), así un reintento tras un void no
+	// reutiliza la autorización anulada
+	IdempotencyKey string
+}
+
+// Result es la respuesta del proveedor a una operación
+type Result struct {
+	Reference     string
+	Approved      bool
+	DeclineReason string
+	Amount        float64
+}
+
+// WebhookEvent es un callback asíncrono del proveedor
+type WebhookEvent struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Reference string  `json:"reference"`
+	Amount    float64 `json:"amount"`
+}
+
+// Tipos de webhook soportados
+const (
+	WebhookPaymentCaptured = "payment.captured"
+	WebhookPaymentRefunded = "payment.refunded"
+	WebhookPaymentVoided   = "payment.voided"
+	WebhookPaymentFailed   = "payment.failed"
+)
+
+// NewGateway construye el gateway configurado por nombre
+func NewGateway(provider, webhookSecret string) (PaymentGateway, error) {
+	switch provider {
+	case "", "mock":
+		return NewMockGateway(webhookSecret), nil
+	default:
+		return nil, fmt.Errorf("payment provider %q not supported", provider)
+	}
+}
@@ -0,0 +1,127 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Tokens de prueba que entiende el mock. Cualquier otro token se aprueba.
+const (
+	MockTokenDeclined          = "tok_declined"
+	MockTokenInsufficientFunds = "tok_insufficient_funds"
+)
+
+// MockGateway es un proveedor determinístico para desarrollo local y tests:
+// la misma solicitud siempre produce la misma referencia y el mismo resultado.
+// No guarda estado: la referencia incluye el monto autorizado, así capturas,
+// anulaciones y reembolsos siguen funcionando después de reiniciar el
+// servicio. Los acumulados de cada pago los controla PaymentService.
+type MockGateway struct {
+	webhookSecret string
+}
+
+func NewMockGateway(webhookSecret string) *MockGateway {
+	return &MockGateway{webhookSecret: webhookSecret}
+}
+
+func (g *MockGateway) Name() string {
+	return "mock"
+}
+
+func (g *MockGateway) Authorize(ctx context.Context, req AuthorizeRequest) (Result, error) {
+	if req.Amount <= 0 {
+		return Result{}, ErrInvalidAmount
+	}
+
+	switch req.PaymentToken {
+	case MockTokenDeclined:
+		return Result{Approved: false, DeclineReason: "card_declined", Amount: req.Amount}, nil
+	case MockTokenInsufficientFunds:
+		return Result{Approved: false, DeclineReason: "insufficient_funds", Amount: req.Amount}, nil
+	}
+
+	key := req.IdempotencyKey
+	if key == "" {
+		key = req.ReservationID
+	}
+	reference := mockReference("auth", key, req.Amount)
+	return Result{Reference: reference, Approved: true, Amount: req.Amount}, nil
+}
+
+func (g *MockGateway) Capture(ctx context.Context, reference string, amount float64) (Result, error) {
+	authorized, err := parseMockReference(reference)
+	if err != nil {
+		return Result{}, err
+	}
+	if amount <= 0 || amount > authorized+0.001 {
+		return Result{}, ErrInvalidAmount
+	}
+	return Result{Reference: reference, Approved: true, Amount: amount}, nil
+}
+
+func (g *MockGateway) Refund(ctx context.Context, reference string, amount float64) (Result, error) {
+	authorized, err := parseMockReference(reference)
+	if err != nil {
+		return Result{}, err
+	}
+	if amount <= 0 || amount > authorized+0.001 {
+		return Result{}, ErrInvalidAmount
+	}
+	return Result{Reference: reference, Approved: true, Amount: amount}, nil
+}
+
+func (g *MockGateway) Void(ctx context.Context, reference string) (Result, error) {
+	authorized, err := parseMockReference(reference)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Reference: reference, Approved: true, Amount: authorized}, nil
+}
+
+// ParseWebhook valida la firma HMAC-SHA256 (hex) del payload. Si no hay
+// secreto configurado se aceptan callbacks sin firmar (solo desarrollo).
+func (g *MockGateway) ParseWebhook(payload []byte, signature string) (WebhookEvent, error) {
+	if g.webhookSecret != "" {
+		mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+		mac.Write(payload)
+		expected := hex.EncodeToString(mac.Sum(nil))
+		if !hmac.Equal([]byte(expected), []byte(signature)) {
+			return WebhookEvent{}, ErrInvalidSignature
+		}
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return WebhookEvent{}, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	if event.ID == "" || event.Type == "" || event.Reference == "" {
+		return WebhookEvent{}, fmt.Errorf("invalid webhook payload: id, type and reference are required")
+	}
+	return event, nil
+}
+
+// mockReference tiene la forma mock_<prefix>_<hash>_<centavos>
+func mockReference(prefix, key string, amount float64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%.2f", prefix, key, amount)))
+	return fmt.Sprintf("mock_%s_%s_%d", prefix, hex.EncodeToString(sum[:8]), int64(math.Round(amount*100)))
+}
+
+// parseMockReference devuelve el monto autorizado de una referencia del mock
+func parseMockReference(reference string) (float64, error) {
+	parts := strings.Split(reference, "_")
+	if len(parts) != 4 || parts[0] != "mock" || parts[1] != "auth" {
+		return 0, ErrUnknownReference
+	}
+	cents, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil || cents <= 0 {
+		return 0, ErrUnknownReference
+	}
+	return float64(cents) / 100, nil
+}
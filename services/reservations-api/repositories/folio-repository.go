@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FolioRepository guarda los extras cargados a las reservas
+type FolioRepository interface {
+	AddCharge(ctx context.Context, charge domain.FolioCharge) (domain.FolioCharge, error)
+	ListCharges(ctx context.Context, reservationID string) ([]domain.FolioCharge, error)
+}
+
+type folioRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFolioRepository(db *mongo.Database) FolioRepository {
+	return &folioRepository{
+		collection: db.Collection("folio_charges"),
+	}
+}
+
+func (r *folioRepository) AddCharge(ctx context.Context, charge domain.FolioCharge) (domain.FolioCharge, error) {
+	if charge.ID.IsZero() {
+		charge.ID = primitive.NewObjectID()
+	}
+	charge.CreatedAt = time.Now()
+
+	if _, err := r.collection.InsertOne(ctx, charge); err != nil {
+		return domain.FolioCharge{}, fmt.Errorf("failed to add folio charge: %w", err)
+	}
+	return charge, nil
+}
+
+func (r *folioRepository) ListCharges(ctx context.Context, reservationID string) ([]domain.FolioCharge, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"reservation_id": reservationID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch folio charges: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var charges []domain.FolioCharge
+	if err := cursor.All(ctx, &charges); err != nil {
+		return nil, fmt.Errorf("failed to decode folio charges: %w", err)
+	}
+	return charges, nil
+}
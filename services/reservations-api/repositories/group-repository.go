@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type GroupRepository interface {
+	Create(ctx context.Context, group domain.GroupBooking) (domain.GroupBooking, error)
+	GetByID(ctx context.Context, id string) (domain.GroupBooking, error)
+	UpdateDates(ctx context.Context, id primitive.ObjectID, startDate, endDate string) error
+	MarkCanceled(ctx context.Context, id primitive.ObjectID, reason string) error
+	// Delete borra el grupo; solo se usa para deshacer una creación fallida
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type groupRepository struct {
+	collection *mongo.Collection
+}
+
+func NewGroupRepository(db *mongo.Database) GroupRepository {
+	return &groupRepository{
+		collection: db.Collection("reservation_groups"),
+	}
+}
+
+func (r *groupRepository) Create(ctx context.Context, group domain.GroupBooking) (domain.GroupBooking, error) {
+	if group.ID.IsZero() {
+		group.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	group.Status = domain.GroupStatusActive
+	group.CreatedAt = now
+	group.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, group); err != nil {
+		return domain.GroupBooking{}, fmt.Errorf("failed to create group: %w", err)
+	}
+	return group, nil
+}
+
+func (r *groupRepository) GetByID(ctx context.Context, id string) (domain.GroupBooking, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.GroupBooking{}, utils.ErrGroupNotFound
+	}
+
+	var group domain.GroupBooking
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&group)
+	if err == mongo.ErrNoDocuments {
+		return domain.GroupBooking{}, utils.ErrGroupNotFound
+	}
+	if err != nil {
+		return domain.GroupBooking{}, fmt.Errorf("failed to fetch group: %w", err)
+	}
+	return group, nil
+}
+
+func (r *groupRepository) UpdateDates(ctx context.Context, id primitive.ObjectID, startDate, endDate string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"start_date": startDate,
+			"end_date":   endDate,
+			"updated_at": time.Now(),
+		},
+	}
+	if _, err := r.collection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to update group: %w", err)
+	}
+	return nil
+}
+
+func (r *groupRepository) MarkCanceled(ctx context.Context, id primitive.ObjectID, reason string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":        domain.GroupStatusCanceled,
+			"cancel_reason": reason,
+			"updated_at":    time.Now(),
+		},
+	}
+	if _, err := r.collection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to cancel group: %w", err)
+	}
+	return nil
+}
+
+func (r *groupRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	return nil
+}
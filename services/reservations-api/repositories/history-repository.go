@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrHistorySequenceTaken indica que otro cambio de la misma reserva tomó la secuencia
+var ErrHistorySequenceTaken = errors.New("history sequence already taken")
+
+// HistoryRepository guarda el historial append-only de las reservas
+type HistoryRepository interface {
+	Append(ctx context.Context, entry domain.HistoryEntry) error
+	ListByReservation(ctx context.Context, reservationID string) ([]domain.HistoryEntry, error)
+}
+
+type historyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewHistoryRepository(db *mongo.Database) HistoryRepository {
+	return &historyRepository{collection: db.Collection("reservation_history")}
+}
+
+func (r *historyRepository) Append(ctx context.Context, entry domain.HistoryEntry) error {
+	if _, err := r.collection.InsertOne(ctx, entry); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrHistorySequenceTaken
+		}
+		return fmt.Errorf("failed to append history entry: %w", err)
+	}
+	return nil
+}
+
+func (r *historyRepository) ListByReservation(ctx context.Context, reservationID string) ([]domain.HistoryEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"reservation_id": reservationID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []domain.HistoryEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode history: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IdempotencyRepository interface {
+	// Acquire intenta reservar la clave. Si ya existe un registro vigente
+	// devuelve ese registro y acquired=false.
+	Acquire(ctx context.Context, record domain.IdempotencyRecord) (existing domain.IdempotencyRecord, acquired bool, err error)
+	Complete(ctx context.Context, key string, status int, body []byte) error
+	Release(ctx context.Context, key string) error
+	// PurgeExpired borra los registros vencidos y devuelve cuántos eliminó
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIdempotencyRepository(db *mongo.Database) IdempotencyRepository {
+	return &idempotencyRepository{
+		collection: db.Collection("idempotency_keys"),
+	}
+}
+
+func (r *idempotencyRepository) Acquire(ctx context.Context, record domain.IdempotencyRecord) (domain.IdempotencyRecord, bool, error) {
+	_, err := r.collection.InsertOne(ctx, record)
+	if err == nil {
+		return domain.IdempotencyRecord{}, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return domain.IdempotencyRecord{}, false, fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+
+	// Tomar la clave si el registro anterior expiró (el índice TTL todavía
+	// no lo borró) o si quedó un request en curso abandonado con el mismo payload
+	now := time.Now()
+	takeover := bson.M{
+		"_id": record.Key,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lte": now}},
+			bson.M{
+				"status":       domain.IdempotencyStatusInProgress,
+				"fingerprint":  record.Fingerprint,
+				"locked_until": bson.M{"$lte": now},
+			},
+		},
+	}
+	result, err := r.collection.ReplaceOne(ctx, takeover, record)
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+	if result.MatchedCount > 0 {
+		return domain.IdempotencyRecord{}, true, nil
+	}
+
+	var existing domain.IdempotencyRecord
+	if err := r.collection.FindOne(ctx, bson.M{"_id": record.Key}).Decode(&existing); err != nil {
+		if err == mongo.ErrNoDocuments {
+			// Se borró entre medio: reintentar desde cero
+			return r.Acquire(ctx, record)
+		}
+		return domain.IdempotencyRecord{}, false, fmt.Errorf("failed to fetch idempotency key: %w", err)
+	}
+	return existing, false, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, key string, status int, body []byte) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":          domain.IdempotencyStatusCompleted,
+			"response_status": status,
+			"response_body":   body,
+		},
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": key}, update); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, key string) error {
+	filter := bson.M{"_id": key, "status": domain.IdempotencyStatusInProgress}
+	if _, err := r.collection.DeleteOne(ctx, filter); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lte": now}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvoiceRevisionTaken indica que otro request ya emitió esa revisión
+var ErrInvoiceRevisionTaken = errors.New("invoice revision already issued")
+
+// InvoiceRepository guarda las facturas y notas de crédito; solo inserta,
+// un comprobante emitido no se modifica
+type InvoiceRepository interface {
+	// NextNumber reserva el próximo número correlativo de la serie
+	NextNumber(ctx context.Context, series domain.InvoiceType) (int64, error)
+	// Create devuelve ErrInvoiceRevisionTaken si la revisión ya existe
+	Create(ctx context.Context, invoice domain.Invoice) (domain.Invoice, error)
+	SetNumber(ctx context.Context, id primitive.ObjectID, number string) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	ListByReservation(ctx context.Context, reservationID string) ([]domain.Invoice, error)
+}
+
+type invoiceRepository struct {
+	collection *mongo.Collection
+	counters   *mongo.Collection
+}
+
+func NewInvoiceRepository(db *mongo.Database) InvoiceRepository {
+	return &invoiceRepository{
+		collection: db.Collection("invoices"),
+		counters:   db.Collection("counters"),
+	}
+}
+
+func (r *invoiceRepository) NextNumber(ctx context.Context, series domain.InvoiceType) (int64, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := r.counters.FindOneAndUpdate(ctx, bson.M{"_id": string(series)}, bson.M{"$inc": bson.M{"seq": 1}}, opts).Decode(&counter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate invoice number: %w", err)
+	}
+	return counter.Seq, nil
+}
+
+func (r *invoiceRepository) Create(ctx context.Context, invoice domain.Invoice) (domain.Invoice, error) {
+	result, err := r.collection.InsertOne(ctx, invoice)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.Invoice{}, ErrInvoiceRevisionTaken
+	}
+	if err != nil {
+		return domain.Invoice{}, fmt.Errorf("failed to store invoice: %w", err)
+	}
+	if invoice.ID.IsZero() {
+		invoice.ID = result.InsertedID.(primitive.ObjectID)
+	}
+	return invoice, nil
+}
+
+func (r *invoiceRepository) SetNumber(ctx context.Context, id primitive.ObjectID, number string) error {
+	_, err := r.collection.UpdateByID(ctx, id, bson.M{"$set": bson.M{"number": number}})
+	if err != nil {
+		return fmt.Errorf("failed to number invoice: %w", err)
+	}
+	return nil
+}
+
+func (r *invoiceRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("failed to delete invoice: %w", err)
+	}
+	return nil
+}
+
+// ListByReservation devuelve los comprobantes de la reserva en orden de emisión
+func (r *invoiceRepository) ListByReservation(ctx context.Context, reservationID string) ([]domain.Invoice, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "issued_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"reservation_id": reservationID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invoices: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	invoices := []domain.Invoice{}
+	if err := cursor.All(ctx, &invoices); err != nil {
+		return nil, fmt.Errorf("failed to decode invoices: %w", err)
+	}
+	return invoices, nil
+}
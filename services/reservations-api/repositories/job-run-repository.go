@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type JobRunRepository interface {
+	Record(ctx context.Context, run domain.JobRun) error
+	// LastRuns devuelve, por job, la última ejecución y la última exitosa
+	LastRuns(ctx context.Context) (map[string]JobRunSummary, error)
+}
+
+type JobRunSummary struct {
+	LastRun       domain.JobRun `bson:"last_run"`
+	LastSuccessAt *time.Time    `bson:"last_success_at"`
+}
+
+type jobRunRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobRunRepository(db *mongo.Database) JobRunRepository {
+	return &jobRunRepository{
+		collection: db.Collection("job_runs"),
+	}
+}
+
+func (r *jobRunRepository) Record(ctx context.Context, run domain.JobRun) error {
+	if _, err := r.collection.InsertOne(ctx, run); err != nil {
+		return fmt.Errorf("failed to record job run: %w", err)
+	}
+	return nil
+}
+
+func (r *jobRunRepository) LastRuns(ctx context.Context) (map[string]JobRunSummary, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "started_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$job",
+			"last_run": bson.M{"$first": "$$ROOT"},
+			"last_success_at": bson.M{"$max": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$status", domain.JobRunStatusSucceeded}}, "$finished_at", nil,
+			}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate job runs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	summaries := make(map[string]JobRunSummary)
+	for cursor.Next(ctx) {
+		var row struct {
+			Job           string `bson:"_id"`
+			JobRunSummary `bson:",inline"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("failed to decode job runs: %w", err)
+		}
+		summaries[row.Job] = row.JobRunSummary
+	}
+	return summaries, cursor.Err()
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LockRepository maneja leases con vencimiento en la colección "locks".
+// Una réplica es dueña del lock mientras lo renueve antes de que venza.
+type LockRepository interface {
+	// Acquire toma o renueva el lock; acquired=false si otra réplica lo tiene vigente
+	Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name, owner string) error
+	Get(ctx context.Context, name string) (*domain.SchedulerLease, error)
+}
+
+type lockRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLockRepository(db *mongo.Database) LockRepository {
+	return &lockRepository{
+		collection: db.Collection("locks"),
+	}
+}
+
+func (r *lockRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	// acquired_at solo cambia cuando el lock cambia de dueño
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"acquired_at": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$owner", owner}}, "$acquired_at", now,
+			}},
+			"owner":      owner,
+			"expires_at": now.Add(ttl),
+		}}},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err == nil {
+		return true, nil
+	}
+	// El upsert choca con el _id cuando otra réplica tiene el lock vigente
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+}
+
+// Release libera el lock si todavía es nuestro, para que otra réplica lo tome sin esperar el TTL
+func (r *lockRepository) Release(ctx context.Context, name, owner string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": name, "owner": owner})
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *lockRepository) Get(ctx context.Context, name string) (*domain.SchedulerLease, error) {
+	var lease domain.SchedulerLease
+	err := r.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&lease)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lock %s: %w", name, err)
+	}
+	return &lease, nil
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, message domain.OutboxMessage) error
+	// ClaimNext toma el próximo mensaje listo para publicar y lo bloquea por `lease`
+	ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (domain.OutboxMessage, bool, error)
+	MarkSent(ctx context.Context, id primitive.ObjectID, at time.Time) error
+	MarkFailed(ctx context.Context, id primitive.ObjectID, reason string, nextAttempt time.Time) error
+	Stats(ctx context.Context) (domain.OutboxStats, error)
+	// DeletePending borra los mensajes sin publicar de los subjects indicados
+	DeletePending(ctx context.Context, subjects []string) error
+}
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(db *mongo.Database) OutboxRepository {
+	return &outboxRepository{
+		collection: db.Collection("outbox"),
+	}
+}
+
+func (r *outboxRepository) Enqueue(ctx context.Context, message domain.OutboxMessage) error {
+	if message.ID.IsZero() {
+		message.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	message.Status = domain.OutboxStatusPending
+	message.CreatedAt = now
+	message.NextAttemptAt = now
+
+	if _, err := r.collection.InsertOne(ctx, message); err != nil {
+		return fmt.Errorf("failed to enqueue event: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (domain.OutboxMessage, bool, error) {
+	filter := bson.M{
+		"status":          domain.OutboxStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{"next_attempt_at": now.Add(lease)},
+	}
+	// Publicar en orden de creación
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var message domain.OutboxMessage
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message)
+	if err == mongo.ErrNoDocuments {
+		return domain.OutboxMessage{}, false, nil
+	}
+	if err != nil {
+		return domain.OutboxMessage{}, false, fmt.Errorf("failed to claim outbox message: %w", err)
+	}
+	return message, true, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	update := bson.M{
+		"$set":   bson.M{"status": domain.OutboxStatusSent, "sent_at": at},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"last_error": ""},
+	}
+	if _, err := r.collection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to mark outbox message as sent: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, reason string, nextAttempt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"last_error": reason, "next_attempt_at": nextAttempt},
+		"$inc": bson.M{"attempts": 1},
+	}
+	if _, err := r.collection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to mark outbox message as failed: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) Stats(ctx context.Context) (domain.OutboxStats, error) {
+	pendingFilter := bson.M{"status": domain.OutboxStatusPending}
+
+	pending, err := r.collection.CountDocuments(ctx, pendingFilter)
+	if err != nil {
+		return domain.OutboxStats{}, fmt.Errorf("failed to count outbox: %w", err)
+	}
+
+	failing, err := r.collection.CountDocuments(ctx, bson.M{
+		"status":     domain.OutboxStatusPending,
+		"last_error": bson.M{"$exists": true},
+	})
+	if err != nil {
+		return domain.OutboxStats{}, fmt.Errorf("failed to count outbox: %w", err)
+	}
+
+	stats := domain.OutboxStats{Pending: pending, Failing: failing}
+	if pending > 0 {
+		var oldest domain.OutboxMessage
+		opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: 1}})
+		if err := r.collection.FindOne(ctx, pendingFilter, opts).Decode(&oldest); err == nil {
+			stats.OldestPendingAt = &oldest.CreatedAt
+		}
+	}
+	return stats, nil
+}
+
+func (r *outboxRepository) DeletePending(ctx context.Context, subjects []string) error {
+	filter := bson.M{
+		"status":  domain.OutboxStatusPending,
+		"subject": bson.M{"$in": subjects},
+	}
+	if _, err := r.collection.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete outbox messages: %w", err)
+	}
+	return nil
+}
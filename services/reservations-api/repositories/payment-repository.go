@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentRepository interface {
+	// Create devuelve ErrPaymentInProgress si la reserva ya tiene un pago
+	// pending, authorized o captured
+	Create(ctx context.Context, payment domain.Payment) (domain.Payment, error)
+	GetByID(ctx context.Context, id string) (domain.Payment, error)
+	GetByGatewayRef(ctx context.Context, ref string) (domain.Payment, error)
+	GetByReservation(ctx context.Context, reservationID string) ([]domain.Payment, error)
+	FindSuccessful(ctx context.Context, reservationID string) (domain.Payment, bool, error)
+	// Update reemplaza el pago si su versión sigue siendo la leída; si otro
+	// proceso lo modificó antes devuelve ErrPaymentConflict
+	Update(ctx context.Context, payment domain.Payment) (domain.Payment, error)
+	// RecordWebhook guarda el id del callback; devuelve false si ya se había procesado
+	RecordWebhook(ctx context.Context, receipt domain.WebhookReceipt) (bool, error)
+	// ForgetWebhook borra el registro para que el proveedor pueda reintentar
+	ForgetWebhook(ctx context.Context, eventID string) error
+}
+
+type paymentRepository struct {
+	collection *mongo.Collection
+	webhooks   *mongo.Collection
+}
+
+func NewPaymentRepository(db *mongo.Database) PaymentRepository {
+	return &paymentRepository{
+		collection: db.Collection("payments"),
+		webhooks:   db.Collection("payment_webhooks"),
+	}
+}
+
+func (r *paymentRepository) Create(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	if payment.ID.IsZero() {
+		payment.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	payment.CreatedAt = now
+	payment.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, payment); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.Payment{}, utils.ErrPaymentInProgress
+		}
+		return domain.Payment{}, fmt.Errorf("failed to create payment: %w", err)
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) GetByID(ctx context.Context, id string) (domain.Payment, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("invalid id format: %w", err)
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+func (r *paymentRepository) GetByGatewayRef(ctx context.Context, ref string) (domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"gateway_ref": ref})
+}
+
+func (r *paymentRepository) findOne(ctx context.Context, filter bson.M) (domain.Payment, error) {
+	var payment domain.Payment
+	err := r.collection.FindOne(ctx, filter).Decode(&payment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.Payment{}, utils.ErrPaymentNotFound
+		}
+		return domain.Payment{}, fmt.Errorf("failed to fetch payment: %w", err)
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) GetByReservation(ctx context.Context, reservationID string) ([]domain.Payment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"reservation_id": reservationID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch payments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	payments := []domain.Payment{}
+	if err := cursor.All(ctx, &payments); err != nil {
+		return nil, fmt.Errorf("failed to decode payments: %w", err)
+	}
+	return payments, nil
+}
+
+// FindSuccessful devuelve el pago autorizado o capturado de la reserva, si existe
+func (r *paymentRepository) FindSuccessful(ctx context.Context, reservationID string) (domain.Payment, bool, error) {
+	filter := bson.M{
+		"reservation_id": reservationID,
+		"status": bson.M{"$in": []domain.PaymentStatus{
+			domain.PaymentStatusAuthorized,
+			domain.PaymentStatusCaptured,
+		}},
+	}
+	payment, err := r.findOne(ctx, filter)
+	if err == utils.ErrPaymentNotFound {
+		return domain.Payment{}, false, nil
+	}
+	if err != nil {
+		return domain.Payment{}, false, err
+	}
+	return payment, true, nil
+}
+
+func (r *paymentRepository) Update(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	filter := bson.M{"_id": payment.ID, "version": payment.Version}
+	if payment.Version == 0 {
+		// Los pagos anteriores al versionado no tienen el campo
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	payment.Version++
+	payment.UpdatedAt = time.Now()
+	result, err := r.collection.ReplaceOne(ctx, filter, payment)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("failed to update payment: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		if _, err := r.findOne(ctx, bson.M{"_id": payment.ID}); err != nil {
+			return domain.Payment{}, err
+		}
+		return domain.Payment{}, utils.ErrPaymentConflict
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) RecordWebhook(ctx context.Context, receipt domain.WebhookReceipt) (bool, error) {
+	_, err := r.webhooks.InsertOne(ctx, receipt)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to record webhook: %w", err)
+	}
+	return true, nil
+}
+
+func (r *paymentRepository) ForgetWebhook(ctx context.Context, eventID string) error {
+	if _, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": eventID}); err != nil {
+		return fmt.Errorf("failed to forget webhook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error)
+	GetByID(ctx context.Context, id string) (domain.Promotion, error)
+	GetByCode(ctx context.Context, code string) (domain.Promotion, error)
+	List(ctx context.Context) ([]domain.Promotion, error)
+	Update(ctx context.Context, id primitive.ObjectID, set bson.M) (domain.Promotion, error)
+	// UserUses devuelve cuántas veces canjeó el usuario el código
+	UserUses(ctx context.Context, id primitive.ObjectID, userID uint) (int, error)
+	// Redeem suma un uso al código y al usuario sin pasar los límites;
+	// devuelve ErrPromoCodeExhausted si alguno se alcanzó
+	Redeem(ctx context.Context, promotion domain.Promotion, userID uint) error
+	// Release devuelve un uso canjeado (hold vencido o reserva no creada)
+	Release(ctx context.Context, id primitive.ObjectID, userID uint) error
+}
+
+type promotionRepository struct {
+	collection *mongo.Collection
+	// usage lleva un contador por código y usuario para el límite por usuario
+	usage *mongo.Collection
+}
+
+func NewPromotionRepository(db *mongo.Database) PromotionRepository {
+	return &promotionRepository{
+		collection: db.Collection("promotions"),
+		usage:      db.Collection("promotion_usage"),
+	}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	if promotion.ID.IsZero() {
+		promotion.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	promotion.CreatedAt = now
+	promotion.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, promotion)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.Promotion{}, utils.ErrPromoCodeTaken
+	}
+	if err != nil {
+		return domain.Promotion{}, fmt.Errorf("failed to create promotion: %w", err)
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id string) (domain.Promotion, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Promotion{}, utils.ErrPromotionNotFound
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+func (r *promotionRepository) GetByCode(ctx context.Context, code string) (domain.Promotion, error) {
+	return r.findOne(ctx, bson.M{"code": code})
+}
+
+func (r *promotionRepository) findOne(ctx context.Context, filter bson.M) (domain.Promotion, error) {
+	var promotion domain.Promotion
+	err := r.collection.FindOne(ctx, filter).Decode(&promotion)
+	if err == mongo.ErrNoDocuments {
+		return domain.Promotion{}, utils.ErrPromotionNotFound
+	}
+	if err != nil {
+		return domain.Promotion{}, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) List(ctx context.Context) ([]domain.Promotion, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	promotions := []domain.Promotion{}
+	if err := cursor.All(ctx, &promotions); err != nil {
+		return nil, fmt.Errorf("failed to decode promotions: %w", err)
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, id primitive.ObjectID, set bson.M) (domain.Promotion, error) {
+	set["updated_at"] = time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated domain.Promotion
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": set}, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Promotion{}, utils.ErrPromotionNotFound
+	}
+	if err != nil {
+		return domain.Promotion{}, fmt.Errorf("failed to update promotion: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *promotionRepository) UserUses(ctx context.Context, id primitive.ObjectID, userID uint) (int, error) {
+	var usage struct {
+		Count int `bson:"count"`
+	}
+	err := r.usage.FindOne(ctx, bson.M{"_id": usageKey(id, userID)}).Decode(&usage)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get promotion usage: %w", err)
+	}
+	return usage.Count, nil
+}
+
+func (r *promotionRepository) Redeem(ctx context.Context, promotion domain.Promotion, userID uint) error {
+	// Primero el contador del usuario: con el límite en el filtro, si ya se
+	// alcanzó el upsert choca con el _id existente
+	userFilter := bson.M{"_id": usageKey(promotion.ID, userID)}
+	if promotion.MaxUsesPerUser > 0 {
+		userFilter["count"] = bson.M{"$lt": promotion.MaxUsesPerUser}
+	}
+	_, err := r.usage.UpdateOne(ctx, userFilter,
+		bson.M{
+			"$inc":         bson.M{"count": 1},
+			"$setOnInsert": bson.M{"promotion_id": promotion.ID, "user_id": userID},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrPromoCodeExhausted
+	}
+	if err != nil {
+		return fmt.Errorf("failed to redeem promo code: %w", err)
+	}
+
+	filter := bson.M{
+		"_id":    promotion.ID,
+		"active": true,
+		"$or": bson.A{
+			bson.M{"max_uses": 0},
+			bson.M{"$expr": bson.M{"$lt": bson.A{"$uses", "$max_uses"}}},
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{
+		"$inc": bson.M{"uses": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	})
+	if err == nil && result.MatchedCount == 1 {
+		return nil
+	}
+
+	// Devolver el uso del usuario: el código se agotó o falló la escritura
+	if _, undoErr := r.usage.UpdateOne(ctx, bson.M{"_id": usageKey(promotion.ID, userID)}, bson.M{"$inc": bson.M{"count": -1}}); undoErr != nil {
+		return fmt.Errorf("failed to undo promo code usage: %w", undoErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to redeem promo code: %w", err)
+	}
+	return utils.ErrPromoCodeExhausted
+}
+
+func (r *promotionRepository) Release(ctx context.Context, id primitive.ObjectID, userID uint) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "uses": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"uses": -1}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release promo code: %w", err)
+	}
+
+	_, err = r.usage.UpdateOne(ctx,
+		bson.M{"_id": usageKey(id, userID), "count": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"count": -1}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release promo code usage: %w", err)
+	}
+	return nil
+}
+
+func usageKey(id primitive.ObjectID, userID uint) string {
+	return fmt.Sprintf("%s:%d", id.Hex(), userID)
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReportRepository calcula con pipelines de agregación los datos crudos
+// de los reportes; los precios y atributos de habitación los agrega el servicio
+type ReportRepository interface {
+	// SoldRoomNights devuelve, por habitación, las noches de reservas activas
+	// o completadas que caen dentro de [from, to) y su ingreso: el total
+	// acordado de cada reserva prorrateado por las noches dentro del rango
+	SoldRoomNights(ctx context.Context, from, to string) ([]domain.RoomNights, error)
+	// BookingStats resume las reservas creadas en [from, to)
+	BookingStats(ctx context.Context, from, to time.Time) (domain.BookingStats, error)
+}
+
+type reportRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReportRepository(db *mongo.Database) ReportRepository {
+	return &reportRepository{
+		collection: db.Collection("reservations"),
+	}
+}
+
+func (r *reportRepository) SoldRoomNights(ctx context.Context, from, to string) ([]domain.RoomNights, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status": bson.M{"$in": bson.A{
+				domain.ReservationStatusActive,
+				domain.ReservationStatusCompleted,
+			}},
+			"start_date": bson.M{"$lt": to},
+			"end_date":   bson.M{"$gt": from},
+		}}},
+		// Recortar la estadía al rango: las fechas YYYY-MM-DD se comparan como strings
+		{{Key: "$project", Value: bson.M{
+			"room_id": 1,
+			"total":   "$pricing.total",
+			"start":   bson.M{"$dateFromString": bson.M{"dateString": bson.M{"$max": bson.A{"$start_date", from}}}},
+			"end":     bson.M{"$dateFromString": bson.M{"dateString": bson.M{"$min": bson.A{"$end_date", to}}}},
+			"stay": bson.M{"$dateDiff": bson.M{
+				"startDate": bson.M{"$dateFromString": bson.M{"dateString": "$start_date"}},
+				"endDate":   bson.M{"$dateFromString": bson.M{"dateString": "$end_date"}},
+				"unit":      "day",
+			}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"nights": bson.M{"$dateDiff": bson.M{
+				"startDate": "$start",
+				"endDate":   "$end",
+				"unit":      "day",
+			}},
+			"priced": bson.M{"$ne": bson.A{bson.M{"$type": "$total"}, "missing"}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$room_id",
+			"nights": bson.M{"$sum": "$nights"},
+			"revenue": bson.M{"$sum": bson.M{"$cond": bson.A{
+				"$priced",
+				bson.M{"$divide": bson.A{bson.M{"$multiply": bson.A{"$total", "$nights"}}, "$stay"}},
+				0,
+			}}},
+			"unpriced_nights": bson.M{"$sum": bson.M{"$cond": bson.A{"$priced", 0, "$nights"}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate room nights: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var nights []domain.RoomNights
+	if err := cursor.All(ctx, &nights); err != nil {
+		return nil, fmt.Errorf("failed to decode room nights: %w", err)
+	}
+	return nights, nil
+}
+
+func (r *reportRepository) BookingStats(ctx context.Context, from, to time.Time) (domain.BookingStats, error) {
+	pipeline := mongo.Pipeline{
+		// Los holds que vencieron o siguen pendientes no son reservas
+		{{Key: "$match", Value: bson.M{
+			"created_at": bson.M{"$gte": from, "$lt": to},
+			"status": bson.M{"$in": bson.A{
+				domain.ReservationStatusActive,
+				domain.ReservationStatusCanceled,
+				domain.ReservationStatusNoShow,
+				domain.ReservationStatusCompleted,
+			}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"canceled": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$status", domain.ReservationStatusCanceled}}, 1, 0,
+			}},
+			"lead_time": bson.M{"$dateDiff": bson.M{
+				"startDate": "$created_at",
+				"endDate":   bson.M{"$dateFromString": bson.M{"dateString": "$start_date"}},
+				"unit":      "day",
+			}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                nil,
+			"bookings":           bson.M{"$sum": 1},
+			"cancellations":      bson.M{"$sum": "$canceled"},
+			"avg_lead_time_days": bson.M{"$avg": "$lead_time"},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return domain.BookingStats{}, fmt.Errorf("failed to aggregate booking stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stats domain.BookingStats
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&stats); err != nil {
+			return domain.BookingStats{}, fmt.Errorf("failed to decode booking stats: %w", err)
+		}
+	}
+	return stats, cursor.Err()
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// listCursor identifica la última reserva de una página. La paginación es
+// por keyset sobre (campo de orden, _id), así que no se saltea ni repite
+// documentos aunque se inserten reservas entre página y página.
+type listCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// List devuelve una página de reservas según los filtros de la consulta
+func (r *reservationRepository) List(ctx context.Context, query domain.ReservationListQuery) (domain.ReservationPage, error) {
+	field, direction := sortSpec(query.Sort)
+
+	conditions := listConditions(query)
+	filter := bson.M{}
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+
+	page := domain.ReservationPage{Reservations: []domain.Reservation{}}
+	if query.IncludeTotal {
+		total, err := r.collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return domain.ReservationPage{}, fmt.Errorf("failed to count reservations: %w", err)
+		}
+		page.Total = &total
+	}
+
+	if query.Cursor != "" {
+		after, err := cursorCondition(query.Cursor, query.Sort, field, direction)
+		if err != nil {
+			return domain.ReservationPage{}, err
+		}
+		filter["$and"] = append(conditions, after)
+	}
+
+	// Se pide un documento extra para saber si hay una página siguiente
+	opts := options.Find().
+		SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(int64(query.Limit + 1))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.ReservationPage{}, fmt.Errorf("failed to fetch reservations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &page.Reservations); err != nil {
+		return domain.ReservationPage{}, fmt.Errorf("failed to decode reservations: %w", err)
+	}
+
+	if len(page.Reservations) > query.Limit {
+		page.Reservations = page.Reservations[:query.Limit]
+		next := encodeCursor(query.Sort, field, page.Reservations[query.Limit-1])
+		page.NextCursor = &next
+	}
+	return page, nil
+}
+
+func listConditions(query domain.ReservationListQuery) bson.A {
+	conditions := bson.A{}
+	if query.UserID != nil {
+		conditions = append(conditions, bson.M{"user_id": *query.UserID})
+	}
+	if len(query.Statuses) > 0 {
+		conditions = append(conditions, bson.M{"status": bson.M{"$in": query.Statuses}})
+	}
+	if query.RoomID != nil {
+		conditions = append(conditions, bson.M{"room_id": *query.RoomID})
+	}
+	// Reservas que ocupan al menos una noche de [from, to)
+	if query.From != "" {
+		conditions = append(conditions, bson.M{"end_date": bson.M{"$gt": query.From}})
+	}
+	if query.To != "" {
+		conditions = append(conditions, bson.M{"start_date": bson.M{"$lt": query.To}})
+	}
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$gt": *query.CreatedAfter}})
+	}
+	return conditions
+}
+
+func sortSpec(sort string) (string, int) {
+	if strings.HasPrefix(sort, "-") {
+		return strings.TrimPrefix(sort, "-"), -1
+	}
+	return sort, 1
+}
+
+func encodeCursor(sort, field string, last domain.Reservation) string {
+	c := listCursor{Sort: sort, ID: last.ID.Hex()}
+	if field == "created_at" {
+		c.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	} else {
+		c.Value = last.StartDate
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// cursorCondition arma la condición "después del cursor" para el orden pedido
+func cursorCondition(encoded, sort, field string, direction int) (bson.M, error) {
+	invalid := fmt.Errorf("%w: cursor invalido", utils.ErrInvalidReservationData)
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalid
+	}
+	var c listCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Sort != sort {
+		// Un cursor solo vale para el mismo orden con el que se generó
+		return nil, invalid
+	}
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var value interface{} = c.Value
+	if field == "created_at" {
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, invalid
+		}
+		value = t
+	}
+
+	op := "$gt"
+	if direction < 0 {
+		op = "$lt"
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{op: id}},
+	}}, nil
+}
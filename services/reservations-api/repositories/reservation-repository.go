@@ -7,19 +7,38 @@ import (
 	"time"
 
 	"reservations-api/domain"
+	"reservations-api/utils"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ReservationRepository interface {
-	GetmyReservations(ctx context.Context, userID uint) ([]domain.Reservation, error)
-	GetAll(ctx context.Context) ([]domain.Reservation, error)
+	List(ctx context.Context, query domain.ReservationListQuery) (domain.ReservationPage, error)
 	Create(ctx context.Context, reservation domain.Reservation) (domain.Reservation, error)
-	Delete(ctx context.Context, id string, reason string) error
+	Delete(ctx context.Context, id string, reason string, quote *domain.CancellationQuote) error
 	GetByID(ctx context.Context, id string) (domain.Reservation, error)
 	HasActiveOverlap(ctx context.Context, roomID uint, startDate, endDate string) (bool, error)
+	HasOverlapExcluding(ctx context.Context, roomID uint, startDate, endDate string, exclude []primitive.ObjectID) (bool, error)
+	FindByGroup(ctx context.Context, groupID primitive.ObjectID) ([]domain.Reservation, error)
+	UpdateAllocation(ctx context.Context, id primitive.ObjectID, startDate, endDate, guestName string) (domain.Reservation, error)
+	// SetPricing reemplaza el precio acordado; nil lo borra
+	SetPricing(ctx context.Context, id primitive.ObjectID, pricing *domain.ReservationPricing) error
+	// DeleteByGroup borra físicamente las reservas de un grupo; solo para deshacer una creación fallida
+	DeleteByGroup(ctx context.Context, groupID primitive.ObjectID) error
+	Confirm(ctx context.Context, id string, now time.Time) (domain.Reservation, error)
+	CheckIn(ctx context.Context, id string, now time.Time) (domain.Reservation, error)
+	CheckOut(ctx context.Context, id string, now time.Time) (domain.Reservation, error)
+	ExpireNextHold(ctx context.Context, now time.Time) (domain.Reservation, bool, error)
+	MarkNextNoShow(ctx context.Context, startedBy string, now time.Time) (domain.Reservation, bool, error)
+	CompleteNextStay(ctx context.Context, endedBy string, now time.Time) (domain.Reservation, bool, error)
+	FindBlocking(ctx context.Context, roomIDs []uint, from, to string) ([]domain.Reservation, error)
+	FindUpcomingByRoom(ctx context.Context, roomID uint, from string) ([]domain.Reservation, error)
+	FlagRelocation(ctx context.Context, id string, info domain.RelocationInfo) (bool, error)
+	Relocate(ctx context.Context, id string, fromRoomID, toRoomID uint, now time.Time) (domain.Reservation, bool, error)
+	FindExternalBlocks(ctx context.Context, roomID uint, feedURL, from string) ([]domain.Reservation, error)
 }
 
 type reservationRepository struct {
@@ -32,33 +51,6 @@ func NewReservationRepository(db *mongo.Database) ReservationRepository {
 	}
 }
 
-func (r *reservationRepository) GetmyReservations(ctx context.Context, userID uint) ([]domain.Reservation, error) {
-	filter := bson.M{"user_id": userID}
-	cursor, err := r.collection.Find(ctx, filter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch reservations: %w", err)
-	}
-	defer cursor.Close(ctx)
-	var reservations []domain.Reservation
-	if err := cursor.All(ctx, &reservations); err != nil {
-		return nil, fmt.Errorf("failed to decode reservations: %w", err)
-	}
-	return reservations, nil
-}
-
-func (r *reservationRepository) GetAll(ctx context.Context) ([]domain.Reservation, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch reservations: %w", err)
-	}
-	defer cursor.Close(ctx)
-	var reservations []domain.Reservation
-	if err := cursor.All(ctx, &reservations); err != nil {
-		return nil, fmt.Errorf("failed to decode reservations: %w", err)
-	}
-	return reservations, nil
-}
-
 // Create persiste una Reservation en MongoDB y devuelve la entidad guardada.
 func (r *reservationRepository) Create(ctx context.Context, reservation domain.Reservation) (domain.Reservation, error) {
 	// Estado por defecto si no viene seteado
@@ -85,8 +77,9 @@ func (r *reservationRepository) Create(ctx context.Context, reservation domain.R
 	return reservation, nil
 }
 
-// Delete marca una reserva como cancelada (soft delete)
-func (r *reservationRepository) Delete(ctx context.Context, id string, reason string) error {
+// Delete marca una reserva como cancelada (soft delete). Devuelve
+// ErrReservationNotCancelable si ya no estaba activa ni retenida.
+func (r *reservationRepository) Delete(ctx context.Context, id string, reason string, quote *domain.CancellationQuote) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return fmt.Errorf("invalid id format: %w", err)
@@ -94,20 +87,25 @@ func (r *reservationRepository) Delete(ctx context.Context, id string, reason st
 
 	now := time.Now()
 
-	// Solo cancela si esta activa
+	// Solo cancela si esta activa o retenida (hold)
 	filter := bson.M{
-		"_id":    objID,
-		"status": domain.ReservationStatusActive,
+		"_id": objID,
+		"status": bson.M{"$in": []domain.ReservationStatus{
+			domain.ReservationStatusActive,
+			domain.ReservationStatusPending,
+		}},
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"status":        domain.ReservationStatusCanceled,
-			"cancel_reason": reason,
-			"deleted_at":    now,
-			"updated_at":    now,
-		},
+	set := bson.M{
+		"status":        domain.ReservationStatusCanceled,
+		"cancel_reason": reason,
+		"deleted_at":    now,
+		"updated_at":    now,
 	}
+	if quote != nil {
+		set["cancellation"] = quote
+	}
+	update := bson.M{"$set": set}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -123,8 +121,8 @@ func (r *reservationRepository) Delete(ctx context.Context, id string, reason st
 		if count == 0 {
 			return errors.New("reservation not found")
 		}
-		// Ya estaba cancelada
-		return nil
+		// Ya estaba cancelada: otro pedido ganó la carrera y es el que reembolsa
+		return utils.ErrReservationNotCancelable
 	}
 
 	return nil
@@ -149,17 +147,19 @@ func (r *reservationRepository) GetByID(ctx context.Context, id string) (domain.
 	return reservation, nil
 }
 
-// HasActiveOverlap verifica si ya existe una reserva activa para la habitación en el rango dado.
+// HasActiveOverlap verifica si ya existe una reserva activa (o un hold vigente)
+// para la habitación en el rango dado. Los rangos son semiabiertos: el día de
+// salida de una reserva puede ser el de llegada de la siguiente.
 func (r *reservationRepository) HasActiveOverlap(ctx context.Context, roomID uint, startDate, endDate string) (bool, error) {
 	filter := bson.M{
 		"room_id": roomID,
-		"status":  domain.ReservationStatusActive,
 		"start_date": bson.M{
-			"$lte": endDate,
+			"$lt": endDate,
 		},
 		"end_date": bson.M{
-			"$gte": startDate,
+			"$gt": startDate,
 		},
+		"$or": blockingStatusFilter(time.Now()),
 	}
 
 	err := r.collection.FindOne(ctx, filter).Err()
@@ -172,3 +172,442 @@ func (r *reservationRepository) HasActiveOverlap(ctx context.Context, roomID uin
 
 	return true, nil
 }
+
+// blockingStatusFilter devuelve las condiciones de status que bloquean una
+// habitación: reservas activas y holds pending que todavía no expiraron.
+func blockingStatusFilter(now time.Time) bson.A {
+	return bson.A{
+		bson.M{"status": domain.ReservationStatusActive},
+		bson.M{
+			"status":     domain.ReservationStatusPending,
+			"expires_at": bson.M{"$gt": now},
+		},
+	}
+}
+
+// Confirm convierte un hold pending vigente en una reserva activa.
+func (r *reservationRepository) Confirm(ctx context.Context, id string, now time.Time) (domain.Reservation, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Reservation{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	filter := bson.M{
+		"_id":        objID,
+		"status":     domain.ReservationStatusPending,
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       domain.ReservationStatusActive,
+			"confirmed_at": now,
+			"updated_at":   now,
+		},
+		"$unset": bson.M{"expires_at": ""},
+	}
+
+	var confirmed domain.Reservation
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&confirmed)
+	if err == nil {
+		return confirmed, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return domain.Reservation{}, fmt.Errorf("confirmar reserva: %w", err)
+	}
+
+	// No se pudo confirmar: averiguar por qué
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+	switch current.Status {
+	case domain.ReservationStatusPending, domain.ReservationStatusExpired:
+		return domain.Reservation{}, utils.ErrHoldExpired
+	default:
+		return domain.Reservation{}, utils.ErrReservationNotPending
+	}
+}
+
+// ExpireNextHold marca como expirado un hold pending cuyo TTL venció y lo
+// devuelve. La actualización es atómica para que dos instancias no expiren
+// el mismo hold dos veces; found=false si no quedan holds vencidos.
+func (r *reservationRepository) ExpireNextHold(ctx context.Context, now time.Time) (domain.Reservation, bool, error) {
+	filter := bson.M{
+		"status":     domain.ReservationStatusPending,
+		"expires_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     domain.ReservationStatusExpired,
+			"updated_at": now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var reservation domain.Reservation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&reservation)
+	if err == mongo.ErrNoDocuments {
+		return domain.Reservation{}, false, nil
+	}
+	if err != nil {
+		return domain.Reservation{}, false, fmt.Errorf("expirar hold: %w", err)
+	}
+	return reservation, true, nil
+}
+
+// MarkNextNoShow marca como no-show una reserva activa sin check-in que
+// empezó a más tardar en `startedBy`. Los bloqueos importados de calendarios
+// externos no tienen check-in, así que no se consideran.
+func (r *reservationRepository) MarkNextNoShow(ctx context.Context, startedBy string, now time.Time) (domain.Reservation, bool, error) {
+	filter := bson.M{
+		"status":        domain.ReservationStatusActive,
+		"start_date":    bson.M{"$lte": startedBy},
+		"checked_in_at": bson.M{"$exists": false},
+		"external":      bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     domain.ReservationStatusNoShow,
+			"updated_at": now,
+		},
+	}
+	return r.closeNext(ctx, filter, update)
+}
+
+// CompleteNextStay cierra una estadía activa que terminó a más tardar en
+// `endedBy`; si el huésped no hizo check-out se registra ahora
+func (r *reservationRepository) CompleteNextStay(ctx context.Context, endedBy string, now time.Time) (domain.Reservation, bool, error) {
+	filter := bson.M{
+		"status":   domain.ReservationStatusActive,
+		"end_date": bson.M{"$lte": endedBy},
+		"$or": bson.A{
+			bson.M{"checked_in_at": bson.M{"$exists": true}},
+			bson.M{"external": bson.M{"$exists": true}},
+		},
+	}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"status":         domain.ReservationStatusCompleted,
+			"checked_out_at": bson.M{"$ifNull": bson.A{"$checked_out_at", now}},
+			"updated_at":     now,
+		}}},
+	}
+	return r.closeNext(ctx, filter, update)
+}
+
+// closeNext aplica el cierre a una sola reserva de forma atómica, igual que
+// ExpireNextHold, para que el job pueda encolar un evento por reserva
+func (r *reservationRepository) closeNext(ctx context.Context, filter bson.M, update interface{}) (domain.Reservation, bool, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var reservation domain.Reservation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&reservation)
+	if err == mongo.ErrNoDocuments {
+		return domain.Reservation{}, false, nil
+	}
+	if err != nil {
+		return domain.Reservation{}, false, fmt.Errorf("cerrar reserva: %w", err)
+	}
+	return reservation, true, nil
+}
+
+// FindBlocking devuelve las reservas activas y holds vigentes de las
+// habitaciones que ocupan alguna noche en [from, to). La consulta usa el
+// índice room_id/start_date/end_date/status.
+func (r *reservationRepository) FindBlocking(ctx context.Context, roomIDs []uint, from, to string) ([]domain.Reservation, error) {
+	filter := bson.M{
+		"room_id":    bson.M{"$in": roomIDs},
+		"start_date": bson.M{"$lt": to},
+		"end_date":   bson.M{"$gt": from},
+		"$or":        blockingStatusFilter(time.Now()),
+	}
+	opts := options.Find().SetProjection(bson.M{
+		"room_id":    1,
+		"start_date": 1,
+		"end_date":   1,
+		"status":     1,
+		"expires_at": 1,
+	})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reservations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []domain.Reservation
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, fmt.Errorf("failed to decode reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// FindUpcomingByRoom devuelve las reservas activas y holds vigentes de la
+// habitación que empiezan a partir de `from`
+func (r *reservationRepository) FindUpcomingByRoom(ctx context.Context, roomID uint, from string) ([]domain.Reservation, error) {
+	filter := bson.M{
+		"room_id":    roomID,
+		"start_date": bson.M{"$gte": from},
+		"$or":        blockingStatusFilter(time.Now()),
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reservations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []domain.Reservation
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, fmt.Errorf("failed to decode reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// FlagRelocation marca la reserva como pendiente de reubicación. Devuelve
+// false si ya estaba marcada, para no notificar dos veces el mismo problema.
+func (r *reservationRepository) FlagRelocation(ctx context.Context, id string, info domain.RelocationInfo) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	filter := bson.M{
+		"_id":              objID,
+		"room_id":          info.FromRoomID,
+		"needs_relocation": bson.M{"$ne": true},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"needs_relocation": true,
+			"relocation":       info,
+			"updated_at":       info.FlaggedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("marcar reubicación: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// Relocate mueve una reserva marcada a otra habitación. Solo aplica si la
+// reserva sigue en la habitación original y pendiente de reubicación.
+func (r *reservationRepository) Relocate(ctx context.Context, id string, fromRoomID, toRoomID uint, now time.Time) (domain.Reservation, bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Reservation{}, false, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	filter := bson.M{
+		"_id":              objID,
+		"room_id":          fromRoomID,
+		"needs_relocation": true,
+		"status": bson.M{"$in": bson.A{
+			domain.ReservationStatusActive,
+			domain.ReservationStatusPending,
+		}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"room_id":                 toRoomID,
+			"needs_relocation":        false,
+			"relocation.to_room_id":   toRoomID,
+			"relocation.relocated_at": now,
+			"updated_at":              now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var relocated domain.Reservation
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&relocated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Reservation{}, false, nil
+	}
+	if err != nil {
+		return domain.Reservation{}, false, fmt.Errorf("reubicar reserva: %w", err)
+	}
+	return relocated, true, nil
+}
+
+// CheckIn registra la llegada del huésped. Solo aplica a reservas activas
+// cuya estadía ya empezó y que no tienen check-in previo; el día de salida
+// ya no es una noche de la estadía.
+func (r *reservationRepository) CheckIn(ctx context.Context, id string, now time.Time) (domain.Reservation, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Reservation{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	today := now.Format(utils.DateLayout)
+	filter := bson.M{
+		"_id":           objID,
+		"status":        domain.ReservationStatusActive,
+		"start_date":    bson.M{"$lte": today},
+		"end_date":      bson.M{"$gt": today},
+		"checked_in_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{"checked_in_at": now, "updated_at": now},
+	}
+	return r.updateStay(ctx, objID, filter, update, utils.ErrCheckInNotAllowed)
+}
+
+// CheckOut registra la salida del huésped; requiere un check-in previo
+func (r *reservationRepository) CheckOut(ctx context.Context, id string, now time.Time) (domain.Reservation, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Reservation{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	filter := bson.M{
+		"_id":            objID,
+		"status":         domain.ReservationStatusActive,
+		"checked_in_at":  bson.M{"$exists": true},
+		"checked_out_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{"checked_out_at": now, "updated_at": now},
+	}
+	return r.updateStay(ctx, objID, filter, update, utils.ErrCheckOutNotAllowed)
+}
+
+// updateStay aplica un cambio de estadía y distingue "no existe" de
+// "no está en el estado correcto" cuando el filtro no matchea
+func (r *reservationRepository) updateStay(ctx context.Context, objID primitive.ObjectID, filter, update bson.M, notAllowed error) (domain.Reservation, error) {
+	var updated domain.Reservation
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == nil {
+		return updated, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return domain.Reservation{}, fmt.Errorf("actualizar estadía: %w", err)
+	}
+
+	if _, err := r.GetByID(ctx, objID.Hex()); err != nil {
+		return domain.Reservation{}, err
+	}
+	return domain.Reservation{}, notAllowed
+}
+
+// HasOverlapExcluding es como HasActiveOverlap pero ignora las reservas
+// indicadas, para poder mover de fecha reservas que se solapan consigo mismas
+func (r *reservationRepository) HasOverlapExcluding(ctx context.Context, roomID uint, startDate, endDate string, exclude []primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id":        bson.M{"$nin": exclude},
+		"room_id":    roomID,
+		"start_date": bson.M{"$lt": endDate},
+		"end_date":   bson.M{"$gt": startDate},
+		"$or":        blockingStatusFilter(time.Now()),
+	}
+
+	err := r.collection.FindOne(ctx, filter).Err()
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("overlap lookup failed: %w", err)
+	}
+	return true, nil
+}
+
+// FindByGroup devuelve todas las reservas de un grupo, en cualquier estado
+func (r *reservationRepository) FindByGroup(ctx context.Context, groupID primitive.ObjectID) ([]domain.Reservation, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "room_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"group_id": groupID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reservations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []domain.Reservation
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, fmt.Errorf("failed to decode reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// UpdateAllocation cambia fechas y huésped de una reserva activa del grupo
+func (r *reservationRepository) UpdateAllocation(ctx context.Context, id primitive.ObjectID, startDate, endDate, guestName string) (domain.Reservation, error) {
+	filter := bson.M{
+		"_id": id,
+		"status": bson.M{"$in": bson.A{
+			domain.ReservationStatusActive,
+			domain.ReservationStatusPending,
+		}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"start_date": startDate,
+			"end_date":   endDate,
+			"guest_name": guestName,
+			"updated_at": time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated domain.Reservation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Reservation{}, utils.ErrReservationNotFound
+	}
+	if err != nil {
+		return domain.Reservation{}, fmt.Errorf("actualizar reserva: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *reservationRepository) SetPricing(ctx context.Context, id primitive.ObjectID, pricing *domain.ReservationPricing) error {
+	update := bson.M{
+		"$set": bson.M{"pricing": pricing, "updated_at": time.Now()},
+	}
+	if pricing == nil {
+		update = bson.M{
+			"$unset": bson.M{"pricing": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("actualizar precio de la reserva: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return utils.ErrReservationNotFound
+	}
+	return nil
+}
+
+func (r *reservationRepository) DeleteByGroup(ctx context.Context, groupID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"group_id": groupID}); err != nil {
+		return fmt.Errorf("failed to delete group reservations: %w", err)
+	}
+	return nil
+}
+
+// FindExternalBlocks devuelve los bloqueos activos importados de un feed para
+// la habitación que terminan después de `from`
+func (r *reservationRepository) FindExternalBlocks(ctx context.Context, roomID uint, feedURL, from string) ([]domain.Reservation, error) {
+	filter := bson.M{
+		"room_id":           roomID,
+		"external.feed_url": feedURL,
+		"status":            domain.ReservationStatusActive,
+		"end_date":          bson.M{"$gt": from},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch external blocks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []domain.Reservation
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, fmt.Errorf("failed to decode external blocks: %w", err)
+	}
+	return reservations, nil
+}
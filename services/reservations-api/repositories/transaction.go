@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TxRunner ejecuta una función dentro de una transacción de MongoDB. Los
+// repositorios llamados con el ctx recibido participan de la transacción.
+type TxRunner interface {
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type mongoTxRunner struct {
+	client        *mongo.Client
+	transactional bool
+}
+
+// NewTxRunner verifica que el despliegue soporte transacciones (replica set
+// o mongos). Un standalone se rechaza salvo que allowStandalone lo habilite;
+// en ese caso las operaciones se ejecutan sin transacción y el outbox puede
+// quedar desalineado con las reservas si falla una escritura intermedia.
+func NewTxRunner(db *mongo.Database, allowStandalone bool) (TxRunner, error) {
+	runner := &mongoTxRunner{client: db.Client()}
+
+	var hello bson.M
+	err := db.RunCommand(context.Background(), bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect transaction support: %w", err)
+	}
+	_, isReplicaSet := hello["setName"]
+	runner.transactional = isReplicaSet || hello["msg"] == "isdbgrid"
+
+	if !runner.transactional {
+		if !allowStandalone {
+			return nil, errors.New("MongoDB standalone does not support transactions; use a replica set or set MONGO_ALLOW_STANDALONE=true")
+		}
+		log.Println("Advertencia: MongoDB standalone, el outbox se escribe sin transacción")
+	}
+	return runner, nil
+}
+
+func (r *mongoTxRunner) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !r.transactional {
+		return fn(ctx)
+	}
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
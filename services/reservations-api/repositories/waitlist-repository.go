@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry domain.WaitlistEntry) (domain.WaitlistEntry, error)
+	GetByID(ctx context.Context, id string) (domain.WaitlistEntry, error)
+	// FindWaiting devuelve, por orden de llegada, las entradas en espera que
+	// piden la habitación (o su tipo) y se solapan con [from, to)
+	FindWaiting(ctx context.Context, roomID uint, roomType, from, to, today string) ([]domain.WaitlistEntry, error)
+	// ClaimOffer pasa la entrada de waiting a offered; false si otro la tomó antes
+	ClaimOffer(ctx context.Context, id primitive.ObjectID, roomID uint) (bool, error)
+	AttachOffer(ctx context.Context, id primitive.ObjectID, reservationID string, expiresAt time.Time) error
+	RevertOffer(ctx context.Context, id primitive.ObjectID) error
+	// ResolveOffer cierra la oferta asociada a un hold; false si no había ninguna
+	ResolveOffer(ctx context.Context, reservationID string, status domain.WaitlistStatus) (bool, error)
+	Cancel(ctx context.Context, id string) (domain.WaitlistEntry, error)
+}
+
+type waitlistRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWaitlistRepository(db *mongo.Database) WaitlistRepository {
+	return &waitlistRepository{
+		collection: db.Collection("waitlist"),
+	}
+}
+
+func (r *waitlistRepository) Create(ctx context.Context, entry domain.WaitlistEntry) (domain.WaitlistEntry, error) {
+	now := time.Now()
+	entry.ID = primitive.NewObjectID()
+	entry.Status = domain.WaitlistStatusWaiting
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, entry); err != nil {
+		return domain.WaitlistEntry{}, fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *waitlistRepository) GetByID(ctx context.Context, id string) (domain.WaitlistEntry, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.WaitlistEntry{}, utils.ErrWaitlistEntryNotFound
+	}
+
+	var entry domain.WaitlistEntry
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return domain.WaitlistEntry{}, utils.ErrWaitlistEntryNotFound
+	}
+	if err != nil {
+		return domain.WaitlistEntry{}, fmt.Errorf("failed to fetch waitlist entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *waitlistRepository) FindWaiting(ctx context.Context, roomID uint, roomType, from, to, today string) ([]domain.WaitlistEntry, error) {
+	filter := bson.M{
+		"status": domain.WaitlistStatusWaiting,
+		"$or": bson.A{
+			bson.M{"room_id": roomID},
+			bson.M{"room_id": bson.M{"$exists": false}, "room_type": roomType},
+		},
+		"start_date": bson.M{"$lt": to, "$gte": today},
+		"end_date":   bson.M{"$gt": from},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch waitlist: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []domain.WaitlistEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode waitlist: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepository) ClaimOffer(ctx context.Context, id primitive.ObjectID, roomID uint) (bool, error) {
+	filter := bson.M{"_id": id, "status": domain.WaitlistStatusWaiting}
+	update := bson.M{
+		"$set": bson.M{
+			"status":          domain.WaitlistStatusOffered,
+			"offered_room_id": roomID,
+			"updated_at":      time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim waitlist entry: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *waitlistRepository) AttachOffer(ctx context.Context, id primitive.ObjectID, reservationID string, expiresAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"reservation_id":   reservationID,
+			"offer_expires_at": expiresAt,
+			"updated_at":       time.Now(),
+		},
+	}
+	if _, err := r.collection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to attach waitlist offer: %w", err)
+	}
+	return nil
+}
+
+func (r *waitlistRepository) RevertOffer(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "status": domain.WaitlistStatusOffered}
+	update := bson.M{
+		"$set":   bson.M{"status": domain.WaitlistStatusWaiting, "updated_at": time.Now()},
+		"$unset": bson.M{"offered_room_id": "", "reservation_id": "", "offer_expires_at": ""},
+	}
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to revert waitlist offer: %w", err)
+	}
+	return nil
+}
+
+func (r *waitlistRepository) ResolveOffer(ctx context.Context, reservationID string, status domain.WaitlistStatus) (bool, error) {
+	filter := bson.M{"reservation_id": reservationID, "status": domain.WaitlistStatusOffered}
+	update := bson.M{
+		"$set": bson.M{"status": status, "updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve waitlist offer: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// Cancel da de baja una entrada en espera u ofrecida y devuelve cómo
+// estaba antes, para que el servicio libere el hold si había oferta
+func (r *waitlistRepository) Cancel(ctx context.Context, id string) (domain.WaitlistEntry, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.WaitlistEntry{}, utils.ErrWaitlistEntryNotFound
+	}
+
+	filter := bson.M{
+		"_id": objID,
+		"status": bson.M{"$in": bson.A{
+			domain.WaitlistStatusWaiting,
+			domain.WaitlistStatusOffered,
+		}},
+	}
+	update := bson.M{
+		"$set": bson.M{"status": domain.WaitlistStatusCanceled, "updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var previous domain.WaitlistEntry
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		if _, getErr := r.GetByID(ctx, id); getErr != nil {
+			return domain.WaitlistEntry{}, getErr
+		}
+		return domain.WaitlistEntry{}, utils.ErrWaitlistEntryNotActive
+	}
+	if err != nil {
+		return domain.WaitlistEntry{}, fmt.Errorf("failed to cancel waitlist entry: %w", err)
+	}
+	return previous, nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+// maxCalendarNights limita el rango consultable para acotar el costo de la query
+const maxCalendarNights = 366
+
+type AvailabilityService interface {
+	RoomCalendar(ctx context.Context, roomID uint, from, to string) (domain.RoomAvailability, error)
+	TypeCalendar(ctx context.Context, roomType, from, to string) (domain.TypeAvailability, error)
+}
+
+type availabilityService struct {
+	repository  repositories.ReservationRepository
+	roomsClient *config.RoomsAPIClient
+}
+
+func NewAvailabilityService(repository repositories.ReservationRepository, roomsClient *config.RoomsAPIClient) AvailabilityService {
+	return &availabilityService{
+		repository:  repository,
+		roomsClient: roomsClient,
+	}
+}
+
+func (s *availabilityService) RoomCalendar(ctx context.Context, roomID uint, from, to string) (domain.RoomAvailability, error) {
+	nights, err := calendarNights(from, to)
+	if err != nil {
+		return domain.RoomAvailability{}, err
+	}
+
+	reservations, err := s.repository.FindBlocking(ctx, []uint{roomID}, from, to)
+	if err != nil {
+		return domain.RoomAvailability{}, err
+	}
+
+	calendar := make([]domain.RoomNight, len(nights))
+	for i, night := range nights {
+		calendar[i] = domain.RoomNight{Date: night, Status: domain.NightStatusFree}
+	}
+	for _, reservation := range reservations {
+		status := nightStatus(reservation)
+		for i, night := range nights {
+			// Un booking gana sobre un hold si por algún motivo se pisan
+			if occupiesNight(reservation, night) && calendar[i].Status != domain.NightStatusBooked {
+				calendar[i].Status = status
+				calendar[i].ReservationID = reservation.ID.Hex()
+			}
+		}
+	}
+
+	return domain.RoomAvailability{
+		RoomID: roomID,
+		From:   from,
+		To:     to,
+		Nights: calendar,
+	}, nil
+}
+
+func (s *availabilityService) TypeCalendar(ctx context.Context, roomType, from, to string) (domain.TypeAvailability, error) {
+	nights, err := calendarNights(from, to)
+	if err != nil {
+		return domain.TypeAvailability{}, err
+	}
+
+	rooms, err := s.roomsClient.ListRoomsByType(ctx, roomType)
+	if err != nil {
+		return domain.TypeAvailability{}, fmt.Errorf("failed to obtener habitaciones: %w", err)
+	}
+
+	roomIDs := make([]uint, len(rooms))
+	for i, room := range rooms {
+		roomIDs[i] = room.ID
+	}
+
+	calendar := make([]domain.TypeNight, len(nights))
+	for i, night := range nights {
+		calendar[i] = domain.TypeNight{Date: night, Total: len(rooms)}
+	}
+	if len(roomIDs) == 0 {
+		return domain.TypeAvailability{Type: roomType, From: from, To: to, RoomIDs: roomIDs, Nights: calendar}, nil
+	}
+
+	reservations, err := s.repository.FindBlocking(ctx, roomIDs, from, to)
+	if err != nil {
+		return domain.TypeAvailability{}, err
+	}
+
+	// Estado por habitación y noche; booked tiene prioridad sobre held
+	occupied := make(map[uint]map[int]domain.NightStatus, len(roomIDs))
+	for _, reservation := range reservations {
+		status := nightStatus(reservation)
+		if occupied[reservation.RoomID] == nil {
+			occupied[reservation.RoomID] = make(map[int]domain.NightStatus)
+		}
+		for i, night := range nights {
+			if occupiesNight(reservation, night) && occupied[reservation.RoomID][i] != domain.NightStatusBooked {
+				occupied[reservation.RoomID][i] = status
+			}
+		}
+	}
+
+	for _, roomNights := range occupied {
+		for i, status := range roomNights {
+			switch status {
+			case domain.NightStatusBooked:
+				calendar[i].Booked++
+			case domain.NightStatusHeld:
+				calendar[i].Held++
+			}
+		}
+	}
+	for i := range calendar {
+		calendar[i].Free = calendar[i].Total - calendar[i].Booked - calendar[i].Held
+	}
+
+	return domain.TypeAvailability{
+		Type:    roomType,
+		From:    from,
+		To:      to,
+		RoomIDs: roomIDs,
+		Nights:  calendar,
+	}, nil
+}
+
+func calendarNights(from, to string) ([]string, error) {
+	nights, err := utils.NightsBetween(from, to, maxCalendarNights)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidReservationData, err)
+	}
+	return nights, nil
+}
+
+// occupiesNight indica si la reserva ocupa la noche que empieza en `night`
+// (el día de check-out no cuenta como noche ocupada)
+func occupiesNight(reservation domain.Reservation, night string) bool {
+	return reservation.StartDate <= night && night < reservation.EndDate
+}
+
+func nightStatus(reservation domain.Reservation) domain.NightStatus {
+	if reservation.Status == domain.ReservationStatusPending {
+		return domain.NightStatusHeld
+	}
+	return domain.NightStatusBooked
+}
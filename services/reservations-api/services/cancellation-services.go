@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/utils"
+)
+
+type CancellationService interface {
+	// Quote calcula reembolso y penalidad si la reserva se cancelara en `at`
+	Quote(ctx context.Context, reservation domain.Reservation, at time.Time) (domain.CancellationQuote, error)
+	// PolicyType devuelve la política configurada para el tipo de habitación;
+	// se guarda en la reserva al crearla
+	PolicyType(roomType string) domain.CancellationPolicyType
+}
+
+type cancellationService struct {
+	payments    PaymentService
+	roomsClient *config.RoomsAPIClient
+	config      config.CancellationConfig
+}
+
+func NewCancellationService(payments PaymentService, roomsClient *config.RoomsAPIClient, cfg config.CancellationConfig) CancellationService {
+	return &cancellationService{
+		payments:    payments,
+		roomsClient: roomsClient,
+		config:      cfg,
+	}
+}
+
+func (s *cancellationService) Quote(ctx context.Context, reservation domain.Reservation, at time.Time) (domain.CancellationQuote, error) {
+	checkIn, err := time.ParseInLocation(utils.DateLayout, reservation.StartDate, time.Local)
+	if err != nil {
+		return domain.CancellationQuote{}, err
+	}
+	checkIn = checkIn.Add(time.Duration(s.config.CheckInHour) * time.Hour)
+
+	policy, err := s.policyFor(ctx, reservation)
+	if err != nil {
+		return domain.CancellationQuote{}, err
+	}
+	hoursBefore := checkIn.Sub(at).Hours()
+	refundPercent := policy.RefundPercent(hoursBefore)
+
+	summary, err := s.payments.Summary(ctx, reservation)
+	if err != nil {
+		return domain.CancellationQuote{}, err
+	}
+
+	// La penalidad se calcula sobre el total y se retiene de lo ya cobrado
+	penalty := utils.RoundMoney(summary.ReservationTotal * (100 - refundPercent) / 100)
+	refund := utils.RoundMoney(summary.AmountPaid - penalty)
+	if refund < 0 {
+		refund = 0
+	}
+
+	return domain.CancellationQuote{
+		ReservationID:      reservation.ID.Hex(),
+		Policy:             policy.Type,
+		HoursBeforeCheckIn: utils.RoundMoney(hoursBefore),
+		RefundPercent:      refundPercent,
+		Currency:           summary.Currency,
+		ReservationTotal:   summary.ReservationTotal,
+		AmountPaid:         summary.AmountPaid,
+		PenaltyAmount:      penalty,
+		RefundAmount:       refund,
+		CalculatedAt:       at,
+	}, nil
+}
+
+func (s *cancellationService) PolicyType(roomType string) domain.CancellationPolicyType {
+	return s.config.PolicyFor(roomType).Type
+}
+
+// policyFor usa la política guardada al reservar. Las reservas anteriores
+// no la tienen y se resuelven con el tipo actual de la habitación; si
+// rooms-api no responde o la habitación ya no existe no se adivina (la
+// política por defecto podría reembolsar de más) y se devuelve error.
+func (s *cancellationService) policyFor(ctx context.Context, reservation domain.Reservation) (domain.CancellationPolicy, error) {
+	if policy, ok := domain.DefaultCancellationPolicies[reservation.CancellationPolicy]; ok {
+		return policy, nil
+	}
+
+	room, err := s.roomsClient.GetRoomByID(ctx, reservation.RoomID)
+	if err != nil {
+		log.Printf("No se pudo obtener el tipo de la habitación %d para cancelar la reserva %s: %v",
+			reservation.RoomID, reservation.ID.Hex(), err)
+		return domain.CancellationPolicy{}, utils.ErrCancelPolicyUnknown
+	}
+	return s.config.PolicyFor(room.Type), nil
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+// FolioService arma la cuenta de la reserva a partir del precio acordado,
+// los extras cargados y los pagos
+type FolioService interface {
+	Get(ctx context.Context, reservationID string) (domain.Folio, error)
+	ForReservation(ctx context.Context, reservation domain.Reservation) (domain.Folio, error)
+	AddCharge(ctx context.Context, reservationID string, dto domain.AddFolioChargeDTO) (domain.FolioCharge, error)
+}
+
+type folioService struct {
+	repository   repositories.FolioRepository
+	reservations repositories.ReservationRepository
+	payments     PaymentService
+	roomsClient  *config.RoomsAPIClient
+	currency     string
+	taxRate      float64
+}
+
+func NewFolioService(
+	repository repositories.FolioRepository,
+	reservations repositories.ReservationRepository,
+	payments PaymentService,
+	roomsClient *config.RoomsAPIClient,
+	currency string,
+	invoiceConfig config.InvoiceConfig,
+) FolioService {
+	return &folioService{
+		repository:   repository,
+		reservations: reservations,
+		payments:     payments,
+		roomsClient:  roomsClient,
+		currency:     currency,
+		taxRate:      invoiceConfig.TaxRate,
+	}
+}
+
+func (s *folioService) Get(ctx context.Context, reservationID string) (domain.Folio, error) {
+	reservation, err := s.reservations.GetByID(ctx, reservationID)
+	if err != nil {
+		return domain.Folio{}, err
+	}
+	return s.ForReservation(ctx, reservation)
+}
+
+func (s *folioService) ForReservation(ctx context.Context, reservation domain.Reservation) (domain.Folio, error) {
+	reservationID := reservation.ID.Hex()
+	folio := domain.Folio{ReservationID: reservationID, Currency: s.currency}
+
+	pricing, err := s.stayPricing(ctx, reservation)
+	if err != nil {
+		return domain.Folio{}, err
+	}
+	folio.Lines = append(folio.Lines, domain.FolioLine{
+		Type:        domain.FolioLineRoomNights,
+		Description: fmt.Sprintf("Alojamiento habitación %d (%s a %s)", reservation.RoomID, reservation.StartDate, reservation.EndDate),
+		Date:        reservation.StartDate,
+		Quantity:    pricing.Nights,
+		UnitPrice:   pricing.NightlyRate,
+		Amount:      pricing.Subtotal,
+		TaxRate:     s.taxRate,
+	})
+	if pricing.StayDiscount != nil {
+		folio.Lines = append(folio.Lines, domain.FolioLine{
+			Type:        domain.FolioLineDiscount,
+			Description: fmt.Sprintf("Descuento por estadía de %d noches (%g%%)", pricing.Nights, pricing.StayDiscount.Percent),
+			Date:        reservation.StartDate,
+			Amount:      -pricing.StayDiscount.Amount,
+			TaxRate:     s.taxRate,
+		})
+	}
+	if pricing.Discount != nil {
+		folio.Lines = append(folio.Lines, domain.FolioLine{
+			Type:        domain.FolioLineDiscount,
+			Description: "Descuento código " + pricing.Discount.Code,
+			Date:        reservation.StartDate,
+			Amount:      -pricing.Discount.Amount,
+			TaxRate:     s.taxRate,
+		})
+	}
+
+	charges, err := s.repository.ListCharges(ctx, reservationID)
+	if err != nil {
+		return domain.Folio{}, err
+	}
+	for _, charge := range charges {
+		folio.Lines = append(folio.Lines, domain.FolioLine{
+			Type:        domain.FolioLineExtra,
+			Description: charge.Description,
+			Date:        charge.Date,
+			Quantity:    charge.Quantity,
+			UnitPrice:   charge.UnitPrice,
+			Amount:      charge.Amount,
+			TaxRate:     charge.TaxRate,
+		})
+	}
+	for _, line := range folio.Lines {
+		folio.Charges += line.Amount
+	}
+
+	payments, err := s.payments.GetByReservation(ctx, reservationID)
+	if err != nil {
+		return domain.Folio{}, err
+	}
+	for _, payment := range payments {
+		date := payment.CreatedAt.Format(utils.DateLayout)
+		if payment.CapturedAmount > 0 {
+			folio.Lines = append(folio.Lines, domain.FolioLine{
+				Type:        domain.FolioLinePayment,
+				Description: fmt.Sprintf("Pago %s (%s)", payment.Provider, payment.Policy),
+				Date:        date,
+				Amount:      -payment.CapturedAmount,
+			})
+		}
+		if payment.RefundedAmount > 0 {
+			folio.Lines = append(folio.Lines, domain.FolioLine{
+				Type:        domain.FolioLineRefund,
+				Description: fmt.Sprintf("Reembolso %s", payment.Provider),
+				Date:        date,
+				Amount:      payment.RefundedAmount,
+			})
+		}
+		folio.Paid += payment.CapturedAmount - payment.RefundedAmount
+	}
+
+	folio.Charges = utils.RoundMoney(folio.Charges)
+	folio.Paid = utils.RoundMoney(folio.Paid)
+	folio.Balance = utils.RoundMoney(folio.Charges - folio.Paid)
+	return folio, nil
+}
+
+// stayPricing devuelve el precio acordado al reservar; las reservas
+// anteriores a la cotización se valorizan con las tarifas vigentes del plan
+func (s *folioService) stayPricing(ctx context.Context, reservation domain.Reservation) (domain.ReservationPricing, error) {
+	if reservation.Pricing != nil {
+		return *reservation.Pricing, nil
+	}
+
+	nights, err := utils.CountNights(reservation.StartDate, reservation.EndDate)
+	if err != nil {
+		return domain.ReservationPricing{}, err
+	}
+	rates, err := s.roomsClient.GetRoomRates(ctx, reservation.RoomID, reservation.StartDate, reservation.EndDate)
+	if err != nil {
+		return domain.ReservationPricing{}, fmt.Errorf("failed to obtener tarifas de la habitación: %w", err)
+	}
+	return pricingFromRates(rates, s.currency, nights), nil
+}
+
+// AddCharge carga un extra; solo se aceptan en reservas activas o estadías
+// ya cerradas (para cargos tardíos que se corrigen con nota de crédito)
+func (s *folioService) AddCharge(ctx context.Context, reservationID string, dto domain.AddFolioChargeDTO) (domain.FolioCharge, error) {
+	reservation, err := s.reservations.GetByID(ctx, reservationID)
+	if err != nil {
+		return domain.FolioCharge{}, err
+	}
+	if reservation.Status != domain.ReservationStatusActive && reservation.Status != domain.ReservationStatusCompleted {
+		return domain.FolioCharge{}, utils.ErrFolioClosed
+	}
+
+	date := dto.Date
+	if date == "" {
+		date = time.Now().Format(utils.DateLayout)
+	}
+	taxRate := s.taxRate
+	if dto.TaxRate != nil {
+		taxRate = *dto.TaxRate
+	}
+
+	return s.repository.AddCharge(ctx, domain.FolioCharge{
+		ReservationID: reservation.ID.Hex(),
+		Description:   dto.Description,
+		Date:          date,
+		Quantity:      dto.Quantity,
+		UnitPrice:     dto.UnitPrice,
+		Amount:        utils.RoundMoney(dto.UnitPrice * float64(dto.Quantity)),
+		TaxRate:       taxRate,
+	})
+}
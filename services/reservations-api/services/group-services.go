@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GroupService interface {
+	Create(ctx context.Context, dto domain.CreateGroupReservationDTO) (domain.GroupBookingDetail, error)
+	Get(ctx context.Context, id string) (domain.GroupBookingDetail, error)
+	Update(ctx context.Context, id string, dto domain.UpdateGroupReservationDTO) (domain.GroupBookingDetail, error)
+	Cancel(ctx context.Context, id string, reason string) error
+}
+
+type groupService struct {
+	repository   repositories.GroupRepository
+	reservations repositories.ReservationRepository
+	// Las cancelaciones reutilizan el flujo individual (política, reembolso, eventos)
+	reservationService ReservationService
+	pricing            PricingService
+	cancellations      CancellationService
+	outbox             repositories.OutboxRepository
+	history            HistoryService
+	tx                 repositories.TxRunner
+}
+
+func NewGroupService(
+	repository repositories.GroupRepository,
+	reservations repositories.ReservationRepository,
+	reservationService ReservationService,
+	pricing PricingService,
+	cancellations CancellationService,
+	outbox repositories.OutboxRepository,
+	history HistoryService,
+	tx repositories.TxRunner,
+) GroupService {
+	return &groupService{
+		repository:         repository,
+		reservations:       reservations,
+		reservationService: reservationService,
+		pricing:            pricing,
+		cancellations:      cancellations,
+		outbox:             outbox,
+		history:            history,
+		tx:                 tx,
+	}
+}
+
+// Create reserva todas las habitaciones o ninguna: primero verifica el
+// solapamiento y cotiza cada una, y luego las guarda en una sola transacción
+func (s *groupService) Create(ctx context.Context, dto domain.CreateGroupReservationDTO) (domain.GroupBookingDetail, error) {
+	if dto.EndDate <= dto.StartDate {
+		return domain.GroupBookingDetail{}, fmt.Errorf("%w: end_date debe ser posterior a start_date", utils.ErrInvalidReservationData)
+	}
+
+	roomIDs := make([]uint, 0, len(dto.Rooms))
+	seen := make(map[uint]bool, len(dto.Rooms))
+	for _, room := range dto.Rooms {
+		if seen[room.RoomID] {
+			return domain.GroupBookingDetail{}, fmt.Errorf("%w: habitación %d repetida", utils.ErrInvalidReservationData, room.RoomID)
+		}
+		seen[room.RoomID] = true
+		roomIDs = append(roomIDs, room.RoomID)
+	}
+
+	if err := s.checkRooms(ctx, roomIDs, dto.StartDate, dto.EndDate, nil); err != nil {
+		return domain.GroupBookingDetail{}, err
+	}
+	quotes, err := s.priceRooms(ctx, dto.UserID, roomIDs, dto.StartDate, dto.EndDate)
+	if err != nil {
+		return domain.GroupBookingDetail{}, err
+	}
+
+	groupID := primitive.NewObjectID()
+	var (
+		group    domain.GroupBooking
+		reserved []domain.Reservation
+	)
+	err = s.tx.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		group, err = s.repository.Create(txCtx, domain.GroupBooking{
+			ID:        groupID,
+			UserID:    dto.UserID,
+			Name:      dto.Name,
+			StartDate: dto.StartDate,
+			EndDate:   dto.EndDate,
+			RoomIDs:   roomIDs,
+		})
+		if err != nil {
+			return err
+		}
+
+		reserved = reserved[:0]
+		for _, room := range dto.Rooms {
+			entity := domain.Reservation{
+				UserID:    dto.UserID,
+				RoomID:    room.RoomID,
+				StartDate: dto.StartDate,
+				EndDate:   dto.EndDate,
+				Status:    domain.ReservationStatusActive,
+				GroupID:   &groupID,
+				GuestName: room.GuestName,
+			}
+			applyQuote(&entity, quotes[room.RoomID], s.cancellations)
+			saved, err := s.reservations.Create(txCtx, entity)
+			if err != nil {
+				return err
+			}
+			reserved = append(reserved, saved)
+		}
+
+		// Los eventos se encolan al final para no publicar reservas a medio crear
+		for _, reservation := range reserved {
+			if err := enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(domain.EventReservationCreated, reservation)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Sin soporte de transacciones hay que deshacer a mano lo que se haya guardado
+		s.rollbackCreate(groupID, reserved)
+		return domain.GroupBookingDetail{}, fmt.Errorf("failed to create group reservation: %w", err)
+	}
+
+	return newGroupDetail(group, reserved), nil
+}
+
+func (s *groupService) Get(ctx context.Context, id string) (domain.GroupBookingDetail, error) {
+	group, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return domain.GroupBookingDetail{}, err
+	}
+
+	reservations, err := s.reservations.FindByGroup(ctx, group.ID)
+	if err != nil {
+		return domain.GroupBookingDetail{}, err
+	}
+	return newGroupDetail(group, reservations), nil
+}
+
+// Update aplica un cambio de fechas a todas las habitaciones y/o cambia el
+// huésped de algunas. Si alguna habitación no está libre en las nuevas
+// fechas no se modifica ninguna.
+func (s *groupService) Update(ctx context.Context, id string, dto domain.UpdateGroupReservationDTO) (domain.GroupBookingDetail, error) {
+	group, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return domain.GroupBookingDetail{}, err
+	}
+	if group.Status != domain.GroupStatusActive {
+		return domain.GroupBookingDetail{}, utils.ErrGroupNotActive
+	}
+
+	startDate, endDate := group.StartDate, group.EndDate
+	if dto.StartDate != nil {
+		startDate = *dto.StartDate
+	}
+	if dto.EndDate != nil {
+		endDate = *dto.EndDate
+	}
+	if endDate <= startDate {
+		return domain.GroupBookingDetail{}, fmt.Errorf("%w: end_date debe ser posterior a start_date", utils.ErrInvalidReservationData)
+	}
+	datesChanged := startDate != group.StartDate || endDate != group.EndDate
+
+	all, err := s.reservations.FindByGroup(ctx, group.ID)
+	if err != nil {
+		return domain.GroupBookingDetail{}, err
+	}
+	allocations := make(map[uint]domain.Reservation, len(all))
+	for _, reservation := range all {
+		if isCancelable(reservation) {
+			allocations[reservation.RoomID] = reservation
+		}
+	}
+
+	guestNames := make(map[uint]string, len(dto.Rooms))
+	for _, room := range dto.Rooms {
+		if _, ok := allocations[room.RoomID]; !ok {
+			return domain.GroupBookingDetail{}, fmt.Errorf("%w: la habitación %d no pertenece al grupo", utils.ErrInvalidReservationData, room.RoomID)
+		}
+		guestNames[room.RoomID] = room.GuestName
+	}
+
+	var quotes map[uint]*domain.PriceQuote
+	if datesChanged {
+		roomIDs := make([]uint, 0, len(allocations))
+		own := make([]primitive.ObjectID, 0, len(allocations))
+		for roomID, reservation := range allocations {
+			roomIDs = append(roomIDs, roomID)
+			own = append(own, reservation.ID)
+		}
+		if err := s.checkRooms(ctx, roomIDs, startDate, endDate, own); err != nil {
+			return domain.GroupBookingDetail{}, err
+		}
+		// Con fechas nuevas el precio acordado deja de valer: se vuelve a cotizar
+		if quotes, err = s.priceRooms(ctx, group.UserID, roomIDs, startDate, endDate); err != nil {
+			return domain.GroupBookingDetail{}, err
+		}
+	}
+
+	err = s.tx.Run(ctx, func(txCtx context.Context) error {
+		for roomID, reservation := range allocations {
+			guestName, renamed := guestNames[roomID]
+			if !renamed {
+				guestName = reservation.GuestName
+			}
+			if !datesChanged && guestName == reservation.GuestName {
+				continue
+			}
+
+			if datesChanged {
+				var pricing *domain.ReservationPricing
+				if quote := quotes[roomID]; quote != nil {
+					pricing = &quote.ReservationPricing
+				}
+				if err := s.reservations.SetPricing(txCtx, reservation.ID, pricing); err != nil {
+					return err
+				}
+			}
+			updated, err := s.reservations.UpdateAllocation(txCtx, reservation.ID, startDate, endDate, guestName)
+			if err != nil {
+				return err
+			}
+			if err := enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(domain.EventReservationModified, updated)); err != nil {
+				return err
+			}
+		}
+
+		if datesChanged {
+			return s.repository.UpdateDates(txCtx, group.ID, startDate, endDate)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.GroupBookingDetail{}, fmt.Errorf("failed to update group reservation: %w", err)
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Cancel cancela todas las habitaciones del grupo. Cada reserva pasa por la
+// cancelación individual, así que se aplican política y reembolso por habitación.
+func (s *groupService) Cancel(ctx context.Context, id string, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("%w: reason es requerido", utils.ErrInvalidReservationData)
+	}
+
+	group, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := s.reservations.FindByGroup(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		// DeleteReservation ignora las que ya estaban canceladas: reintentar es seguro
+		if err := s.reservationService.DeleteReservation(ctx, reservation.ID.Hex(), reason); err != nil {
+			return fmt.Errorf("failed to cancel reservation %s: %w", reservation.ID.Hex(), err)
+		}
+	}
+
+	return s.repository.MarkCanceled(ctx, group.ID, reason)
+}
+
+// checkRooms verifica que todas las habitaciones estén libres y, si no,
+// informa cuáles están ocupadas
+func (s *groupService) checkRooms(ctx context.Context, roomIDs []uint, startDate, endDate string, exclude []primitive.ObjectID) error {
+	var busy []uint
+	for _, roomID := range roomIDs {
+		overlap, err := s.reservations.HasOverlapExcluding(ctx, roomID, startDate, endDate, exclude)
+		if err != nil {
+			return fmt.Errorf("failed to validar disponibilidad: %w", err)
+		}
+		if overlap {
+			busy = append(busy, roomID)
+		}
+	}
+	if len(busy) > 0 {
+		return fmt.Errorf("%w: habitaciones %v", utils.ErrReservationConflict, busy)
+	}
+	return nil
+}
+
+// priceRooms cotiza la estadía de cada habitación con su plan de tarifas y
+// rechaza el grupo si alguna no cumple la estadía mínima. Si rooms-api no
+// responde la habitación queda sin precio acordado, como en las reservas
+// individuales.
+func (s *groupService) priceRooms(ctx context.Context, userID uint, roomIDs []uint, startDate, endDate string) (map[uint]*domain.PriceQuote, error) {
+	quotes := make(map[uint]*domain.PriceQuote, len(roomIDs))
+	for _, roomID := range roomIDs {
+		quote, err := quoteStay(ctx, s.pricing, domain.CreateReservationDTO{
+			UserID:    userID,
+			RoomID:    roomID,
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("habitación %d: %w", roomID, err)
+		}
+		if quote != nil {
+			quotes[roomID] = quote
+		}
+	}
+	return quotes, nil
+}
+
+func (s *groupService) rollbackCreate(groupID primitive.ObjectID, reserved []domain.Reservation) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Primero los eventos, para que el relay no publique reservas que se
+	// están borrando
+	if len(reserved) > 0 {
+		subjects := make([]string, 0, len(reserved))
+		for _, reservation := range reserved {
+			subjects = append(subjects, reservation.ID.Hex())
+		}
+		if err := s.outbox.DeletePending(ctx, subjects); err != nil {
+			log.Printf("Error deshaciendo los eventos del grupo %s: %v", groupID.Hex(), err)
+		}
+	}
+
+	if err := s.reservations.DeleteByGroup(ctx, groupID); err != nil {
+		log.Printf("Error deshaciendo las reservas del grupo %s: %v", groupID.Hex(), err)
+	}
+	if err := s.repository.Delete(ctx, groupID); err != nil {
+		log.Printf("Error deshaciendo el grupo %s: %v", groupID.Hex(), err)
+	}
+}
+
+func newGroupDetail(group domain.GroupBooking, reservations []domain.Reservation) domain.GroupBookingDetail {
+	detail := domain.GroupBookingDetail{
+		Group:        group,
+		Reservations: make([]domain.ReservationResponseDTO, 0, len(reservations)),
+	}
+	for _, reservation := range reservations {
+		detail.Reservations = append(detail.Reservations, toResponseDTO(reservation))
+	}
+	return detail
+}
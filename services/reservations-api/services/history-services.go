@@ -0,0 +1,180 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+// Intentos de tomar la siguiente secuencia cuando dos cambios de la misma
+// reserva se registran a la vez (sin transacciones en Mongo standalone)
+const maxHistoryAttempts = 3
+
+// HistoryService lleva el historial append-only de cada reserva: quién hizo
+// cada cambio y qué campos modificó
+type HistoryService interface {
+	// Record registra el estado actual de la reserva como resultado de
+	// `eventType`; se llama dentro de la transacción del cambio
+	Record(ctx context.Context, eventType domain.EventType, reservationID string) error
+	List(ctx context.Context, reservationID string) ([]domain.HistoryEntry, error)
+	// Verify reconstruye la reserva aplicando el historial y la compara con el documento actual
+	Verify(ctx context.Context, reservationID string) (domain.HistoryVerification, error)
+}
+
+type historyService struct {
+	repository   repositories.HistoryRepository
+	reservations repositories.ReservationRepository
+}
+
+func NewHistoryService(repository repositories.HistoryRepository, reservations repositories.ReservationRepository) HistoryService {
+	return &historyService{repository: repository, reservations: reservations}
+}
+
+func (s *historyService) Record(ctx context.Context, eventType domain.EventType, reservationID string) error {
+	current, err := s.reservations.GetByID(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("failed to load reservation for history: %w", err)
+	}
+	after, err := flattenReservation(current)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; attempt < maxHistoryAttempts; attempt++ {
+		entries, err := s.repository.ListByReservation(ctx, reservationID)
+		if err != nil {
+			return err
+		}
+
+		// Las reservas anteriores al historial arrancan con el estado
+		// completo en su primera entrada
+		sequence := 1
+		if len(entries) > 0 {
+			sequence = entries[len(entries)-1].Sequence + 1
+		}
+		err = s.repository.Append(ctx, domain.HistoryEntry{
+			ReservationID: reservationID,
+			Sequence:      sequence,
+			Action:        domain.HistoryActionFor(eventType),
+			Actor:         utils.ActorFromContext(ctx),
+			Changes:       diffStates(replayHistory(entries), after),
+			At:            time.Now(),
+		})
+		if !errors.Is(err, repositories.ErrHistorySequenceTaken) {
+			return err
+		}
+	}
+	return repositories.ErrHistorySequenceTaken
+}
+
+func (s *historyService) List(ctx context.Context, reservationID string) ([]domain.HistoryEntry, error) {
+	if _, err := s.reservations.GetByID(ctx, reservationID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListByReservation(ctx, reservationID)
+}
+
+func (s *historyService) Verify(ctx context.Context, reservationID string) (domain.HistoryVerification, error) {
+	current, err := s.reservations.GetByID(ctx, reservationID)
+	if err != nil {
+		return domain.HistoryVerification{}, err
+	}
+	entries, err := s.repository.ListByReservation(ctx, reservationID)
+	if err != nil {
+		return domain.HistoryVerification{}, err
+	}
+	actual, err := flattenReservation(current)
+	if err != nil {
+		return domain.HistoryVerification{}, err
+	}
+
+	mismatches := diffStates(replayHistory(entries), actual)
+	return domain.HistoryVerification{
+		Consistent: len(mismatches) == 0,
+		Entries:    len(entries),
+		Mismatches: mismatches,
+	}, nil
+}
+
+// flattenReservation lleva la reserva a un mapa path → valor usando la
+// representación JSON, que es la misma que expone la API
+func flattenReservation(reservation domain.Reservation) (map[string]interface{}, error) {
+	raw, err := json.Marshal(reservation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reservation: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reservation: %w", err)
+	}
+	delete(doc, "id")
+
+	state := make(map[string]interface{})
+	flattenInto(state, "", doc)
+	return state, nil
+}
+
+func flattenInto(state map[string]interface{}, prefix string, doc map[string]interface{}) {
+	for key, value := range doc {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenInto(state, path, nested)
+			continue
+		}
+		state[path] = value
+	}
+}
+
+// replayHistory aplica los cambios en orden; un After nil borra el campo
+func replayHistory(entries []domain.HistoryEntry) map[string]interface{} {
+	state := make(map[string]interface{})
+	for _, entry := range entries {
+		for _, change := range entry.Changes {
+			if change.After == nil {
+				delete(state, change.Field)
+				continue
+			}
+			state[change.Field] = change.After
+		}
+	}
+	return state
+}
+
+// diffStates lista los campos que difieren, ordenados por path
+func diffStates(before, after map[string]interface{}) []domain.FieldChange {
+	fields := make(map[string]struct{}, len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+
+	changes := []domain.FieldChange{}
+	for field := range fields {
+		if !sameValue(before[field], after[field]) {
+			changes = append(changes, domain.FieldChange{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// sameValue compara por JSON: los valores leídos de Mongo y los recién
+// serializados tienen tipos Go distintos (primitive.A vs []interface{})
+func sameValue(a, b interface{}) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(rawA, rawB)
+}
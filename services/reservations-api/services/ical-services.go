@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+const (
+	icalProdID = "-//reservations-api//Room Calendar//ES"
+	// icalMaxFeedBytes evita cargar en memoria feeds desproporcionados
+	icalMaxFeedBytes = 5 << 20
+	icalFetchTimeout = 15 * time.Second
+)
+
+// ICalService publica la disponibilidad de una habitación como feed iCal e
+// importa feeds externos como reservas que bloquean la habitación
+type ICalService interface {
+	ExportRoom(ctx context.Context, roomID uint) ([]byte, error)
+	ImportFeed(ctx context.Context, feed domain.ICalFeed) (domain.ICalImportResult, error)
+}
+
+type icalService struct {
+	repository  repositories.ReservationRepository
+	outbox      repositories.OutboxRepository
+	history     HistoryService
+	tx          repositories.TxRunner
+	roomsClient *config.RoomsAPIClient
+	httpClient  *http.Client
+	exportDays  int
+}
+
+func NewICalService(
+	repository repositories.ReservationRepository,
+	outbox repositories.OutboxRepository,
+	history HistoryService,
+	tx repositories.TxRunner,
+	roomsClient *config.RoomsAPIClient,
+	cfg config.ICalConfig,
+) ICalService {
+	return &icalService{
+		repository:  repository,
+		outbox:      outbox,
+		history:     history,
+		tx:          tx,
+		roomsClient: roomsClient,
+		httpClient:  &http.Client{Timeout: icalFetchTimeout},
+		exportDays:  cfg.ExportDays,
+	}
+}
+
+// ExportRoom arma el feed con las noches reservadas de la habitación desde hoy.
+// Solo se publican fechas: ni huésped ni usuario salen del servicio.
+func (s *icalService) ExportRoom(ctx context.Context, roomID uint) ([]byte, error) {
+	room, err := s.roomsClient.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := now.Format(utils.DateLayout)
+	to := now.AddDate(0, 0, s.exportDays).Format(utils.DateLayout)
+	reservations, err := s.repository.FindBlocking(ctx, []uint{roomID}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := utils.NewICalWriter(icalProdID, "Habitación "+room.Number)
+	for _, reservation := range reservations {
+		// Los holds son temporales: no se publican como noches ocupadas
+		if reservation.Status != domain.ReservationStatusActive {
+			continue
+		}
+		uid := reservation.ID.Hex() + "@reservations-api"
+		if err := calendar.AddAllDayEvent(uid, reservation.StartDate, reservation.EndDate, "Reservado", now); err != nil {
+			return nil, fmt.Errorf("failed to export reservation %s: %w", reservation.ID.Hex(), err)
+		}
+	}
+	return calendar.Bytes(), nil
+}
+
+// ImportFeed reconcilia el feed con los bloqueos ya importados: crea los
+// eventos nuevos, mueve los que cambiaron de fecha y cancela los que ya no
+// están. Los eventos pasados no se tocan.
+func (s *icalService) ImportFeed(ctx context.Context, feed domain.ICalFeed) (domain.ICalImportResult, error) {
+	var result domain.ICalImportResult
+
+	events, err := s.fetchFeed(ctx, feed.URL)
+	if err != nil {
+		return result, err
+	}
+
+	today := time.Now().Format(utils.DateLayout)
+	existing, err := s.repository.FindExternalBlocks(ctx, feed.RoomID, feed.URL, today)
+	if err != nil {
+		return result, err
+	}
+	blocks := make(map[string]domain.Reservation, len(existing))
+	for _, block := range existing {
+		blocks[block.External.UID] = block
+	}
+
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		if event.Canceled || event.EndDate <= today || seen[event.UID] {
+			continue
+		}
+		seen[event.UID] = true
+
+		block, found := blocks[event.UID]
+		switch {
+		case !found:
+			err = s.addBlock(ctx, feed, event)
+			if err == nil {
+				result.Added++
+			}
+		case block.StartDate != event.StartDate || block.EndDate != event.EndDate:
+			err = s.moveBlock(ctx, block, event)
+			if err == nil {
+				result.Updated++
+			}
+		default:
+			continue
+		}
+
+		if errors.Is(err, utils.ErrReservationConflict) {
+			// Se reintenta en la próxima corrida; puede liberarse la reserva local
+			log.Printf("Evento iCal %s (habitación %d, %s a %s) en conflicto con una reserva existente",
+				event.UID, feed.RoomID, event.StartDate, event.EndDate)
+			result.Conflicts++
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	for uid, block := range blocks {
+		if seen[uid] {
+			continue
+		}
+		if err := s.removeBlock(ctx, block); err != nil {
+			return result, err
+		}
+		result.Removed++
+	}
+	return result, nil
+}
+
+func (s *icalService) addBlock(ctx context.Context, feed domain.ICalFeed, event domain.ICalEvent) error {
+	if err := s.checkFree(ctx, feed.RoomID, event, nil); err != nil {
+		return err
+	}
+
+	return s.tx.Run(ctx, func(txCtx context.Context) error {
+		saved, err := s.repository.Create(txCtx, domain.Reservation{
+			RoomID:    feed.RoomID,
+			StartDate: event.StartDate,
+			EndDate:   event.EndDate,
+			Status:    domain.ReservationStatusActive,
+			External:  &domain.ExternalBlock{FeedURL: feed.URL, UID: event.UID},
+		})
+		if err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(domain.EventReservationCreated, saved))
+	})
+}
+
+func (s *icalService) moveBlock(ctx context.Context, block domain.Reservation, event domain.ICalEvent) error {
+	if err := s.checkFree(ctx, block.RoomID, event, &block); err != nil {
+		return err
+	}
+
+	return s.tx.Run(ctx, func(txCtx context.Context) error {
+		updated, err := s.repository.UpdateAllocation(txCtx, block.ID, event.StartDate, event.EndDate, "")
+		if err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(domain.EventReservationModified, updated))
+	})
+}
+
+func (s *icalService) removeBlock(ctx context.Context, block domain.Reservation) error {
+	reason := "evento eliminado del calendario externo"
+	event := newReservationEvent(domain.EventReservationCanceled, block)
+	event.Status = string(domain.ReservationStatusCanceled)
+	event.CancelReason = &reason
+
+	err := s.tx.Run(ctx, func(txCtx context.Context) error {
+		if err := s.repository.Delete(txCtx, block.ID.Hex(), reason, nil); err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, event)
+	})
+	if errors.Is(err, utils.ErrReservationNotCancelable) {
+		return nil
+	}
+	return err
+}
+
+// checkFree verifica por noches que nada más ocupe el rango del evento, así
+// los eventos consecutivos del feed (check-out y check-in el mismo día) no chocan
+func (s *icalService) checkFree(ctx context.Context, roomID uint, event domain.ICalEvent, self *domain.Reservation) error {
+	reservations, err := s.repository.FindBlocking(ctx, []uint{roomID}, event.StartDate, event.EndDate)
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		if self == nil || reservation.ID != self.ID {
+			return utils.ErrReservationConflict
+		}
+	}
+	return nil
+}
+
+// fetchFeed descarga el feed por http(s) o lo lee de disco (file:// o ruta local)
+func (s *icalService) fetchFeed(ctx context.Context, url string) ([]domain.ICalEvent, error) {
+	var body io.ReadCloser
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build feed request: %w", err)
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch feed %s: %w", url, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to fetch feed %s: status %d", url, resp.StatusCode)
+		}
+		body = resp.Body
+	} else {
+		file, err := os.Open(strings.TrimPrefix(url, "file://"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open feed %s: %w", url, err)
+		}
+		body = file
+	}
+	defer body.Close()
+
+	events, err := utils.ParseICalEvents(io.LimitReader(body, icalMaxFeedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed %s: %w", url, err)
+	}
+	return events, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+const (
+	// idempotencyTTL es cuánto se recuerda una clave y su respuesta
+	idempotencyTTL = 24 * time.Hour
+	// idempotencyLock es cuánto puede quedar un request en curso antes de
+	// considerarse abandonado
+	idempotencyLock = time.Minute
+)
+
+type IdempotencyService interface {
+	// Begin reserva la clave para un request nuevo. Si la clave ya se usó
+	// con el mismo payload y terminó, devuelve el registro y replay=true.
+	Begin(ctx context.Context, key, fingerprint string) (record domain.IdempotencyRecord, replay bool, err error)
+	Complete(ctx context.Context, key string, status int, body []byte) error
+	Release(ctx context.Context, key string) error
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+type idempotencyService struct {
+	repository repositories.IdempotencyRepository
+}
+
+func NewIdempotencyService(repository repositories.IdempotencyRepository) IdempotencyService {
+	return &idempotencyService{repository: repository}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, key, fingerprint string) (domain.IdempotencyRecord, bool, error) {
+	now := time.Now()
+	record := domain.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      domain.IdempotencyStatusInProgress,
+		LockedUntil: now.Add(idempotencyLock),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(idempotencyTTL),
+	}
+
+	existing, acquired, err := s.repository.Acquire(ctx, record)
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, err
+	}
+	if acquired {
+		return domain.IdempotencyRecord{}, false, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return domain.IdempotencyRecord{}, false, utils.ErrIdempotencyKeyReused
+	}
+	if existing.Status != domain.IdempotencyStatusCompleted {
+		return domain.IdempotencyRecord{}, false, utils.ErrIdempotencyInProgress
+	}
+	return existing, true, nil
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, key string, status int, body []byte) error {
+	return s.repository.Complete(ctx, key, status, body)
+}
+
+func (s *idempotencyService) Release(ctx context.Context, key string) error {
+	return s.repository.Release(ctx, key)
+}
+
+// PurgeExpired borra las claves vencidas. El índice TTL también las elimina,
+// pero su monitor corre cada minuto y no se puede medir desde el servicio.
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int, error) {
+	purged, err := s.repository.PurgeExpired(ctx, time.Now())
+	return int(purged), err
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Prefijos de numeración de cada serie
+var invoicePrefixes = map[domain.InvoiceType]string{
+	domain.InvoiceTypeInvoice:    "F",
+	domain.InvoiceTypeCreditNote: "NC",
+}
+
+type InvoiceService interface {
+	// Current devuelve la factura vigente de la reserva y la emite si todavía no existe
+	Current(ctx context.Context, reservationID string) (domain.Invoice, error)
+	List(ctx context.Context, reservationID string) ([]domain.Invoice, error)
+	// CreditNote anula la factura vigente; el próximo Current emite una nueva con el folio actual
+	CreditNote(ctx context.Context, reservationID, reason string) (domain.Invoice, error)
+	Config() config.InvoiceConfig
+}
+
+type invoiceService struct {
+	repository   repositories.InvoiceRepository
+	reservations repositories.ReservationRepository
+	folios       FolioService
+	tx           repositories.TxRunner
+	config       config.InvoiceConfig
+}
+
+func NewInvoiceService(
+	repository repositories.InvoiceRepository,
+	reservations repositories.ReservationRepository,
+	folios FolioService,
+	tx repositories.TxRunner,
+	cfg config.InvoiceConfig,
+) InvoiceService {
+	return &invoiceService{
+		repository:   repository,
+		reservations: reservations,
+		folios:       folios,
+		tx:           tx,
+		config:       cfg,
+	}
+}
+
+func (s *invoiceService) Config() config.InvoiceConfig {
+	return s.config
+}
+
+func (s *invoiceService) List(ctx context.Context, reservationID string) ([]domain.Invoice, error) {
+	if _, err := s.reservations.GetByID(ctx, reservationID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListByReservation(ctx, reservationID)
+}
+
+func (s *invoiceService) Current(ctx context.Context, reservationID string) (domain.Invoice, error) {
+	reservation, err := s.reservations.GetByID(ctx, reservationID)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+
+	invoices, err := s.repository.ListByReservation(ctx, reservationID)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+	if open := openInvoice(invoices); open != nil {
+		return *open, nil
+	}
+
+	if reservation.Status != domain.ReservationStatusCompleted && reservation.CheckedOutAt == nil {
+		return domain.Invoice{}, utils.ErrInvoiceNotAvailable
+	}
+
+	folio, err := s.folios.ForReservation(ctx, reservation)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+	invoice := s.buildInvoice(reservation, folio)
+	invoice.Revision = nextRevision(invoices)
+
+	issued, err := s.issue(ctx, invoice)
+	if errors.Is(err, repositories.ErrInvoiceRevisionTaken) {
+		// Otro request la emitió en paralelo: devolver esa
+		invoices, err := s.repository.ListByReservation(ctx, reservationID)
+		if err != nil {
+			return domain.Invoice{}, err
+		}
+		if open := openInvoice(invoices); open != nil {
+			return *open, nil
+		}
+		return domain.Invoice{}, repositories.ErrInvoiceRevisionTaken
+	}
+	return issued, err
+}
+
+func (s *invoiceService) CreditNote(ctx context.Context, reservationID, reason string) (domain.Invoice, error) {
+	if reason == "" {
+		return domain.Invoice{}, fmt.Errorf("%w: reason es requerido", utils.ErrInvalidReservationData)
+	}
+
+	invoices, err := s.List(ctx, reservationID)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+	open := openInvoice(invoices)
+	if open == nil {
+		return domain.Invoice{}, utils.ErrInvoiceNotFound
+	}
+
+	credit := *open
+	credit.ID = [12]byte{}
+	credit.Type = domain.InvoiceTypeCreditNote
+	credit.Lines = make([]domain.InvoiceLine, len(open.Lines))
+	for i, line := range open.Lines {
+		line.Amount = -line.Amount
+		credit.Lines[i] = line
+	}
+	credit.TaxLines = make([]domain.TaxLine, len(open.TaxLines))
+	for i, tax := range open.TaxLines {
+		tax.Base = -tax.Base
+		tax.Amount = -tax.Amount
+		credit.TaxLines[i] = tax
+	}
+	credit.Net = -open.Net
+	credit.TaxTotal = -open.TaxTotal
+	credit.Total = -open.Total
+	credit.AmountPaid = 0
+	credit.BalanceDue = 0
+	credit.CreditedInvoiceID = &open.ID
+	credit.CreditedInvoiceNumber = open.Number
+	credit.Reason = reason
+
+	// La nota de crédito comparte la revisión de la factura: el índice único
+	// impide anular dos veces la misma factura
+	issued, err := s.issue(ctx, credit)
+	if errors.Is(err, repositories.ErrInvoiceRevisionTaken) {
+		return domain.Invoice{}, utils.ErrInvoiceNotFound
+	}
+	return issued, err
+}
+
+// issue guarda el comprobante y recién después toma el número: el insert
+// reclama la revisión (índice único), así un request concurrente que pierde
+// la carrera no consume un número de la serie. Mientras tanto el comprobante
+// lleva un número provisorio único por id.
+func (s *invoiceService) issue(ctx context.Context, invoice domain.Invoice) (domain.Invoice, error) {
+	invoice.ID = primitive.NewObjectID()
+	invoice.Number = "PEND-" + invoice.ID.Hex()
+	invoice.IssuedAt = time.Now()
+
+	var (
+		issued  domain.Invoice
+		claimed bool
+	)
+	err := s.tx.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		issued, err = s.repository.Create(txCtx, invoice)
+		if err != nil {
+			return err
+		}
+		claimed = true
+
+		number, err := s.repository.NextNumber(txCtx, invoice.Type)
+		if err != nil {
+			return err
+		}
+		issued.Number = fmt.Sprintf("%s-%08d", invoicePrefixes[invoice.Type], number)
+		return s.repository.SetNumber(txCtx, issued.ID, issued.Number)
+	})
+	if err != nil && claimed {
+		// Sin transacciones el comprobante provisorio queda guardado
+		if deleteErr := s.repository.Delete(context.Background(), invoice.ID); deleteErr != nil {
+			log.Printf("Error borrando el comprobante provisorio %s: %v", invoice.ID.Hex(), deleteErr)
+		}
+	}
+	return issued, err
+}
+
+// buildInvoice toma los cargos del folio (no los pagos) y desglosa el
+// impuesto incluido en cada alícuota
+func (s *invoiceService) buildInvoice(reservation domain.Reservation, folio domain.Folio) domain.Invoice {
+	invoice := domain.Invoice{
+		Type:          domain.InvoiceTypeInvoice,
+		ReservationID: reservation.ID.Hex(),
+		UserID:        reservation.UserID,
+		GuestName:     reservation.GuestName,
+		RoomID:        reservation.RoomID,
+		StartDate:     reservation.StartDate,
+		EndDate:       reservation.EndDate,
+		Currency:      folio.Currency,
+		AmountPaid:    folio.Paid,
+	}
+
+	grossByRate := make(map[float64]float64)
+	for _, line := range folio.Lines {
+		switch line.Type {
+		case domain.FolioLinePayment, domain.FolioLineRefund:
+			continue
+		}
+		quantity := line.Quantity
+		unitPrice := line.UnitPrice
+		if quantity == 0 {
+			quantity, unitPrice = 1, line.Amount
+		}
+		invoice.Lines = append(invoice.Lines, domain.InvoiceLine{
+			Description: line.Description,
+			Quantity:    quantity,
+			UnitPrice:   unitPrice,
+			Amount:      line.Amount,
+			TaxRate:     line.TaxRate,
+		})
+		grossByRate[line.TaxRate] += line.Amount
+	}
+
+	rates := make([]float64, 0, len(grossByRate))
+	for rate := range grossByRate {
+		rates = append(rates, rate)
+	}
+	sort.Float64s(rates)
+	for _, rate := range rates {
+		gross := utils.RoundMoney(grossByRate[rate])
+		base := utils.RoundMoney(gross / (1 + rate/100))
+		tax := domain.TaxLine{
+			Name:   s.config.TaxName,
+			Rate:   rate,
+			Base:   base,
+			Amount: utils.RoundMoney(gross - base),
+		}
+		invoice.TaxLines = append(invoice.TaxLines, tax)
+		invoice.Net += tax.Base
+		invoice.TaxTotal += tax.Amount
+		invoice.Total += gross
+	}
+	invoice.Net = utils.RoundMoney(invoice.Net)
+	invoice.TaxTotal = utils.RoundMoney(invoice.TaxTotal)
+	invoice.Total = utils.RoundMoney(invoice.Total)
+	invoice.BalanceDue = utils.RoundMoney(invoice.Total - invoice.AmountPaid)
+	return invoice
+}
+
+// openInvoice devuelve la última factura que no fue anulada con nota de crédito
+func openInvoice(invoices []domain.Invoice) *domain.Invoice {
+	credited := make(map[int]bool)
+	for _, invoice := range invoices {
+		if invoice.Type == domain.InvoiceTypeCreditNote {
+			credited[invoice.Revision] = true
+		}
+	}
+	for i := len(invoices) - 1; i >= 0; i-- {
+		if invoices[i].Type == domain.InvoiceTypeInvoice && !credited[invoices[i].Revision] {
+			return &invoices[i]
+		}
+	}
+	return nil
+}
+
+func nextRevision(invoices []domain.Invoice) int {
+	revision := 0
+	for _, invoice := range invoices {
+		if invoice.Revision > revision {
+			revision = invoice.Revision
+		}
+	}
+	return revision + 1
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+)
+
+type JobService interface {
+	Status(ctx context.Context) (domain.SchedulerStatus, error)
+}
+
+type jobService struct {
+	locks       repositories.LockRepository
+	runs        repositories.JobRunRepository
+	definitions []domain.JobDefinition
+	instance    string
+}
+
+func NewJobService(locks repositories.LockRepository, runs repositories.JobRunRepository, definitions []domain.JobDefinition, instance string) JobService {
+	return &jobService{
+		locks:       locks,
+		runs:        runs,
+		definitions: definitions,
+		instance:    instance,
+	}
+}
+
+// Status devuelve qué réplica es líder y la última corrida de cada job
+func (s *jobService) Status(ctx context.Context) (domain.SchedulerStatus, error) {
+	leader, err := s.locks.Get(ctx, domain.SchedulerLockName)
+	if err != nil {
+		return domain.SchedulerStatus{}, err
+	}
+
+	lastRuns, err := s.runs.LastRuns(ctx)
+	if err != nil {
+		return domain.SchedulerStatus{}, err
+	}
+
+	jobs := make([]domain.JobStatus, len(s.definitions))
+	for i, definition := range s.definitions {
+		jobs[i] = domain.JobStatus{
+			Name:     definition.Name,
+			Interval: definition.Interval.String(),
+		}
+		if summary, ok := lastRuns[definition.Name]; ok {
+			lastRun := summary.LastRun
+			jobs[i].LastRun = &lastRun
+			jobs[i].LastSuccessAt = summary.LastSuccessAt
+		}
+	}
+
+	return domain.SchedulerStatus{
+		Instance: s.instance,
+		Leader:   leader,
+		Jobs:     jobs,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+)
+
+type OutboxService interface {
+	Stats(ctx context.Context) (domain.OutboxStats, error)
+}
+
+type outboxService struct {
+	repository repositories.OutboxRepository
+}
+
+func NewOutboxService(repository repositories.OutboxRepository) OutboxService {
+	return &outboxService{repository: repository}
+}
+
+// Stats devuelve el tamaño del backlog del outbox para monitoreo
+func (s *outboxService) Stats(ctx context.Context) (domain.OutboxStats, error) {
+	stats, err := s.repository.Stats(ctx)
+	if err != nil {
+		return domain.OutboxStats{}, fmt.Errorf("failed to get outbox stats: %w", err)
+	}
+	return stats, nil
+}
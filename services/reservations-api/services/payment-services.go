@@ -0,0 +1,465 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/payments"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+type PaymentService interface {
+	Authorize(ctx context.Context, reservationID string, dto domain.AuthorizePaymentDTO) (domain.Payment, error)
+	Capture(ctx context.Context, paymentID string, amount float64) (domain.Payment, error)
+	Refund(ctx context.Context, paymentID string, amount float64) (domain.Payment, error)
+	Void(ctx context.Context, paymentID string) (domain.Payment, error)
+	GetByReservation(ctx context.Context, reservationID string) ([]domain.Payment, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (bool, error)
+	HasSuccessfulAuthorization(ctx context.Context, reservationID string) (bool, error)
+	ReleaseForReservation(ctx context.Context, reservationID string) error
+	Summary(ctx context.Context, reservation domain.Reservation) (domain.PaymentSummary, error)
+	RefundReservation(ctx context.Context, reservationID string, amount float64) error
+}
+
+type paymentService struct {
+	repository      repositories.PaymentRepository
+	reservationRepo repositories.ReservationRepository
+	gateway         payments.PaymentGateway
+	roomsClient     *config.RoomsAPIClient
+	config          config.PaymentConfig
+}
+
+func NewPaymentService(
+	repository repositories.PaymentRepository,
+	reservationRepo repositories.ReservationRepository,
+	gateway payments.PaymentGateway,
+	roomsClient *config.RoomsAPIClient,
+	cfg config.PaymentConfig,
+) PaymentService {
+	return &paymentService{
+		repository:      repository,
+		reservationRepo: reservationRepo,
+		gateway:         gateway,
+		roomsClient:     roomsClient,
+		config:          cfg,
+	}
+}
+
+// Authorize calcula el monto según la política y lo autoriza en el gateway.
+// Si la reserva ya tiene una autorización exitosa se devuelve esa misma.
+// Antes de llamar al gateway se inserta el pago en pending: el índice único
+// impide que dos requests concurrentes autoricen la misma reserva, y el id
+// del pago es la clave de idempotencia, distinta en cada intento.
+func (s *paymentService) Authorize(ctx context.Context, reservationID string, dto domain.AuthorizePaymentDTO) (domain.Payment, error) {
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	if reservation.Status != domain.ReservationStatusPending && reservation.Status != domain.ReservationStatusActive {
+		return domain.Payment{}, utils.ErrReservationNotPayable
+	}
+
+	existing, found, err := s.repository.FindSuccessful(ctx, reservationID)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	if found {
+		return existing, nil
+	}
+
+	total, err := s.reservationTotal(ctx, reservation)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	amount := total
+	if dto.Policy == domain.PaymentPolicyDeposit {
+		amount = utils.RoundMoney(total * s.config.DepositPercent / 100)
+	}
+
+	payment, err := s.repository.Create(ctx, domain.Payment{
+		ReservationID:    reservationID,
+		UserID:           reservation.UserID,
+		Provider:         s.gateway.Name(),
+		Policy:           dto.Policy,
+		Status:           domain.PaymentStatusPending,
+		Currency:         s.config.Currency,
+		ReservationTotal: total,
+		Amount:           amount,
+	})
+	if errors.Is(err, utils.ErrPaymentInProgress) {
+		// Si el otro request ya terminó se devuelve su autorización
+		if existing, found, findErr := s.repository.FindSuccessful(ctx, reservationID); findErr == nil && found {
+			return existing, nil
+		}
+	}
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	result, err := s.gateway.Authorize(ctx, payments.AuthorizeRequest{
+		ReservationID:  reservationID,
+		Amount:         amount,
+		Currency:       s.config.Currency,
+		PaymentToken:   dto.PaymentToken,
+		IdempotencyKey: payment.ID.Hex(),
+	})
+	if err != nil {
+		// Se libera el pago pending para que se pueda reintentar; si falla,
+		// applyResult ya lo registra en el log
+		s.markFailed(ctx, payment, "gateway_error")
+		return domain.Payment{}, fmt.Errorf("failed to authorize payment: %w", err)
+	}
+
+	if !result.Approved {
+		reason := result.DeclineReason
+		if _, err := s.markFailed(ctx, payment, reason); err != nil {
+			return domain.Payment{}, err
+		}
+		return domain.Payment{}, fmt.Errorf("%w: %s", utils.ErrPaymentDeclined, reason)
+	}
+
+	return s.applyResult(ctx, payment, func(payment *domain.Payment) {
+		payment.Status = domain.PaymentStatusAuthorized
+		payment.GatewayRef = result.Reference
+	})
+}
+
+// markFailed cierra un pago pending que el gateway no autorizó
+func (s *paymentService) markFailed(ctx context.Context, payment domain.Payment, reason string) (domain.Payment, error) {
+	return s.applyResult(ctx, payment, func(payment *domain.Payment) {
+		payment.Status = domain.PaymentStatusFailed
+		payment.FailureReason = &reason
+	})
+}
+
+// maxPaymentUpdateAttempts acota los reintentos de applyResult cuando otro
+// proceso modifica el mismo pago
+const maxPaymentUpdateAttempts = 3
+
+func (s *paymentService) Capture(ctx context.Context, paymentID string, amount float64) (domain.Payment, error) {
+	payment, err := s.repository.GetByID(ctx, paymentID)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	if payment.Status != domain.PaymentStatusAuthorized {
+		return domain.Payment{}, payments.ErrInvalidState
+	}
+	remaining := utils.RoundMoney(payment.Amount - payment.CapturedAmount)
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		return domain.Payment{}, payments.ErrInvalidAmount
+	}
+
+	result, err := s.gateway.Capture(ctx, payment.GatewayRef, amount)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("failed to capture payment: %w", err)
+	}
+
+	return s.applyResult(ctx, payment, func(payment *domain.Payment) {
+		addCapture(payment, result.Amount)
+	})
+}
+
+func (s *paymentService) Refund(ctx context.Context, paymentID string, amount float64) (domain.Payment, error) {
+	payment, err := s.repository.GetByID(ctx, paymentID)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	if !isRefundable(payment) {
+		return domain.Payment{}, payments.ErrInvalidState
+	}
+	available := utils.RoundMoney(payment.CapturedAmount - payment.RefundedAmount)
+	if amount == 0 {
+		amount = available
+	}
+	if amount <= 0 || amount > available {
+		return domain.Payment{}, payments.ErrInvalidAmount
+	}
+
+	result, err := s.gateway.Refund(ctx, payment.GatewayRef, amount)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("failed to refund payment: %w", err)
+	}
+
+	return s.applyResult(ctx, payment, func(payment *domain.Payment) {
+		addRefund(payment, result.Amount)
+	})
+}
+
+func (s *paymentService) Void(ctx context.Context, paymentID string) (domain.Payment, error) {
+	payment, err := s.repository.GetByID(ctx, paymentID)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	return s.void(ctx, payment)
+}
+
+func (s *paymentService) void(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	if payment.Status == domain.PaymentStatusVoided {
+		return payment, nil
+	}
+	// Una autorización capturada en parte ya no se puede anular
+	if payment.Status != domain.PaymentStatusAuthorized || payment.CapturedAmount > 0 {
+		return domain.Payment{}, payments.ErrInvalidState
+	}
+
+	if _, err := s.gateway.Void(ctx, payment.GatewayRef); err != nil {
+		return domain.Payment{}, fmt.Errorf("failed to void payment: %w", err)
+	}
+
+	return s.applyResult(ctx, payment, func(payment *domain.Payment) {
+		if payment.Status == domain.PaymentStatusAuthorized {
+			payment.Status = domain.PaymentStatusVoided
+		}
+	})
+}
+
+// applyResult guarda el efecto de una operación que el gateway ya aceptó.
+// Update rechaza la escritura si otro proceso modificó el pago desde la
+// lectura; en ese caso apply se vuelve a aplicar sobre el estado actual para
+// que ninguna de las dos operaciones se pierda.
+func (s *paymentService) applyResult(ctx context.Context, payment domain.Payment, apply func(payment *domain.Payment)) (domain.Payment, error) {
+	for attempt := 1; ; attempt++ {
+		apply(&payment)
+		updated, err := s.repository.Update(ctx, payment)
+		if !errors.Is(err, utils.ErrPaymentConflict) || attempt == maxPaymentUpdateAttempts {
+			if err != nil {
+				log.Printf("Error guardando el pago %s tras operar en el gateway: %v", payment.ID.Hex(), err)
+			}
+			return updated, err
+		}
+
+		payment, err = s.repository.GetByID(ctx, payment.ID.Hex())
+		if err != nil {
+			return domain.Payment{}, err
+		}
+	}
+}
+
+// addCapture suma una captura; el pago pasa a captured recién cuando se
+// capturó todo lo autorizado
+func addCapture(payment *domain.Payment, amount float64) {
+	payment.CapturedAmount = utils.RoundMoney(payment.CapturedAmount + amount)
+	if payment.Status == domain.PaymentStatusAuthorized && payment.CapturedAmount >= payment.Amount {
+		payment.Status = domain.PaymentStatusCaptured
+	}
+}
+
+// addRefund suma un reembolso. Un pago capturado en parte sigue autorizado
+// por el resto aunque se reembolse todo lo capturado.
+func addRefund(payment *domain.Payment, amount float64) {
+	payment.RefundedAmount = utils.RoundMoney(payment.RefundedAmount + amount)
+	if payment.Status == domain.PaymentStatusCaptured && payment.RefundedAmount >= payment.CapturedAmount {
+		payment.Status = domain.PaymentStatusRefunded
+	}
+}
+
+// isRefundable indica si el pago tiene capturas sin reembolsar, incluidas
+// las capturas parciales de una autorización
+func isRefundable(payment domain.Payment) bool {
+	if payment.Status != domain.PaymentStatusCaptured && payment.Status != domain.PaymentStatusAuthorized {
+		return false
+	}
+	return utils.RoundMoney(payment.CapturedAmount-payment.RefundedAmount) > 0
+}
+
+func (s *paymentService) GetByReservation(ctx context.Context, reservationID string) ([]domain.Payment, error) {
+	if _, err := s.reservationRepo.GetByID(ctx, reservationID); err != nil {
+		return nil, err
+	}
+	return s.repository.GetByReservation(ctx, reservationID)
+}
+
+// HandleWebhook aplica un callback del proveedor. Es idempotente: si el
+// evento ya fue procesado devuelve false sin volver a aplicarlo.
+func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) (bool, error) {
+	event, err := s.gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return false, err
+	}
+
+	receipt := domain.WebhookReceipt{
+		EventID:    fmt.Sprintf("%s:%s", s.gateway.Name(), event.ID),
+		Provider:   s.gateway.Name(),
+		Type:       event.Type,
+		Reference:  event.Reference,
+		ReceivedAt: time.Now(),
+	}
+	isNew, err := s.repository.RecordWebhook(ctx, receipt)
+	if err != nil {
+		return false, err
+	}
+	if !isNew {
+		log.Printf("Webhook %s ya procesado, se ignora", receipt.EventID)
+		return false, nil
+	}
+
+	if err := s.applyWebhook(ctx, event); err != nil {
+		// Liberar el registro para que el reintento del proveedor se procese
+		if forgetErr := s.repository.ForgetWebhook(ctx, receipt.EventID); forgetErr != nil {
+			log.Printf("Error liberando webhook %s: %v", receipt.EventID, forgetErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *paymentService) applyWebhook(ctx context.Context, event payments.WebhookEvent) error {
+	payment, err := s.repository.GetByGatewayRef(ctx, event.Reference)
+	if err != nil {
+		return err
+	}
+
+	var apply func(payment *domain.Payment)
+	switch event.Type {
+	case payments.WebhookPaymentCaptured:
+		apply = func(payment *domain.Payment) {
+			if payment.Status == domain.PaymentStatusAuthorized {
+				addCapture(payment, event.Amount)
+			}
+		}
+	case payments.WebhookPaymentRefunded:
+		apply = func(payment *domain.Payment) {
+			if isRefundable(*payment) {
+				addRefund(payment, event.Amount)
+			}
+		}
+	case payments.WebhookPaymentVoided:
+		apply = func(payment *domain.Payment) {
+			if payment.Status == domain.PaymentStatusAuthorized {
+				payment.Status = domain.PaymentStatusVoided
+			}
+		}
+	case payments.WebhookPaymentFailed:
+		apply = func(payment *domain.Payment) {
+			if payment.Status == domain.PaymentStatusAuthorized {
+				reason := "failed_by_provider"
+				payment.Status = domain.PaymentStatusFailed
+				payment.FailureReason = &reason
+			}
+		}
+	default:
+		log.Printf("Tipo de webhook desconocido: %s", event.Type)
+		return nil
+	}
+
+	_, err = s.applyResult(ctx, payment, apply)
+	return err
+}
+
+func (s *paymentService) HasSuccessfulAuthorization(ctx context.Context, reservationID string) (bool, error) {
+	_, found, err := s.repository.FindSuccessful(ctx, reservationID)
+	return found, err
+}
+
+// ReleaseForReservation anula las autorizaciones pendientes de captura
+// de una reserva que se canceló o expiró.
+func (s *paymentService) ReleaseForReservation(ctx context.Context, reservationID string) error {
+	list, err := s.repository.GetByReservation(ctx, reservationID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, payment := range list {
+		// Las capturas parciales no se anulan: se liquidan con reembolsos
+		if payment.Status != domain.PaymentStatusAuthorized || payment.CapturedAmount > 0 {
+			continue
+		}
+		if _, err := s.void(ctx, payment); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Summary devuelve el total de la estadía y lo efectivamente cobrado
+// (capturado menos reembolsado) de una reserva.
+func (s *paymentService) Summary(ctx context.Context, reservation domain.Reservation) (domain.PaymentSummary, error) {
+	list, err := s.repository.GetByReservation(ctx, reservation.ID.Hex())
+	if err != nil {
+		return domain.PaymentSummary{}, err
+	}
+
+	summary := domain.PaymentSummary{Currency: s.config.Currency}
+	for _, payment := range list {
+		if payment.ReservationTotal > summary.ReservationTotal {
+			summary.ReservationTotal = payment.ReservationTotal
+		}
+		summary.AmountPaid += payment.CapturedAmount - payment.RefundedAmount
+	}
+	summary.AmountPaid = utils.RoundMoney(summary.AmountPaid)
+
+	// Sin pagos registrados el total se calcula con el precio actual
+	if summary.ReservationTotal == 0 {
+		total, err := s.reservationTotal(ctx, reservation)
+		if err != nil {
+			log.Printf("No se pudo calcular el total de la reserva %s: %v", reservation.ID.Hex(), err)
+			total = summary.AmountPaid
+		}
+		summary.ReservationTotal = total
+	}
+	return summary, nil
+}
+
+// RefundReservation reembolsa el monto indicado repartiéndolo entre los
+// pagos capturados de la reserva.
+func (s *paymentService) RefundReservation(ctx context.Context, reservationID string, amount float64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	list, err := s.repository.GetByReservation(ctx, reservationID)
+	if err != nil {
+		return err
+	}
+
+	remaining := utils.RoundMoney(amount)
+	for _, payment := range list {
+		if remaining <= 0 {
+			break
+		}
+		if !isRefundable(payment) {
+			continue
+		}
+		available := utils.RoundMoney(payment.CapturedAmount - payment.RefundedAmount)
+		if available <= 0 {
+			continue
+		}
+		refund := remaining
+		if refund > available {
+			refund = available
+		}
+		if _, err := s.Refund(ctx, payment.ID.Hex(), refund); err != nil {
+			return err
+		}
+		remaining = utils.RoundMoney(remaining - refund)
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("no hay fondos capturados suficientes para reembolsar %.2f", remaining)
+	}
+	return nil
+}
+
+// reservationTotal calcula el total de la estadía con las tarifas de rooms-api
+func (s *paymentService) reservationTotal(ctx context.Context, reservation domain.Reservation) (float64, error) {
+	// El precio acordado al reservar (con descuento) tiene prioridad sobre la tarifa vigente
+	if reservation.Pricing != nil {
+		return reservation.Pricing.Total, nil
+	}
+
+	rates, err := s.roomsClient.GetRoomRates(ctx, reservation.RoomID, reservation.StartDate, reservation.EndDate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to obtener tarifas de la habitación: %w", err)
+	}
+	return rates.Total, nil
+}
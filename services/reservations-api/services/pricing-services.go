@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/utils"
+)
+
+// PricingService cotiza una estadía con el plan de tarifas de rooms-api
+// (precio por noche y descuento por estadía larga) y el código promocional,
+// si lo hay. El código se aplica sobre el subtotal ya descontado.
+type PricingService interface {
+	Quote(ctx context.Context, dto domain.CreateReservationDTO) (domain.PriceQuote, error)
+}
+
+type pricingService struct {
+	roomsClient *config.RoomsAPIClient
+	promotions  PromotionService
+	currency    string
+}
+
+func NewPricingService(roomsClient *config.RoomsAPIClient, promotions PromotionService, currency string) PricingService {
+	return &pricingService{
+		roomsClient: roomsClient,
+		promotions:  promotions,
+		currency:    currency,
+	}
+}
+
+func (s *pricingService) Quote(ctx context.Context, dto domain.CreateReservationDTO) (domain.PriceQuote, error) {
+	nights, err := utils.CountNights(dto.StartDate, dto.EndDate)
+	if err != nil {
+		return domain.PriceQuote{}, fmt.Errorf("%w: %v", utils.ErrInvalidReservationData, err)
+	}
+
+	rates, err := s.roomsClient.GetRoomRates(ctx, dto.RoomID, dto.StartDate, dto.EndDate)
+	if err != nil {
+		return domain.PriceQuote{}, fmt.Errorf("failed to obtener tarifas de la habitación: %w", err)
+	}
+	if !rates.MinStayMet {
+		return domain.PriceQuote{}, fmt.Errorf("%w: la estadía mínima para esas fechas es de %d noches",
+			utils.ErrInvalidReservationData, rates.MinStay)
+	}
+
+	quote := domain.PriceQuote{
+		RoomID:             rates.RoomID,
+		RoomType:           rates.RoomType,
+		StartDate:          dto.StartDate,
+		EndDate:            dto.EndDate,
+		ReservationPricing: pricingFromRates(rates, s.currency, nights),
+	}
+
+	if dto.PromoCode != "" {
+		discount, err := s.promotions.Evaluate(ctx, dto.PromoCode, dto.UserID, rates.RoomType, nights, rates.Total)
+		if err != nil {
+			return domain.PriceQuote{}, err
+		}
+		quote.Discount = &discount
+		quote.Total = utils.RoundMoney(rates.Total - discount.Amount)
+	}
+	return quote, nil
+}
+
+// pricingFromRates arma el precio de la estadía con las tarifas por noche de
+// rooms-api, sin códigos promocionales
+func pricingFromRates(rates *domain.RoomRates, currency string, nights int) domain.ReservationPricing {
+	pricing := domain.ReservationPricing{
+		Currency:     currency,
+		NightlyRate:  utils.RoundMoney(rates.Subtotal / float64(nights)),
+		Nights:       nights,
+		NightlyRates: rates.Nights,
+		Subtotal:     rates.Subtotal,
+		Total:        rates.Total,
+	}
+	if rates.Discount > 0 {
+		pricing.StayDiscount = &domain.StayDiscount{
+			Percent: rates.DiscountPercent,
+			Amount:  rates.Discount,
+		}
+	}
+	return pricing
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type PromotionService interface {
+	Create(ctx context.Context, dto domain.CreatePromotionDTO) (domain.Promotion, error)
+	List(ctx context.Context) ([]domain.Promotion, error)
+	Get(ctx context.Context, id string) (domain.Promotion, error)
+	Update(ctx context.Context, id string, dto domain.UpdatePromotionDTO) (domain.Promotion, error)
+	Deactivate(ctx context.Context, id string) error
+	// Evaluate valida el código para la estadía y calcula el descuento sin canjearlo
+	Evaluate(ctx context.Context, code string, userID uint, roomType string, nights int, subtotal float64) (domain.AppliedDiscount, error)
+	Redeem(ctx context.Context, discount domain.AppliedDiscount, userID uint) error
+	// Release devuelve el uso de un descuento canjeado; los errores solo se loguean
+	Release(discount *domain.AppliedDiscount, userID uint)
+}
+
+type promotionService struct {
+	repository repositories.PromotionRepository
+}
+
+func NewPromotionService(repository repositories.PromotionRepository) PromotionService {
+	return &promotionService{repository: repository}
+}
+
+func (s *promotionService) Create(ctx context.Context, dto domain.CreatePromotionDTO) (domain.Promotion, error) {
+	promotion := domain.Promotion{
+		Code:           normalizeCode(dto.Code),
+		Description:    dto.Description,
+		DiscountType:   dto.DiscountType,
+		Value:          dto.Value,
+		ValidFrom:      dto.ValidFrom,
+		ValidUntil:     dto.ValidUntil,
+		MinNights:      dto.MinNights,
+		RoomTypes:      dto.RoomTypes,
+		MaxUses:        dto.MaxUses,
+		MaxUsesPerUser: dto.MaxUsesPerUser,
+		Active:         true,
+	}
+	if err := validatePromotion(promotion); err != nil {
+		return domain.Promotion{}, err
+	}
+	return s.repository.Create(ctx, promotion)
+}
+
+func (s *promotionService) List(ctx context.Context) ([]domain.Promotion, error) {
+	return s.repository.List(ctx)
+}
+
+func (s *promotionService) Get(ctx context.Context, id string) (domain.Promotion, error) {
+	return s.repository.GetByID(ctx, id)
+}
+
+func (s *promotionService) Update(ctx context.Context, id string, dto domain.UpdatePromotionDTO) (domain.Promotion, error) {
+	promotion, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Promotion{}, err
+	}
+
+	set := bson.M{}
+	if dto.Description != nil {
+		promotion.Description = *dto.Description
+		set["description"] = promotion.Description
+	}
+	if dto.DiscountType != nil {
+		promotion.DiscountType = *dto.DiscountType
+		set["discount_type"] = promotion.DiscountType
+	}
+	if dto.Value != nil {
+		promotion.Value = *dto.Value
+		set["value"] = promotion.Value
+	}
+	if dto.ValidFrom != nil {
+		promotion.ValidFrom = *dto.ValidFrom
+		set["valid_from"] = promotion.ValidFrom
+	}
+	if dto.ValidUntil != nil {
+		promotion.ValidUntil = *dto.ValidUntil
+		set["valid_until"] = promotion.ValidUntil
+	}
+	if dto.MinNights != nil {
+		promotion.MinNights = *dto.MinNights
+		set["min_nights"] = promotion.MinNights
+	}
+	if dto.RoomTypes != nil {
+		promotion.RoomTypes = *dto.RoomTypes
+		set["room_types"] = promotion.RoomTypes
+	}
+	if dto.MaxUses != nil {
+		promotion.MaxUses = *dto.MaxUses
+		set["max_uses"] = promotion.MaxUses
+	}
+	if dto.MaxUsesPerUser != nil {
+		promotion.MaxUsesPerUser = *dto.MaxUsesPerUser
+		set["max_uses_per_user"] = promotion.MaxUsesPerUser
+	}
+	if dto.Active != nil {
+		promotion.Active = *dto.Active
+		set["active"] = promotion.Active
+	}
+	if len(set) == 0 {
+		return promotion, nil
+	}
+
+	if err := validatePromotion(promotion); err != nil {
+		return domain.Promotion{}, err
+	}
+	return s.repository.Update(ctx, promotion.ID, set)
+}
+
+// Deactivate da de baja el código; se conserva para los descuentos ya aplicados
+func (s *promotionService) Deactivate(ctx context.Context, id string) error {
+	promotion, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	_, err = s.repository.Update(ctx, promotion.ID, bson.M{"active": false})
+	return err
+}
+
+func (s *promotionService) Evaluate(ctx context.Context, code string, userID uint, roomType string, nights int, subtotal float64) (domain.AppliedDiscount, error) {
+	promotion, err := s.repository.GetByCode(ctx, normalizeCode(code))
+	if err == utils.ErrPromotionNotFound {
+		return domain.AppliedDiscount{}, fmt.Errorf("%w: el código no existe", utils.ErrPromoCodeNotApplicable)
+	}
+	if err != nil {
+		return domain.AppliedDiscount{}, err
+	}
+
+	now := time.Now()
+	switch {
+	case !promotion.Active:
+		return domain.AppliedDiscount{}, fmt.Errorf("%w: el código no está activo", utils.ErrPromoCodeNotApplicable)
+	case now.Before(promotion.ValidFrom) || !now.Before(promotion.ValidUntil):
+		return domain.AppliedDiscount{}, fmt.Errorf("%w: el código no está vigente", utils.ErrPromoCodeNotApplicable)
+	case nights < promotion.MinNights:
+		return domain.AppliedDiscount{}, fmt.Errorf("%w: requiere al menos %d noches", utils.ErrPromoCodeNotApplicable, promotion.MinNights)
+	case !appliesToRoomType(promotion, roomType):
+		return domain.AppliedDiscount{}, fmt.Errorf("%w: no aplica al tipo de habitación %s", utils.ErrPromoCodeNotApplicable, roomType)
+	case promotion.MaxUses > 0 && promotion.Uses >= promotion.MaxUses:
+		return domain.AppliedDiscount{}, utils.ErrPromoCodeExhausted
+	}
+
+	// El límite por usuario se vuelve a verificar de forma atómica al canjear
+	if promotion.MaxUsesPerUser > 0 {
+		uses, err := s.repository.UserUses(ctx, promotion.ID, userID)
+		if err != nil {
+			return domain.AppliedDiscount{}, err
+		}
+		if uses >= promotion.MaxUsesPerUser {
+			return domain.AppliedDiscount{}, utils.ErrPromoCodeExhausted
+		}
+	}
+
+	amount := promotion.Value
+	if promotion.DiscountType == domain.DiscountTypePercentage {
+		amount = subtotal * promotion.Value / 100
+	}
+	amount = utils.RoundMoney(math.Min(amount, subtotal))
+
+	return domain.AppliedDiscount{
+		PromotionID:  promotion.ID,
+		Code:         promotion.Code,
+		DiscountType: promotion.DiscountType,
+		Value:        promotion.Value,
+		Amount:       amount,
+	}, nil
+}
+
+func (s *promotionService) Redeem(ctx context.Context, discount domain.AppliedDiscount, userID uint) error {
+	promotion, err := s.repository.GetByID(ctx, discount.PromotionID.Hex())
+	if err != nil {
+		return err
+	}
+	return s.repository.Redeem(ctx, promotion, userID)
+}
+
+func (s *promotionService) Release(discount *domain.AppliedDiscount, userID uint) {
+	if discount == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.repository.Release(ctx, discount.PromotionID, userID); err != nil {
+		log.Printf("Error devolviendo el uso del código %s: %v", discount.Code, err)
+	}
+}
+
+func validatePromotion(promotion domain.Promotion) error {
+	if !promotion.ValidUntil.After(promotion.ValidFrom) {
+		return fmt.Errorf("%w: valid_until debe ser posterior a valid_from", utils.ErrInvalidReservationData)
+	}
+	if promotion.DiscountType == domain.DiscountTypePercentage && promotion.Value > 100 {
+		return fmt.Errorf("%w: el porcentaje no puede superar 100", utils.ErrInvalidReservationData)
+	}
+	return nil
+}
+
+func appliesToRoomType(promotion domain.Promotion, roomType string) bool {
+	if len(promotion.RoomTypes) == 0 {
+		return true
+	}
+	for _, allowed := range promotion.RoomTypes {
+		if strings.EqualFold(allowed, roomType) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeCode hace que los códigos no distingan mayúsculas
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
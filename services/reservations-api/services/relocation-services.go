@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+type RelocationService interface {
+	// HandleRoomEvent procesa un evento de rooms-api y, si la habitación dejó
+	// de estar disponible, marca y trata de reubicar sus reservas futuras
+	HandleRoomEvent(ctx context.Context, event domain.RoomEvent) error
+}
+
+type relocationService struct {
+	repository  repositories.ReservationRepository
+	outbox      repositories.OutboxRepository
+	history     HistoryService
+	tx          repositories.TxRunner
+	roomsClient *config.RoomsAPIClient
+}
+
+func NewRelocationService(
+	repository repositories.ReservationRepository,
+	outbox repositories.OutboxRepository,
+	history HistoryService,
+	tx repositories.TxRunner,
+	roomsClient *config.RoomsAPIClient,
+) RelocationService {
+	return &relocationService{
+		repository:  repository,
+		outbox:      outbox,
+		history:     history,
+		tx:          tx,
+		roomsClient: roomsClient,
+	}
+}
+
+func (s *relocationService) HandleRoomEvent(ctx context.Context, event domain.RoomEvent) error {
+	var (
+		reason domain.RelocationReason
+		room   domain.RoomInfo
+	)
+
+	switch event.EventType {
+	case domain.RoomEventDeleted:
+		// La habitación ya no existe en rooms-api: usar los datos del evento
+		reason = domain.RelocationReasonRoomDeleted
+		room = domain.RoomInfo{ID: event.RoomID, Type: event.Type, Capacity: event.Capacity}
+		if event.Room != nil {
+			room = *event.Room
+		}
+	case domain.RoomEventStatusChanged:
+		if event.NewStatus != domain.RoomStatusMaintenance || event.Room == nil {
+			return nil
+		}
+		reason = domain.RelocationReasonRoomMaintenance
+		room = *event.Room
+	case domain.RoomEventUpdated:
+		if event.Room != nil {
+			// v2: el pase a mantenimiento llega como status_changed
+			return nil
+		}
+		current, err := s.roomsClient.GetRoomByID(ctx, event.RoomID)
+		if errors.Is(err, config.ErrRoomNotFound) {
+			// Llegó el update después de la baja; el evento deleted se encarga
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch room %d: %w", event.RoomID, err)
+		}
+		if current.Status != domain.RoomStatusMaintenance {
+			return nil
+		}
+		reason = domain.RelocationReasonRoomMaintenance
+		room = *current
+	default:
+		return nil
+	}
+
+	today := time.Now().Format(utils.DateLayout)
+	reservations, err := s.repository.FindUpcomingByRoom(ctx, room.ID, today)
+	if err != nil {
+		return err
+	}
+	if len(reservations) == 0 {
+		return nil
+	}
+	log.Printf("Habitación %d no disponible (%s): %d reservas a reubicar", room.ID, reason, len(reservations))
+
+	candidates, err := s.candidateRooms(ctx, room)
+	if err != nil {
+		// Sin candidatas igual se marcan las reservas para gestión manual
+		log.Printf("Error buscando habitaciones equivalentes a %d: %v", room.ID, err)
+	}
+
+	for _, reservation := range reservations {
+		if err := s.flag(ctx, reservation, reason); err != nil {
+			return err
+		}
+		if err := s.relocate(ctx, reservation, candidates); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flag marca la reserva y encola reservation.needs_relocation en la misma transacción
+func (s *relocationService) flag(ctx context.Context, reservation domain.Reservation, reason domain.RelocationReason) error {
+	info := domain.RelocationInfo{
+		Reason:     reason,
+		FromRoomID: reservation.RoomID,
+		FlaggedAt:  time.Now(),
+	}
+
+	return s.tx.Run(ctx, func(txCtx context.Context) error {
+		flagged, err := s.repository.FlagRelocation(txCtx, reservation.ID.Hex(), info)
+		if err != nil || !flagged {
+			return err
+		}
+
+		event := newReservationEvent(domain.EventReservationNeedsRelocation, reservation)
+		event.RelocationReason = &reason
+		return enqueueEvent(txCtx, s.outbox, s.history, event)
+	})
+}
+
+// relocate mueve la reserva a la primera habitación candidata libre en sus
+// fechas. Si no hay ninguna la reserva queda marcada para un administrador.
+func (s *relocationService) relocate(ctx context.Context, reservation domain.Reservation, candidates []domain.RoomInfo) error {
+	id := reservation.ID.Hex()
+	fromRoomID := reservation.RoomID
+
+	for _, candidate := range candidates {
+		busy, err := s.repository.HasActiveOverlap(ctx, candidate.ID, reservation.StartDate, reservation.EndDate)
+		if err != nil {
+			return err
+		}
+		if busy {
+			continue
+		}
+
+		var relocated bool
+		err = s.tx.Run(ctx, func(txCtx context.Context) error {
+			moved, ok, err := s.repository.Relocate(txCtx, id, fromRoomID, candidate.ID, time.Now())
+			if err != nil || !ok {
+				return err
+			}
+			relocated = true
+
+			event := newReservationEvent(domain.EventReservationRelocated, moved)
+			event.PreviousRoomID = &fromRoomID
+			event.RelocationReason = &moved.Relocation.Reason
+			return enqueueEvent(txCtx, s.outbox, s.history, event)
+		})
+		if err != nil {
+			return err
+		}
+		if relocated {
+			log.Printf("Reserva %s reubicada de la habitación %d a la %d", id, fromRoomID, candidate.ID)
+		}
+		// Si no se movió es porque ya la reubicaron (redelivery); no hay más que hacer
+		return nil
+	}
+
+	log.Printf("No hay habitación equivalente libre para la reserva %s; requiere reubicación manual", id)
+	return nil
+}
+
+// candidateRooms devuelve las habitaciones del mismo tipo con capacidad
+// suficiente, ordenadas de la más parecida a la menos parecida
+func (s *relocationService) candidateRooms(ctx context.Context, room domain.RoomInfo) ([]domain.RoomInfo, error) {
+	if room.Type == "" {
+		return nil, fmt.Errorf("room %d has no known type", room.ID)
+	}
+
+	rooms, err := s.roomsClient.ListRoomsByType(ctx, room.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]domain.RoomInfo, 0, len(rooms))
+	for _, r := range rooms {
+		if r.ID == room.ID || r.Status == domain.RoomStatusMaintenance || r.Capacity < room.Capacity {
+			continue
+		}
+		candidates = append(candidates, r)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Capacity != candidates[j].Capacity {
+			return candidates[i].Capacity < candidates[j].Capacity
+		}
+		return candidates[i].Price < candidates[j].Price
+	})
+	return candidates, nil
+}
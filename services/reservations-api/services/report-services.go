@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+type ReportService interface {
+	Performance(ctx context.Context, from, to string) (domain.PerformanceReport, error)
+	Revenue(ctx context.Context, from, to, groupBy string) (domain.RevenueReport, error)
+}
+
+type reportService struct {
+	repository  repositories.ReportRepository
+	roomsClient *config.RoomsAPIClient
+	currency    string
+}
+
+func NewReportService(repository repositories.ReportRepository, roomsClient *config.RoomsAPIClient, currency string) ReportService {
+	return &reportService{
+		repository:  repository,
+		roomsClient: roomsClient,
+		currency:    currency,
+	}
+}
+
+// roomSales son las noches vendidas de una habitación del inventario actual
+// y su ingreso
+type roomSales struct {
+	room    domain.RoomInfo
+	nights  int
+	revenue float64
+}
+
+// Performance calcula ocupación, ADR y RevPAR sobre las noches de [from, to)
+// y las métricas de reservas creadas en ese mismo rango
+func (s *reportService) Performance(ctx context.Context, from, to string) (domain.PerformanceReport, error) {
+	nights, err := calendarNights(from, to)
+	if err != nil {
+		return domain.PerformanceReport{}, err
+	}
+
+	sales, err := s.sales(ctx, from, to)
+	if err != nil {
+		return domain.PerformanceReport{}, err
+	}
+
+	start, _ := time.Parse(utils.DateLayout, from)
+	end, _ := time.Parse(utils.DateLayout, to)
+	stats, err := s.repository.BookingStats(ctx, start, end)
+	if err != nil {
+		return domain.PerformanceReport{}, err
+	}
+
+	total := summarize("", sales, len(nights))
+	report := domain.PerformanceReport{
+		From:                from,
+		To:                  to,
+		Currency:            s.currency,
+		Rooms:               total.Rooms,
+		AvailableRoomNights: total.AvailableRoomNights,
+		SoldRoomNights:      total.SoldRoomNights,
+		Revenue:             total.Revenue,
+		OccupancyRate:       total.OccupancyRate,
+		ADR:                 total.ADR,
+		RevPAR:              total.RevPAR,
+		Bookings:            stats.Bookings,
+		Cancellations:       stats.Cancellations,
+		AvgLeadTimeDays:     utils.RoundMoney(stats.AvgLeadTimeDays),
+	}
+	if stats.Bookings > 0 {
+		report.CancellationRate = utils.RoundMoney(float64(stats.Cancellations) / float64(stats.Bookings) * 100)
+	}
+	return report, nil
+}
+
+// Revenue desglosa los indicadores por tipo de habitación o por piso
+func (s *reportService) Revenue(ctx context.Context, from, to, groupBy string) (domain.RevenueReport, error) {
+	if groupBy == "" {
+		groupBy = domain.ReportGroupByType
+	}
+	if groupBy != domain.ReportGroupByType && groupBy != domain.ReportGroupByFloor {
+		return domain.RevenueReport{}, fmt.Errorf("%w: group_by debe ser type o floor", utils.ErrInvalidReservationData)
+	}
+
+	nights, err := calendarNights(from, to)
+	if err != nil {
+		return domain.RevenueReport{}, err
+	}
+
+	sales, err := s.sales(ctx, from, to)
+	if err != nil {
+		return domain.RevenueReport{}, err
+	}
+
+	groups := make(map[string][]roomSales)
+	for _, sale := range sales {
+		key := sale.room.Type
+		if groupBy == domain.ReportGroupByFloor {
+			key = strconv.Itoa(sale.room.Floor)
+		}
+		groups[key] = append(groups[key], sale)
+	}
+
+	rows := make([]domain.RevenueRow, 0, len(groups))
+	for key, group := range groups {
+		rows = append(rows, summarize(key, group, len(nights)))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if groupBy == domain.ReportGroupByFloor {
+			a, _ := strconv.Atoi(rows[i].Key)
+			b, _ := strconv.Atoi(rows[j].Key)
+			return a < b
+		}
+		return rows[i].Key < rows[j].Key
+	})
+
+	return domain.RevenueReport{
+		From:     from,
+		To:       to,
+		GroupBy:  groupBy,
+		Currency: s.currency,
+		Rows:     rows,
+	}, nil
+}
+
+// sales cruza las noches vendidas con el inventario de rooms-api. El
+// ingreso sale del precio acordado de cada reserva; solo las reservas
+// anteriores a la cotización se valorizan con la tarifa actual de la
+// habitación. Las habitaciones dadas de baja no cuentan.
+func (s *reportService) sales(ctx context.Context, from, to string) ([]roomSales, error) {
+	rooms, err := s.roomsClient.ListRooms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtener habitaciones: %w", err)
+	}
+
+	sold, err := s.repository.SoldRoomNights(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	soldByRoom := make(map[uint]domain.RoomNights, len(sold))
+	for _, row := range sold {
+		soldByRoom[row.RoomID] = row
+	}
+
+	sales := make([]roomSales, len(rooms))
+	for i, room := range rooms {
+		row := soldByRoom[room.ID]
+		sales[i] = roomSales{
+			room:    room,
+			nights:  row.Nights,
+			revenue: row.Revenue + room.Price*float64(row.UnpricedNights),
+		}
+	}
+	return sales, nil
+}
+
+func summarize(key string, sales []roomSales, nights int) domain.RevenueRow {
+	row := domain.RevenueRow{
+		Key:                 key,
+		Rooms:               len(sales),
+		AvailableRoomNights: len(sales) * nights,
+	}
+	for _, sale := range sales {
+		row.SoldRoomNights += sale.nights
+		row.Revenue += sale.revenue
+	}
+	row.Revenue = utils.RoundMoney(row.Revenue)
+
+	if row.AvailableRoomNights > 0 {
+		row.OccupancyRate = utils.RoundMoney(float64(row.SoldRoomNights) / float64(row.AvailableRoomNights) * 100)
+		row.RevPAR = utils.RoundMoney(row.Revenue / float64(row.AvailableRoomNights))
+	}
+	if row.SoldRoomNights > 0 {
+		row.ADR = utils.RoundMoney(row.Revenue / float64(row.SoldRoomNights))
+	}
+	return row
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reservations-api/domain"
@@ -9,53 +11,104 @@ import (
 	"reservations-api/repositories"
 	"reservations-api/utils"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ReservationService interface {
-	GetmyReservations(ctx context.Context, userID uint) ([]domain.Reservation, error)
-	GetAllReservations(ctx context.Context) ([]domain.Reservation, error)
+	ListReservations(ctx context.Context, query domain.ReservationListQuery) (domain.ReservationPage, error)
+	QuoteReservation(ctx context.Context, dto domain.CreateReservationDTO) (domain.PriceQuote, error)
 	CreateReservation(ctx context.Context, dto domain.CreateReservationDTO) (domain.Reservation, error)
 	DeleteReservation(ctx context.Context, id string, reason string) error
 	GetReservationByID(ctx context.Context, id string) (domain.ReservationResponseDTO, error)
+	CreateHold(ctx context.Context, dto domain.CreateReservationDTO) (domain.Reservation, error)
+	CreateHoldWithTTL(ctx context.Context, dto domain.CreateReservationDTO, ttl time.Duration) (domain.Reservation, error)
+	ConfirmReservation(ctx context.Context, id string) (domain.ReservationResponseDTO, error)
+	ExpireHolds(ctx context.Context) (int, error)
+	MarkNoShows(ctx context.Context, grace time.Duration) (int, error)
+	CompleteStays(ctx context.Context, grace time.Duration) (int, error)
+	CheckIn(ctx context.Context, id string) (domain.ReservationResponseDTO, error)
+	CheckOut(ctx context.Context, id string) (domain.ReservationResponseDTO, error)
+	CancellationPreview(ctx context.Context, id string) (domain.CancellationQuote, error)
 }
 type reservationService struct {
-	repository repositories.ReservationRepository
-	publisher  events.EventPublisher
+	repository    repositories.ReservationRepository
+	outbox        repositories.OutboxRepository
+	history       HistoryService
+	tx            repositories.TxRunner
+	payments      PaymentService
+	cancellations CancellationService
+	pricing       PricingService
+	promotions    PromotionService
+	holdTTL       time.Duration
 }
 
-func NewReservationService(repository repositories.ReservationRepository, publisher events.EventPublisher) ReservationService {
+func NewReservationService(
+	repository repositories.ReservationRepository,
+	outbox repositories.OutboxRepository,
+	history HistoryService,
+	tx repositories.TxRunner,
+	payments PaymentService,
+	cancellations CancellationService,
+	pricing PricingService,
+	promotions PromotionService,
+	holdTTL time.Duration,
+) ReservationService {
 	return &reservationService{
-		repository: repository,
-		publisher:  publisher,
+		repository:    repository,
+		outbox:        outbox,
+		history:       history,
+		tx:            tx,
+		payments:      payments,
+		cancellations: cancellations,
+		pricing:       pricing,
+		promotions:    promotions,
+		holdTTL:       holdTTL,
 	}
 }
 
-func (s *reservationService) GetmyReservations(ctx context.Context, userID uint) ([]domain.Reservation, error) {
-	return s.repository.GetmyReservations(ctx, userID)
+// ListReservations valida la consulta, completa los valores por defecto y
+// devuelve una página de reservas
+func (s *reservationService) ListReservations(ctx context.Context, query domain.ReservationListQuery) (domain.ReservationPage, error) {
+	switch query.Sort {
+	case "":
+		query.Sort = domain.SortCreatedAtDesc
+	case domain.SortCreatedAtAsc, domain.SortCreatedAtDesc, domain.SortStartDateAsc, domain.SortStartDateDesc:
+	default:
+		return domain.ReservationPage{}, fmt.Errorf("%w: sort invalido", utils.ErrInvalidReservationData)
+	}
+
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultListLimit
+	}
+	if query.Limit < 0 || query.Limit > domain.MaxListLimit {
+		return domain.ReservationPage{}, fmt.Errorf("%w: limit debe estar entre 1 y %d", utils.ErrInvalidReservationData, domain.MaxListLimit)
+	}
+
+	if query.From != "" && query.To != "" && query.To <= query.From {
+		return domain.ReservationPage{}, fmt.Errorf("%w: to debe ser posterior a from", utils.ErrInvalidReservationData)
+	}
+
+	return s.repository.List(ctx, query)
 }
-func (s *reservationService) GetAllReservations(ctx context.Context) ([]domain.Reservation, error) {
-	return s.repository.GetAll(ctx)
+
+// QuoteReservation cotiza la estadía sin reservar ni canjear el código promocional
+func (s *reservationService) QuoteReservation(ctx context.Context, dto domain.CreateReservationDTO) (domain.PriceQuote, error) {
+	return s.pricing.Quote(ctx, dto)
 }
+
 func (s *reservationService) CreateReservation(
 	ctx context.Context,
 	dto domain.CreateReservationDTO,
 ) (domain.Reservation, error) {
 
-	// Validaciones simples con strings
-	if dto.StartDate == "" || dto.EndDate == "" {
-		return domain.Reservation{}, fmt.Errorf("start_date y end_date son requeridos")
-	}
-
-	if dto.EndDate <= dto.StartDate {
-		return domain.Reservation{}, fmt.Errorf("end_date debe ser posterior a start_date")
+	if err := s.checkAvailability(ctx, dto); err != nil {
+		return domain.Reservation{}, err
 	}
 
-	hasOverlap, err := s.repository.HasActiveOverlap(ctx, dto.RoomID, dto.StartDate, dto.EndDate)
+	quote, err := s.priceReservation(ctx, dto)
 	if err != nil {
-		return domain.Reservation{}, fmt.Errorf("failed to validar disponibilidad: %w", err)
-	}
-	if hasOverlap {
-		return domain.Reservation{}, utils.ErrReservationConflict
+		return domain.Reservation{}, err
 	}
 
 	// Mapear DTO → entidad (sin conversión)
@@ -66,35 +119,23 @@ func (s *reservationService) CreateReservation(
 		EndDate:   dto.EndDate,
 		Status:    domain.ReservationStatusActive,
 	}
+	applyQuote(&entity, quote, s.cancellations)
 
-	// Guardar en base de datos
-	saved, err := s.repository.Create(ctx, entity)
+	// Guardar la reserva y su evento en la misma transacción
+	var saved domain.Reservation
+	err = s.tx.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		saved, err = s.repository.Create(txCtx, entity)
+		if err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(domain.EventReservationCreated, saved))
+	})
 	if err != nil {
+		s.releaseDiscount(entity.Pricing, dto.UserID)
 		return domain.Reservation{}, fmt.Errorf("failed to create reservation: %w", err)
 	}
 
-	// Publicar evento en goroutine para no bloquear la respuesta
-	go func() {
-		event := domain.ReservationEvent{
-			EventType:     domain.EventReservationCreated,
-			ReservationID: saved.ID.Hex(),
-			UserID:        saved.UserID,
-			RoomID:        saved.RoomID,
-			StartDate:     saved.StartDate,
-			EndDate:       saved.EndDate,
-			Status:        string(saved.Status),
-			Timestamp:     time.Now(),
-		}
-
-		// Crear contexto con timeout para la publicación
-		pubCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		if err := s.publisher.PublishReservationCreated(pubCtx, event); err != nil {
-			log.Printf("Error publicando evento de reserva creada: %v", err)
-		}
-	}()
-
 	// Mapear entidad → DTO de respuesta
 	resp := domain.Reservation{
 		ID:        saved.ID,
@@ -103,6 +144,9 @@ func (s *reservationService) CreateReservation(
 		StartDate: saved.StartDate,
 		EndDate:   saved.EndDate,
 		Status:    saved.Status,
+		Pricing:   saved.Pricing,
+
+		CancellationPolicy: saved.CancellationPolicy,
 	}
 
 	return resp, nil
@@ -121,51 +165,416 @@ func (s *reservationService) DeleteReservation(ctx context.Context, id string, r
 	if err != nil {
 		return err
 	}
+	if !isCancelable(reservation) {
+		// Ya estaba cancelada o expirada: no hay nada que reembolsar
+		return nil
+	}
+
+	// Calcular reembolso y penalidad según la política de cancelación
+	quote, err := s.cancellations.Quote(ctx, reservation, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to calcular cancelación: %w", err)
+	}
+
+	event := newReservationEvent(domain.EventReservationCanceled, reservation)
+	event.Status = string(domain.ReservationStatusCanceled)
+	event.CancelReason = &reason
+	event.Refund = &domain.RefundInfo{
+		Policy:        quote.Policy,
+		Currency:      quote.Currency,
+		RefundPercent: quote.RefundPercent,
+		RefundAmount:  quote.RefundAmount,
+		PenaltyAmount: quote.PenaltyAmount,
+	}
 
-	// Cancelar la reserva
-	err = s.repository.Delete(ctx, id, reason)
+	// Cancelar la reserva y registrar el evento en la misma transacción
+	err = s.tx.Run(ctx, func(txCtx context.Context) error {
+		if err := s.repository.Delete(txCtx, id, reason, &quote); err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, event)
+	})
+	if errors.Is(err, utils.ErrReservationNotCancelable) {
+		// Un DELETE concurrente la canceló primero: ese ya publica el evento,
+		// reembolsa y anula las autorizaciones
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	// Publicar evento en goroutine para no bloquear la respuesta
-	go func() {
-		event := domain.ReservationEvent{
-			EventType:     domain.EventReservationCanceled,
-			ReservationID: reservation.ID.Hex(),
-			UserID:        reservation.UserID,
-			RoomID:        reservation.RoomID,
-			StartDate:     reservation.StartDate,
-			EndDate:       reservation.EndDate,
-			Status:        string(domain.ReservationStatusCanceled),
-			CancelReason:  &reason,
-			Timestamp:     time.Now(),
+	if err := s.payments.RefundReservation(ctx, id, quote.RefundAmount); err != nil {
+		log.Printf("Error reembolsando la reserva %s: %v", id, err)
+	}
+	s.releasePayments(id)
+
+	return nil
+}
+
+// CancellationPreview calcula cuánto se reembolsaría si se cancelara ahora
+func (s *reservationService) CancellationPreview(ctx context.Context, id string) (domain.CancellationQuote, error) {
+	reservation, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return domain.CancellationQuote{}, err
+	}
+	if !isCancelable(reservation) {
+		return domain.CancellationQuote{}, utils.ErrReservationNotCancelable
+	}
+	return s.cancellations.Quote(ctx, reservation, time.Now())
+}
+
+func isCancelable(reservation domain.Reservation) bool {
+	return reservation.Status == domain.ReservationStatusActive ||
+		reservation.Status == domain.ReservationStatusPending
+}
+
+func (s *reservationService) GetReservationByID(ctx context.Context, id string) (domain.ReservationResponseDTO, error) {
+	res, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return domain.ReservationResponseDTO{}, err
+	}
+	return toResponseDTO(res), nil
+}
+
+// CreateHold retiene la habitación durante el checkout con una reserva
+// pending que expira sola si no se confirma dentro del TTL.
+func (s *reservationService) CreateHold(ctx context.Context, dto domain.CreateReservationDTO) (domain.Reservation, error) {
+	return s.CreateHoldWithTTL(ctx, dto, s.holdTTL)
+}
+
+// CreateHoldWithTTL crea un hold con una duración distinta a la del checkout,
+// por ejemplo para las ofertas de la lista de espera
+func (s *reservationService) CreateHoldWithTTL(ctx context.Context, dto domain.CreateReservationDTO, ttl time.Duration) (domain.Reservation, error) {
+	if err := s.checkAvailability(ctx, dto); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	quote, err := s.priceReservation(ctx, dto)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	entity := domain.Reservation{
+		UserID:    dto.UserID,
+		RoomID:    dto.RoomID,
+		StartDate: dto.StartDate,
+		EndDate:   dto.EndDate,
+		Status:    domain.ReservationStatusPending,
+		ExpiresAt: &expiresAt,
+	}
+	applyQuote(&entity, quote, s.cancellations)
+
+	// El hold se publica como reservation.created con status pending; los
+	// consumidores no lo cuentan como ocupación hasta que se confirme
+	var saved domain.Reservation
+	err = s.tx.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		saved, err = s.repository.Create(txCtx, entity)
+		if err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(domain.EventReservationCreated, saved))
+	})
+	if err != nil {
+		s.releaseDiscount(entity.Pricing, dto.UserID)
+		return domain.Reservation{}, fmt.Errorf("failed to create hold: %w", err)
+	}
+
+	return saved, nil
+}
+
+// ConfirmReservation convierte un hold vigente en una reserva activa.
+func (s *reservationService) ConfirmReservation(ctx context.Context, id string) (domain.ReservationResponseDTO, error) {
+	if id == "" {
+		return domain.ReservationResponseDTO{}, fmt.Errorf("id invalido")
+	}
+
+	// El hold solo se confirma con una autorización de pago exitosa
+	authorized, err := s.payments.HasSuccessfulAuthorization(ctx, id)
+	if err != nil {
+		return domain.ReservationResponseDTO{}, err
+	}
+	if !authorized {
+		return domain.ReservationResponseDTO{}, utils.ErrPaymentRequired
+	}
+
+	var confirmed domain.Reservation
+	err = s.tx.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		confirmed, err = s.repository.Confirm(txCtx, id, time.Now())
+		if err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(domain.EventReservationConfirmed, confirmed))
+	})
+	if errors.Is(err, utils.ErrReservationNotPending) {
+		// Confirmar dos veces es idempotente: se devuelve la reserva sin republicar
+		current, getErr := s.repository.GetByID(ctx, id)
+		if getErr == nil && current.Status == domain.ReservationStatusActive {
+			return toResponseDTO(current), nil
+		}
+		return domain.ReservationResponseDTO{}, err
+	}
+	if err != nil {
+		return domain.ReservationResponseDTO{}, err
+	}
+
+	return toResponseDTO(confirmed), nil
+}
+
+// CheckIn registra la llegada del huésped y publica reservation.checked_in
+// para que rooms-api marque la habitación como ocupada
+func (s *reservationService) CheckIn(ctx context.Context, id string) (domain.ReservationResponseDTO, error) {
+	return s.recordStay(ctx, id, s.repository.CheckIn, domain.EventReservationCheckedIn)
+}
+
+// CheckOut registra la salida del huésped y publica reservation.checked_out
+func (s *reservationService) CheckOut(ctx context.Context, id string) (domain.ReservationResponseDTO, error) {
+	return s.recordStay(ctx, id, s.repository.CheckOut, domain.EventReservationCheckedOut)
+}
+
+func (s *reservationService) recordStay(
+	ctx context.Context,
+	id string,
+	update func(context.Context, string, time.Time) (domain.Reservation, error),
+	eventType domain.EventType,
+) (domain.ReservationResponseDTO, error) {
+	if id == "" {
+		return domain.ReservationResponseDTO{}, fmt.Errorf("id invalido")
+	}
+
+	var updated domain.Reservation
+	err := s.tx.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		updated, err = update(txCtx, id, time.Now())
+		if err != nil {
+			return err
+		}
+		return enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(eventType, updated))
+	})
+	if err != nil {
+		return domain.ReservationResponseDTO{}, err
+	}
+
+	return toResponseDTO(updated), nil
+}
+
+// ExpireHolds libera los holds vencidos y publica reservation.expired por
+// cada uno. Devuelve la cantidad de holds expirados.
+func (s *reservationService) ExpireHolds(ctx context.Context) (int, error) {
+	return s.closeReservations(ctx, domain.EventReservationExpired, s.repository.ExpireNextHold, func(expired domain.Reservation) {
+		s.releasePayments(expired.ID.Hex())
+		s.releaseDiscount(expired.Pricing, expired.UserID)
+	})
+}
+
+// MarkNoShows marca como no-show las reservas activas sin check-in cuando
+// pasó `grace` desde el inicio (medianoche) de start_date, y publica
+// reservation.no_show para liberar las noches restantes
+func (s *reservationService) MarkNoShows(ctx context.Context, grace time.Duration) (int, error) {
+	return s.closeReservations(ctx, domain.EventReservationNoShow, func(txCtx context.Context, now time.Time) (domain.Reservation, bool, error) {
+		return s.repository.MarkNextNoShow(txCtx, now.Add(-grace).Format(utils.DateLayout), now)
+	}, nil)
+}
+
+// CompleteStays cierra las estadías cuando pasó `grace` desde el inicio de
+// end_date y publica reservation.completed
+func (s *reservationService) CompleteStays(ctx context.Context, grace time.Duration) (int, error) {
+	return s.closeReservations(ctx, domain.EventReservationCompleted, func(txCtx context.Context, now time.Time) (domain.Reservation, bool, error) {
+		return s.repository.CompleteNextStay(txCtx, now.Add(-grace).Format(utils.DateLayout), now)
+	}, nil)
+}
+
+// closeReservations aplica `closeNext` hasta que no queden reservas y encola
+// el evento de cada una en la misma transacción; `afterClose` (opcional) corre
+// fuera de la transacción. Devuelve cuántas cerró.
+func (s *reservationService) closeReservations(
+	ctx context.Context,
+	eventType domain.EventType,
+	closeNext func(context.Context, time.Time) (domain.Reservation, bool, error),
+	afterClose func(domain.Reservation),
+) (int, error) {
+	count := 0
+	for {
+		var (
+			closed domain.Reservation
+			found  bool
+		)
+		err := s.tx.Run(ctx, func(txCtx context.Context) error {
+			var err error
+			closed, found, err = closeNext(txCtx, time.Now())
+			if err != nil || !found {
+				return err
+			}
+			return enqueueEvent(txCtx, s.outbox, s.history, newReservationEvent(eventType, closed))
+		})
+		if err != nil {
+			return count, err
+		}
+		if !found {
+			return count, nil
+		}
+
+		count++
+		if afterClose != nil {
+			afterClose(closed)
+		}
+	}
+}
+
+// checkAvailability valida el rango de fechas y que no haya solapamiento
+// con reservas activas ni holds vigentes.
+func (s *reservationService) checkAvailability(ctx context.Context, dto domain.CreateReservationDTO) error {
+	// Validaciones simples con strings
+	if dto.StartDate == "" || dto.EndDate == "" {
+		return fmt.Errorf("start_date y end_date son requeridos")
+	}
+
+	if dto.EndDate <= dto.StartDate {
+		return fmt.Errorf("end_date debe ser posterior a start_date")
+	}
+
+	hasOverlap, err := s.repository.HasActiveOverlap(ctx, dto.RoomID, dto.StartDate, dto.EndDate)
+	if err != nil {
+		return fmt.Errorf("failed to validar disponibilidad: %w", err)
+	}
+	if hasOverlap {
+		return utils.ErrReservationConflict
+	}
+	return nil
+}
+
+// priceReservation cotiza la estadía y canjea el código promocional, si vino
+func (s *reservationService) priceReservation(ctx context.Context, dto domain.CreateReservationDTO) (*domain.PriceQuote, error) {
+	quote, err := quoteStay(ctx, s.pricing, dto)
+	if quote == nil || err != nil {
+		return nil, err
+	}
+
+	if quote.Discount != nil {
+		if err := s.promotions.Redeem(ctx, *quote.Discount, dto.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return quote, nil
+}
+
+// applyQuote guarda en la reserva el precio acordado y la política de
+// cancelación del tipo de habitación. Sin cotización (rooms-api no
+// respondió) ambos quedan vacíos y se resuelven al cobrar o al cancelar.
+func applyQuote(reservation *domain.Reservation, quote *domain.PriceQuote, cancellations CancellationService) {
+	if quote == nil {
+		return
+	}
+	reservation.Pricing = &quote.ReservationPricing
+	reservation.CancellationPolicy = cancellations.PolicyType(quote.RoomType)
+}
+
+// quoteStay cotiza la estadía con el plan de tarifas. Sin código promocional
+// el precio es informativo: si rooms-api no responde devuelve nil y la
+// reserva se crea igual (el pago se calcula con la tarifa vigente). La
+// estadía mínima y los datos inválidos se rechazan siempre.
+func quoteStay(ctx context.Context, pricing PricingService, dto domain.CreateReservationDTO) (*domain.PriceQuote, error) {
+	quote, err := pricing.Quote(ctx, dto)
+	if err != nil {
+		if dto.PromoCode == "" && !errors.Is(err, utils.ErrInvalidReservationData) {
+			log.Printf("No se pudo cotizar la habitación %d: %v", dto.RoomID, err)
+			return nil, nil
 		}
+		return nil, err
+	}
+	return &quote, nil
+}
 
-		// Crear contexto con timeout para la publicación
-		pubCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// releaseDiscount devuelve el uso del código si la reserva no llegó a concretarse
+func (s *reservationService) releaseDiscount(pricing *domain.ReservationPricing, userID uint) {
+	if pricing == nil || pricing.Discount == nil {
+		return
+	}
+	s.promotions.Release(pricing.Discount, userID)
+}
+
+// releasePayments anula en background las autorizaciones sin capturar
+func (s *reservationService) releasePayments(reservationID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		if err := s.publisher.PublishReservationCanceled(pubCtx, event); err != nil {
-			log.Printf("Error publicando evento de reserva cancelada: %v", err)
+		if err := s.payments.ReleaseForReservation(ctx, reservationID); err != nil {
+			log.Printf("Error liberando pagos de la reserva %s: %v", reservationID, err)
 		}
 	}()
+}
 
-	return nil
+func newReservationEvent(eventType domain.EventType, reservation domain.Reservation) domain.ReservationEvent {
+	return domain.ReservationEvent{
+		EventType:     eventType,
+		ReservationID: reservation.ID.Hex(),
+		UserID:        reservation.UserID,
+		RoomID:        reservation.RoomID,
+		StartDate:     reservation.StartDate,
+		EndDate:       reservation.EndDate,
+		Status:        string(reservation.Status),
+		GroupID:       groupIDHex(reservation.GroupID),
+		Timestamp:     time.Now(),
+	}
 }
 
-func (s *reservationService) GetReservationByID(ctx context.Context, id string) (domain.ReservationResponseDTO, error) {
-	res, err := s.repository.GetByID(ctx, id)
+func groupIDHex(id *primitive.ObjectID) *string {
+	if id == nil {
+		return nil
+	}
+	hex := id.Hex()
+	return &hex
+}
+
+// enqueueEvent registra el cambio en el historial de la reserva y escribe
+// el evento en el outbox; el relay lo publica después
+func enqueueEvent(ctx context.Context, outbox repositories.OutboxRepository, history HistoryService, event domain.ReservationEvent) error {
+	if err := history.Record(ctx, event.EventType, event.ReservationID); err != nil {
+		return err
+	}
+	return enqueueMessage(ctx, outbox, string(event.EventType), event.ReservationID, domain.ReservationEventSchemaV1, event)
+}
+
+// enqueueMessage publica `message` como `data` de un envelope CloudEvents
+// cuyo type es la routing key
+func enqueueMessage(ctx context.Context, outbox repositories.OutboxRepository, routingKey, subject, schema string, message interface{}) error {
+	envelope, err := events.NewCloudEvent(routingKey, subject, schema, message)
 	if err != nil {
-		return domain.ReservationResponseDTO{}, err
+		return err
 	}
-	dto := domain.ReservationResponseDTO{
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return outbox.Enqueue(ctx, domain.OutboxMessage{
+		RoutingKey: routingKey,
+		Subject:    subject,
+		Payload:    payload,
+	})
+}
+
+func toResponseDTO(res domain.Reservation) domain.ReservationResponseDTO {
+	return domain.ReservationResponseDTO{
 		ID:        res.ID.Hex(),
 		UserID:    res.UserID,
 		RoomID:    res.RoomID,
 		StartDate: res.StartDate,
 		EndDate:   res.EndDate,
 		Status:    string(res.Status),
+		ExpiresAt: res.ExpiresAt,
+
+		CheckedInAt:  res.CheckedInAt,
+		CheckedOutAt: res.CheckedOutAt,
+
+		NeedsRelocation: res.NeedsRelocation,
+
+		GroupID:   groupIDHex(res.GroupID),
+		GuestName: res.GuestName,
+
+		Pricing:            res.Pricing,
+		CancellationPolicy: res.CancellationPolicy,
 	}
-	return dto, nil
 }
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"reservations-api/config"
+	"reservations-api/domain"
+	"reservations-api/repositories"
+	"reservations-api/utils"
+)
+
+type WaitlistService interface {
+	Join(ctx context.Context, dto domain.CreateWaitlistDTO) (domain.WaitlistEntry, error)
+	Get(ctx context.Context, id string) (domain.WaitlistEntry, error)
+	Cancel(ctx context.Context, id string) error
+	// HandleReservationEvent cierra ofertas y busca a quién ofrecer el
+	// inventario que liberan las cancelaciones y los holds vencidos
+	HandleReservationEvent(ctx context.Context, event domain.ReservationEvent) error
+}
+
+type waitlistService struct {
+	repository   repositories.WaitlistRepository
+	reservations ReservationService
+	outbox       repositories.OutboxRepository
+	tx           repositories.TxRunner
+	roomsClient  *config.RoomsAPIClient
+	offerTTL     time.Duration
+}
+
+func NewWaitlistService(
+	repository repositories.WaitlistRepository,
+	reservations ReservationService,
+	outbox repositories.OutboxRepository,
+	tx repositories.TxRunner,
+	roomsClient *config.RoomsAPIClient,
+	cfg config.WaitlistConfig,
+) WaitlistService {
+	return &waitlistService{
+		repository:   repository,
+		reservations: reservations,
+		outbox:       outbox,
+		tx:           tx,
+		roomsClient:  roomsClient,
+		offerTTL:     cfg.OfferTTL,
+	}
+}
+
+func (s *waitlistService) Join(ctx context.Context, dto domain.CreateWaitlistDTO) (domain.WaitlistEntry, error) {
+	if (dto.RoomID == nil) == (dto.RoomType == "") {
+		return domain.WaitlistEntry{}, fmt.Errorf("%w: indicar room_id o room_type (solo uno)", utils.ErrInvalidReservationData)
+	}
+	if dto.EndDate <= dto.StartDate {
+		return domain.WaitlistEntry{}, fmt.Errorf("%w: end_date debe ser posterior a start_date", utils.ErrInvalidReservationData)
+	}
+	if dto.StartDate < time.Now().Format(utils.DateLayout) {
+		return domain.WaitlistEntry{}, fmt.Errorf("%w: start_date no puede ser pasada", utils.ErrInvalidReservationData)
+	}
+
+	entry := domain.WaitlistEntry{
+		UserID:    dto.UserID,
+		RoomID:    dto.RoomID,
+		RoomType:  dto.RoomType,
+		StartDate: dto.StartDate,
+		EndDate:   dto.EndDate,
+	}
+
+	// Guardar también el tipo de la habitación pedida para poder listar por tipo
+	var rooms []domain.RoomInfo
+	if dto.RoomID != nil {
+		room, err := s.roomsClient.GetRoomByID(ctx, *dto.RoomID)
+		if errors.Is(err, config.ErrRoomNotFound) {
+			return domain.WaitlistEntry{}, fmt.Errorf("%w: la habitación no existe", utils.ErrInvalidReservationData)
+		}
+		if err != nil {
+			return domain.WaitlistEntry{}, err
+		}
+		entry.RoomType = room.Type
+		rooms = []domain.RoomInfo{*room}
+	} else {
+		var err error
+		rooms, err = s.roomsClient.ListRoomsByType(ctx, dto.RoomType)
+		if err != nil {
+			return domain.WaitlistEntry{}, err
+		}
+		if len(rooms) == 0 {
+			return domain.WaitlistEntry{}, fmt.Errorf("%w: no hay habitaciones de tipo %s", utils.ErrInvalidReservationData, dto.RoomType)
+		}
+	}
+
+	saved, err := s.repository.Create(ctx, entry)
+	if err != nil {
+		return domain.WaitlistEntry{}, err
+	}
+
+	// Si ya hay lugar (por ejemplo, se liberó mientras el huésped se anotaba)
+	// se ofrece en el momento en lugar de esperar al próximo evento
+	for _, room := range rooms {
+		if room.Status == domain.RoomStatusMaintenance {
+			continue
+		}
+		offered, err := s.tryOffer(ctx, saved, room.ID)
+		if err != nil {
+			log.Printf("Error ofreciendo la habitación %d a la entrada %s: %v", room.ID, saved.ID.Hex(), err)
+			break
+		}
+		if offered {
+			return s.repository.GetByID(ctx, saved.ID.Hex())
+		}
+	}
+
+	return saved, nil
+}
+
+func (s *waitlistService) Get(ctx context.Context, id string) (domain.WaitlistEntry, error) {
+	return s.repository.GetByID(ctx, id)
+}
+
+func (s *waitlistService) Cancel(ctx context.Context, id string) error {
+	previous, err := s.repository.Cancel(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Si había un hold ofrecido se libera para el siguiente en la lista
+	if previous.Status == domain.WaitlistStatusOffered && previous.ReservationID != nil {
+		if err := s.reservations.DeleteReservation(ctx, *previous.ReservationID, "waitlist offer declined"); err != nil {
+			log.Printf("Error liberando el hold %s de la lista de espera: %v", *previous.ReservationID, err)
+		}
+	}
+	return nil
+}
+
+func (s *waitlistService) HandleReservationEvent(ctx context.Context, event domain.ReservationEvent) error {
+	switch event.EventType {
+	case domain.EventReservationConfirmed:
+		_, err := s.repository.ResolveOffer(ctx, event.ReservationID, domain.WaitlistStatusAccepted)
+		return err
+	case domain.EventReservationExpired:
+		if _, err := s.repository.ResolveOffer(ctx, event.ReservationID, domain.WaitlistStatusExpired); err != nil {
+			return err
+		}
+	case domain.EventReservationCanceled:
+		if _, err := s.repository.ResolveOffer(ctx, event.ReservationID, domain.WaitlistStatusCanceled); err != nil {
+			return err
+		}
+	case domain.EventReservationNoShow:
+		// Solo se liberan las noches que todavía no pasaron
+		today := time.Now().Format(utils.DateLayout)
+		if event.EndDate <= today {
+			return nil
+		}
+		from := event.StartDate
+		if from < today {
+			from = today
+		}
+		return s.matchFreedRoom(ctx, event.RoomID, from, event.EndDate)
+	default:
+		return nil
+	}
+
+	return s.matchFreedRoom(ctx, event.RoomID, event.StartDate, event.EndDate)
+}
+
+// matchFreedRoom ofrece la habitación liberada a las entradas en espera que
+// la piden (o piden su tipo), respetando el orden de llegada
+func (s *waitlistService) matchFreedRoom(ctx context.Context, roomID uint, from, to string) error {
+	room, err := s.roomsClient.GetRoomByID(ctx, roomID)
+	if errors.Is(err, config.ErrRoomNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch room %d: %w", roomID, err)
+	}
+	if room.Status == domain.RoomStatusMaintenance {
+		return nil
+	}
+
+	today := time.Now().Format(utils.DateLayout)
+	entries, err := s.repository.FindWaiting(ctx, room.ID, room.Type, from, to, today)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if _, err := s.tryOffer(ctx, entry, room.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tryOffer crea un hold para la entrada sobre la habitación y publica
+// waitlist.offered. Devuelve false si la habitación no está libre en las
+// fechas pedidas, si la estadía no cumple sus reglas de tarifa o si otra
+// instancia ya tomó la entrada.
+func (s *waitlistService) tryOffer(ctx context.Context, entry domain.WaitlistEntry, roomID uint) (bool, error) {
+	claimed, err := s.repository.ClaimOffer(ctx, entry.ID, roomID)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	hold, err := s.reservations.CreateHoldWithTTL(ctx, domain.CreateReservationDTO{
+		UserID:    entry.UserID,
+		RoomID:    roomID,
+		StartDate: entry.StartDate,
+		EndDate:   entry.EndDate,
+	}, s.offerTTL)
+	if err != nil {
+		if revertErr := s.repository.RevertOffer(ctx, entry.ID); revertErr != nil {
+			log.Printf("Error devolviendo la entrada %s a la espera: %v", entry.ID.Hex(), revertErr)
+		}
+		// Tampoco se ofrece la habitación si la estadía no cumple sus reglas
+		// de tarifa (por ejemplo la estadía mínima); devolver el error haría
+		// que el consumer reencole el evento para siempre
+		if errors.Is(err, utils.ErrReservationConflict) || errors.Is(err, utils.ErrInvalidReservationData) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	reservationID := hold.ID.Hex()
+	event := domain.WaitlistEvent{
+		EventType:      domain.EventWaitlistOffered,
+		EntryID:        entry.ID.Hex(),
+		UserID:         entry.UserID,
+		RoomID:         roomID,
+		ReservationID:  reservationID,
+		StartDate:      entry.StartDate,
+		EndDate:        entry.EndDate,
+		OfferExpiresAt: *hold.ExpiresAt,
+		Timestamp:      time.Now(),
+	}
+
+	err = s.tx.Run(ctx, func(txCtx context.Context) error {
+		if err := s.repository.AttachOffer(txCtx, entry.ID, reservationID, *hold.ExpiresAt); err != nil {
+			return err
+		}
+		return enqueueMessage(txCtx, s.outbox, string(event.EventType), event.EntryID, domain.WaitlistEventSchemaV1, event)
+	})
+	if err != nil {
+		// Sin oferta registrada el hold vence solo y la entrada vuelve a esperar
+		if revertErr := s.repository.RevertOffer(ctx, entry.ID); revertErr != nil {
+			log.Printf("Error devolviendo la entrada %s a la espera: %v", entry.ID.Hex(), revertErr)
+		}
+		return false, err
+	}
+
+	log.Printf("Habitación %d ofrecida a la entrada %s de la lista de espera (hold %s)", roomID, entry.ID.Hex(), reservationID)
+	return true, nil
+}
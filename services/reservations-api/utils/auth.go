@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"reservations-api/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ActorContextKey es la clave con la que el middleware guarda el actor en
+// gin.Context (gin resuelve ctx.Value con claves string desde sus Keys)
+const ActorContextKey = "actor"
+
+type actorKey struct{}
+
+// Claims replica los claims que emite users-api
+type Claims struct {
+	UserID   uint   `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// ParseBearerToken extrae el token de un header "Authorization: Bearer <token>"
+func ParseBearerToken(header string) (string, error) {
+	parts := strings.Fields(header)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", errors.New("invalid authorization header format")
+	}
+	return parts[1], nil
+}
+
+func ValidateToken(tokenString, secret string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// WithActor asocia el actor al contexto para procesos fuera de un request
+// (jobs y consumers)
+func WithActor(ctx context.Context, actor domain.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext devuelve quién origina el cambio; sin actor asociado se
+// asume un proceso interno
+func ActorFromContext(ctx context.Context) domain.Actor {
+	if actor, ok := ctx.Value(actorKey{}).(domain.Actor); ok {
+		return actor
+	}
+	if actor, ok := ctx.Value(ActorContextKey).(domain.Actor); ok {
+		return actor
+	}
+	return domain.Actor{Type: domain.ActorTypeSystem}
+}
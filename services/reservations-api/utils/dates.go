@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DateLayout es el formato de fecha que usan start_date y end_date
+const DateLayout = "2006-01-02"
+
+// CountNights devuelve la cantidad de noches entre check-in y check-out
+func CountNights(startDate, endDate string) (int, error) {
+	start, err := time.Parse(DateLayout, startDate)
+	if err != nil {
+		return 0, fmt.Errorf("start_date invalida: %w", err)
+	}
+	end, err := time.Parse(DateLayout, endDate)
+	if err != nil {
+		return 0, fmt.Errorf("end_date invalida: %w", err)
+	}
+	nights := int(end.Sub(start).Hours() / 24)
+	if nights <= 0 {
+		return 0, fmt.Errorf("end_date debe ser posterior a start_date")
+	}
+	return nights, nil
+}
+
+// RoundMoney redondea un monto a 2 decimales
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// NightsBetween devuelve las fechas de cada noche en [from, to). El rango se
+// valida contra maxNights antes de armar la lista.
+func NightsBetween(from, to string, maxNights int) ([]string, error) {
+	start, err := time.Parse(DateLayout, from)
+	if err != nil {
+		return nil, fmt.Errorf("from invalida: %w", err)
+	}
+	end, err := time.Parse(DateLayout, to)
+	if err != nil {
+		return nil, fmt.Errorf("to invalida: %w", err)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("to debe ser posterior a from")
+	}
+	count := int(end.Sub(start).Hours() / 24)
+	if count > maxNights {
+		return nil, fmt.Errorf("el rango no puede superar %d noches", maxNights)
+	}
+
+	nights := make([]string, 0, count)
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		nights = append(nights, d.Format(DateLayout))
+	}
+	return nights, nil
+}
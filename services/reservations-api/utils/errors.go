@@ -5,8 +5,33 @@ import (
 )
 
 var (
-	ErrReservationNotFound    = errors.New("reservation not found")
-	ErrInvalidReservationData = errors.New("invalid reservation data")
-	ErrInternalServer         = errors.New("internal server error")
-	ErrReservationConflict    = errors.New("room already reserved for selected dates")
+	ErrReservationNotFound      = errors.New("reservation not found")
+	ErrInvalidReservationData   = errors.New("invalid reservation data")
+	ErrInternalServer           = errors.New("internal server error")
+	ErrReservationConflict      = errors.New("room already reserved for selected dates")
+	ErrHoldExpired              = errors.New("reservation hold has expired")
+	ErrReservationNotPending    = errors.New("reservation is not pending confirmation")
+	ErrPaymentRequired          = errors.New("a successful payment authorization is required")
+	ErrPaymentNotFound          = errors.New("payment not found")
+	ErrPaymentConflict          = errors.New("payment was modified concurrently")
+	ErrPaymentDeclined          = errors.New("payment declined")
+	ErrPaymentInProgress        = errors.New("another payment authorization is in progress for this reservation")
+	ErrReservationNotPayable    = errors.New("reservation cannot be paid in its current status")
+	ErrReservationNotCancelable = errors.New("reservation is already canceled or expired")
+	ErrCancelPolicyUnknown      = errors.New("cancellation policy could not be determined; try again later")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different payload")
+	ErrIdempotencyInProgress    = errors.New("a request with this idempotency key is already in progress")
+	ErrCheckInNotAllowed        = errors.New("reservation cannot be checked in in its current state")
+	ErrCheckOutNotAllowed       = errors.New("reservation is not checked in")
+	ErrWaitlistEntryNotFound    = errors.New("waitlist entry not found")
+	ErrWaitlistEntryNotActive   = errors.New("waitlist entry is no longer active")
+	ErrGroupNotFound            = errors.New("group booking not found")
+	ErrGroupNotActive           = errors.New("group booking is canceled")
+	ErrPromotionNotFound        = errors.New("promotion not found")
+	ErrPromoCodeTaken           = errors.New("promo code already exists")
+	ErrPromoCodeNotApplicable   = errors.New("promo code is not applicable")
+	ErrPromoCodeExhausted       = errors.New("promo code usage limit reached")
+	ErrInvoiceNotAvailable      = errors.New("invoice can only be issued for completed stays")
+	ErrInvoiceNotFound          = errors.New("no open invoice for reservation")
+	ErrFolioClosed              = errors.New("reservation does not accept folio charges in its current status")
 )
@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"reservations-api/domain"
+)
+
+const (
+	icalDateLayout     = "20060102"
+	icalDateTimeLayout = "20060102T150405Z"
+	// RFC 5545 §3.1: las líneas no deben superar 75 octetos
+	icalMaxLineOctets = 75
+)
+
+// ICalWriter arma un VCALENDAR con líneas CRLF plegadas según RFC 5545
+type ICalWriter struct {
+	builder strings.Builder
+}
+
+func NewICalWriter(prodID, name string) *ICalWriter {
+	w := &ICalWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + prodID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + EscapeICalText(name))
+	return w
+}
+
+// AddAllDayEvent agrega un evento de día completo que ocupa [startDate, endDate)
+func (w *ICalWriter) AddAllDayEvent(uid, startDate, endDate, summary string, stamp time.Time) error {
+	start, err := time.Parse(DateLayout, startDate)
+	if err != nil {
+		return fmt.Errorf("start_date invalida: %w", err)
+	}
+	end, err := time.Parse(DateLayout, endDate)
+	if err != nil {
+		return fmt.Errorf("end_date invalida: %w", err)
+	}
+
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + EscapeICalText(uid))
+	w.line("DTSTAMP:" + stamp.UTC().Format(icalDateTimeLayout))
+	w.line("DTSTART;VALUE=DATE:" + start.Format(icalDateLayout))
+	w.line("DTEND;VALUE=DATE:" + end.Format(icalDateLayout))
+	w.line("SUMMARY:" + EscapeICalText(summary))
+	w.line("TRANSP:OPAQUE")
+	w.line("STATUS:CONFIRMED")
+	w.line("END:VEVENT")
+	return nil
+}
+
+// Bytes cierra el calendario y devuelve el contenido
+func (w *ICalWriter) Bytes() []byte {
+	w.line("END:VCALENDAR")
+	return []byte(w.builder.String())
+}
+
+func (w *ICalWriter) line(content string) {
+	// Plegar sin cortar caracteres UTF-8: cada continuación empieza con un espacio
+	limit := icalMaxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		w.builder.WriteString(content[:cut])
+		w.builder.WriteString("\r\n ")
+		content = content[cut:]
+		limit = icalMaxLineOctets - 1
+	}
+	w.builder.WriteString(content)
+	w.builder.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// EscapeICalText escapa un valor TEXT (RFC 5545 §3.3.11)
+func EscapeICalText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(value)
+}
+
+// ParseICalEvents lee los VEVENT de un calendario. Los eventos con fecha y
+// hora se redondean a días: ocupan desde el día de inicio hasta el de fin.
+func ParseICalEvents(r io.Reader) ([]domain.ICalEvent, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []domain.ICalEvent
+		current *domain.ICalEvent
+	)
+	for i, raw := range lines {
+		name, params, value := splitICalLine(raw)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &domain.ICalEvent{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("línea %d: END:VEVENT sin BEGIN", i+1)
+			}
+			event, err := finishICalEvent(*current)
+			if err != nil {
+				return nil, fmt.Errorf("línea %d: %w", i+1, err)
+			}
+			events = append(events, event)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = unescapeICalText(value)
+		case name == "SUMMARY":
+			current.Summary = unescapeICalText(value)
+		case name == "STATUS":
+			current.Canceled = strings.EqualFold(value, "CANCELLED")
+		case name == "DTSTART":
+			current.StartDate, err = parseICalDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("línea %d: DTSTART invalido: %w", i+1, err)
+			}
+		case name == "DTEND":
+			current.EndDate, err = parseICalDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("línea %d: DTEND invalido: %w", i+1, err)
+			}
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("VEVENT sin cerrar")
+	}
+	return events, nil
+}
+
+func finishICalEvent(event domain.ICalEvent) (domain.ICalEvent, error) {
+	if event.UID == "" {
+		return event, fmt.Errorf("VEVENT sin UID")
+	}
+	if event.StartDate == "" {
+		return event, fmt.Errorf("VEVENT %s sin DTSTART", event.UID)
+	}
+	// Sin DTEND (o con un evento dentro del mismo día) se bloquea una noche
+	if event.EndDate <= event.StartDate {
+		start, _ := time.Parse(DateLayout, event.StartDate)
+		event.EndDate = start.AddDate(0, 0, 1).Format(DateLayout)
+	}
+	return event, nil
+}
+
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// splitICalLine separa "NOMBRE;PARAM=X:valor" en nombre, parámetros y valor
+func splitICalLine(line string) (string, string, string) {
+	head, value, _ := strings.Cut(line, ":")
+	name, params, _ := strings.Cut(head, ";")
+	return strings.ToUpper(name), strings.ToUpper(params), value
+}
+
+func parseICalDate(value, params string) (string, error) {
+	if len(value) < len(icalDateLayout) {
+		return "", fmt.Errorf("fecha %q", value)
+	}
+	// Las fechas con hora y TZID se toman en la hora local del feed
+	if strings.HasSuffix(value, "Z") && !strings.Contains(params, "VALUE=DATE") {
+		t, err := time.Parse(icalDateTimeLayout, value)
+		if err != nil {
+			return "", err
+		}
+		return t.Format(DateLayout), nil
+	}
+	t, err := time.Parse(icalDateLayout, value[:len(icalDateLayout)])
+	if err != nil {
+		return "", err
+	}
+	return t.Format(DateLayout), nil
+}
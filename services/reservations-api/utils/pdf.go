@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"reservations-api/domain"
+)
+
+// Tamaño A4 en puntos
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+)
+
+// PDFWriter genera documentos PDF 1.4 de texto simple con las fuentes
+// estándar Helvetica y Helvetica-Bold (no requieren incrustar fuentes)
+type PDFWriter struct {
+	pages []*bytes.Buffer
+}
+
+func NewPDFWriter() *PDFWriter {
+	w := &PDFWriter{}
+	w.AddPage()
+	return w
+}
+
+func (w *PDFWriter) AddPage() {
+	w.pages = append(w.pages, &bytes.Buffer{})
+}
+
+func (w *PDFWriter) current() *bytes.Buffer {
+	return w.pages[len(w.pages)-1]
+}
+
+// Text escribe texto con el origen en (x, y), medido desde abajo a la izquierda
+func (w *PDFWriter) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(w.current(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(text))
+}
+
+// TextRight escribe texto alineado a derecha en x
+func (w *PDFWriter) TextRight(x, y, size float64, bold bool, text string) {
+	w.Text(x-pdfTextWidth(text, size), y, size, bold, text)
+}
+
+func (w *PDFWriter) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(w.current(), "%.2f w %.2f %.2f m %.2f %.2f l S\n", 0.5, x1, y1, x2, y2)
+}
+
+func (w *PDFWriter) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: catálogo, 2: árbol de páginas, 3-4: fuentes, luego página + contenido
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range w.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+i*2,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfEscape convierte a WinAnsi (Latin-1 más el euro) y escapa los
+// delimitadores de strings de PDF
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '€':
+			b.WriteString(`\200`)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, `\%03o`, r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// pdfTextWidth aproxima el ancho en Helvetica; es exacto para cifras,
+// que es lo que se alinea a derecha
+func pdfTextWidth(text string, size float64) float64 {
+	units := 0
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			units += 556
+		case r == '.' || r == ',' || r == ' ':
+			units += 278
+		case r == '-':
+			units += 333
+		case r == '%':
+			units += 889
+		default:
+			units += 600
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+func truncateText(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	return string([]rune(text)[:max-3]) + "..."
+}
+
+// RenderInvoicePDF arma el PDF de una factura o nota de crédito
+func RenderInvoicePDF(invoice domain.Invoice, issuerName, issuerTaxID string) []byte {
+	w := NewPDFWriter()
+	right := float64(pdfPageWidth - pdfMargin)
+	left := float64(pdfMargin)
+	y := float64(pdfPageHeight - pdfMargin)
+
+	money := func(amount float64) string {
+		return fmt.Sprintf("%.2f", amount)
+	}
+
+	title := "FACTURA"
+	if invoice.Type == domain.InvoiceTypeCreditNote {
+		title = "NOTA DE CRÉDITO"
+	}
+
+	w.Text(left, y, 16, true, issuerName)
+	w.TextRight(right, y, 16, true, title)
+	y -= 18
+	if issuerTaxID != "" {
+		w.Text(left, y, 10, false, "Identificación fiscal: "+issuerTaxID)
+	}
+	w.TextRight(right, y, 10, false, "Nº "+invoice.Number)
+	y -= 14
+	w.TextRight(right, y, 10, false, "Fecha "+invoice.IssuedAt.Format(DateLayout))
+	y -= 30
+
+	guest := invoice.GuestName
+	if guest == "" {
+		guest = fmt.Sprintf("Usuario %d", invoice.UserID)
+	}
+	w.Text(left, y, 10, true, "Cliente")
+	w.Text(left+80, y, 10, false, guest)
+	y -= 14
+	w.Text(left, y, 10, true, "Reserva")
+	w.Text(left+80, y, 10, false, invoice.ReservationID)
+	y -= 14
+	w.Text(left, y, 10, true, "Estadía")
+	w.Text(left+80, y, 10, false, fmt.Sprintf("Habitación %d, %s a %s", invoice.RoomID, invoice.StartDate, invoice.EndDate))
+	y -= 14
+	if invoice.CreditedInvoiceNumber != "" {
+		w.Text(left, y, 10, true, "Anula")
+		w.Text(left+80, y, 10, false, fmt.Sprintf("Factura %s: %s", invoice.CreditedInvoiceNumber, invoice.Reason))
+		y -= 14
+	}
+	y -= 16
+
+	header := func() {
+		w.Text(left, y, 9, true, "Descripción")
+		w.TextRight(right-200, y, 9, true, "Cant.")
+		w.TextRight(right-130, y, 9, true, "Precio")
+		w.TextRight(right-70, y, 9, true, "Imp. %")
+		w.TextRight(right, y, 9, true, "Importe")
+		y -= 6
+		w.Line(left, y, right, y)
+		y -= 14
+	}
+	header()
+	for _, line := range invoice.Lines {
+		if y < pdfMargin+120 {
+			w.AddPage()
+			y = float64(pdfPageHeight - pdfMargin)
+			header()
+		}
+		w.Text(left, y, 9, false, truncateText(line.Description, 50))
+		w.TextRight(right-200, y, 9, false, fmt.Sprintf("%d", line.Quantity))
+		w.TextRight(right-130, y, 9, false, money(line.UnitPrice))
+		w.TextRight(right-70, y, 9, false, fmt.Sprintf("%g", line.TaxRate))
+		w.TextRight(right, y, 9, false, money(line.Amount))
+		y -= 14
+	}
+	w.Line(left, y+6, right, y+6)
+	y -= 14
+
+	for _, tax := range invoice.TaxLines {
+		w.Text(right-250, y, 9, false, fmt.Sprintf("Base %s %g%%", tax.Name, tax.Rate))
+		w.TextRight(right-90, y, 9, false, money(tax.Base))
+		w.Text(right-80, y, 9, false, tax.Name)
+		w.TextRight(right, y, 9, false, money(tax.Amount))
+		y -= 14
+	}
+	y -= 6
+	type total struct {
+		label  string
+		amount float64
+		bold   bool
+	}
+	totals := []total{
+		{"Neto", invoice.Net, false},
+		{"Impuestos", invoice.TaxTotal, false},
+		{"Total " + invoice.Currency, invoice.Total, true},
+	}
+	if invoice.Type == domain.InvoiceTypeInvoice {
+		totals = append(totals,
+			total{"Pagado", invoice.AmountPaid, false},
+			total{"Saldo pendiente", invoice.BalanceDue, true},
+		)
+	}
+	for _, row := range totals {
+		w.Text(right-250, y, 10, row.bold, row.label)
+		w.TextRight(right, y, 10, row.bold, money(row.amount))
+		y -= 16
+	}
+
+	return w.Bytes()
+}
@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+const defaultArrivalsCheckInterval = time.Hour
+
+// ArrivalsConfig agrupa la configuración del barrido diario de llegadas
+type ArrivalsConfig struct {
+	// CheckInterval es cada cuánto se verifica si cambió el día; el barrido
+	// corre una vez por día
+	CheckInterval time.Duration
+}
+
+func LoadArrivalsConfig() ArrivalsConfig {
+	return ArrivalsConfig{
+		CheckInterval: getDurationOrDefault("ROOM_ARRIVALS_CHECK_INTERVAL", defaultArrivalsCheckInterval),
+	}
+}
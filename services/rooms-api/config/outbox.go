@@ -0,0 +1,39 @@
+package config
+
+import (
+	"log"
+	"time"
+)
+
+const (
+	defaultOutboxRelayInterval = 2 * time.Second
+	defaultOutboxRetention     = 7 * 24 * time.Hour
+)
+
+// OutboxConfig agrupa la configuración del relay del outbox de eventos
+type OutboxConfig struct {
+	// RelayInterval es cada cuánto el relay busca mensajes pendientes
+	RelayInterval time.Duration
+	// Retention es cuánto se conservan los mensajes ya enviados
+	Retention time.Duration
+}
+
+func LoadOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		RelayInterval: getDurationOrDefault("OUTBOX_RELAY_INTERVAL", defaultOutboxRelayInterval),
+		Retention:     getDurationOrDefault("OUTBOX_RETENTION", defaultOutboxRetention),
+	}
+}
+
+func getDurationOrDefault(key string, def time.Duration) time.Duration {
+	raw := getenvOrDefault(key, "")
+	if raw == "" {
+		return def
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		log.Printf("⚠️  Valor inválido para %s (%q), usando %s", key, raw, def)
+		return def
+	}
+	return d
+}
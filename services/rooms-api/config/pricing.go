@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+const defaultDynamicPricingInterval = time.Hour
+
+// DynamicPricingConfig agrupa la configuración del recálculo de precios dinámicos
+type DynamicPricingConfig struct {
+	// RecomputeInterval es cada cuánto se recalcula el horizonte de todas
+	// las políticas; los eventos de reservas recalculan sus noches al llegar
+	RecomputeInterval time.Duration
+}
+
+func LoadDynamicPricingConfig() DynamicPricingConfig {
+	return DynamicPricingConfig{
+		RecomputeInterval: getDurationOrDefault("DYNAMIC_PRICING_INTERVAL", defaultDynamicPricingInterval),
+	}
+}
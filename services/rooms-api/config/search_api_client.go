@@ -58,6 +58,12 @@ type SearchRoomResult struct {
 	HasMinibar  []bool    `json:"has_minibar"`
 	CreatedAt   []string  `json:"created_at"`
 	UpdatedAt   []string  `json:"updated_at"`
+
+	CoverImageURL     []string `json:"cover_image_url,omitempty"`
+	CoverThumbnailURL []string `json:"cover_thumbnail_url,omitempty"`
+
+	AmenityCodes []string `json:"amenity_codes,omitempty"`
+	AmenityNames []string `json:"amenities,omitempty"`
 }
 
 // SearchRooms realiza una búsqueda en search-api
@@ -92,6 +98,9 @@ func (c *SearchAPIClient) SearchRooms(filter domain.RoomFilter, page, limit int)
 	if filter.HasMinibar != nil {
 		params.Add("has_minibar", strconv.FormatBool(*filter.HasMinibar))
 	}
+	for _, amenity := range filter.Amenities {
+		params.Add("amenities", amenity)
+	}
 
 	params.Add("page", strconv.Itoa(page))
 	params.Add("limit", strconv.Itoa(limit))
@@ -132,6 +141,15 @@ func (c *SearchAPIClient) SearchRooms(filter domain.RoomFilter, page, limit int)
 			number = strconv.Itoa(result.Number[0])
 		}
 
+		// Códigos y nombres vienen en el mismo orden; ícono y categoría no se indexan
+		amenities := make([]domain.AmenityResponse, len(result.AmenityCodes))
+		for j, code := range result.AmenityCodes {
+			amenities[j] = domain.AmenityResponse{Code: code}
+			if j < len(result.AmenityNames) {
+				amenities[j].Name = result.AmenityNames[j]
+			}
+		}
+
 		rooms[i] = domain.RoomResponse{
 			ID:          uint(id),
 			Number:      number,
@@ -145,6 +163,12 @@ func (c *SearchAPIClient) SearchRooms(filter domain.RoomFilter, page, limit int)
 			HasAC:       getFirstBool(result.HasAC),
 			HasTV:       getFirstBool(result.HasTV),
 			HasMinibar:  getFirstBool(result.HasMinibar),
+			Amenities:   amenities,
+
+			// El índice solo tiene la portada; la galería completa está en GET /rooms/:id
+			Images:            []domain.RoomImageResponse{},
+			CoverImageURL:     getFirstString(result.CoverImageURL),
+			CoverThumbnailURL: getFirstString(result.CoverThumbnailURL),
 		}
 	}
 
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"rooms-api/storage"
+)
+
+const (
+	defaultImageMaxBytes    = 10 << 20 // 10 MB
+	defaultMaxImagesPerRoom = 20
+)
+
+// StorageConfig agrupa la configuración del almacenamiento de imágenes
+type StorageConfig struct {
+	// Backend es "local" (filesystem) o "s3" (cualquier servicio compatible con S3)
+	Backend  string
+	LocalDir string
+	// MediaBaseURL es la URL pública de la ruta /media de rooms-api
+	MediaBaseURL string
+	S3           storage.S3Config
+
+	MaxImageBytes    int64
+	MaxImagesPerRoom int
+}
+
+func LoadStorageConfig() StorageConfig {
+	mediaBaseURL := getenvOrDefault("MEDIA_BASE_URL", "http://localhost:8081/media")
+	return StorageConfig{
+		Backend:      getenvOrDefault("STORAGE_BACKEND", "local"),
+		LocalDir:     getenvOrDefault("STORAGE_LOCAL_DIR", "./data/media"),
+		MediaBaseURL: mediaBaseURL,
+		S3: storage.S3Config{
+			Endpoint:     getenvOrDefault("S3_ENDPOINT", "http://minio:9000"),
+			Region:       getenvOrDefault("S3_REGION", "us-east-1"),
+			Bucket:       getenvOrDefault("S3_BUCKET", "room-images"),
+			AccessKey:    getenvOrDefault("S3_ACCESS_KEY", ""),
+			SecretKey:    getenvOrDefault("S3_SECRET_KEY", ""),
+			PublicURL:    getenvOrDefault("S3_PUBLIC_URL", ""),
+			MediaBaseURL: mediaBaseURL,
+		},
+		MaxImageBytes:    int64(getIntOrDefault("IMAGE_MAX_BYTES", defaultImageMaxBytes)),
+		MaxImagesPerRoom: getIntOrDefault("IMAGE_MAX_PER_ROOM", defaultMaxImagesPerRoom),
+	}
+}
+
+// InitBlobStore crea el BlobStore del backend configurado
+func InitBlobStore(ctx context.Context, cfg StorageConfig) (storage.BlobStore, error) {
+	switch cfg.Backend {
+	case "local":
+		log.Printf("✅ Imágenes en filesystem local: %s", cfg.LocalDir)
+		return storage.NewLocalStore(cfg.LocalDir, cfg.MediaBaseURL)
+	case "s3":
+		log.Printf("✅ Imágenes en S3: %s/%s", cfg.S3.Endpoint, cfg.S3.Bucket)
+		return storage.NewS3Store(ctx, cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+func getIntOrDefault(key string, def int) int {
+	raw := getenvOrDefault(key, "")
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		log.Printf("⚠️  Valor inválido para %s (%q), usando %d", key, raw, def)
+		return def
+	}
+	return v
+}
@@ -0,0 +1,206 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"rooms-api/domain"
+	"rooms-api/events"
+	"rooms-api/services"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	reservationsExchange = "reservations"
+	consumerTag          = "rooms-api-reservations-consumer"
+
+	minReconnectDelay = 1 * time.Second
+	maxReconnectDelay = 30 * time.Second
+	retryDelay        = 2 * time.Second
+)
+
+var reservationRoutingKeys = []string{
+	domain.ReservationEventCreated,
+	domain.ReservationEventConfirmed,
+	domain.ReservationEventCanceled,
+	domain.ReservationEventExpired,
+	domain.ReservationEventRelocated,
+	domain.ReservationEventCheckedIn,
+	domain.ReservationEventCheckedOut,
+	domain.ReservationEventNoShow,
+	domain.ReservationEventCompleted,
+	domain.ReservationEventModified,
+}
+
+// ReservationsConsumer mantiene el estado operativo de las habitaciones
+// sincronizado con los eventos de reservations-api y registra la ocupación
+// que usan los precios dinámicos
+type ReservationsConsumer struct {
+	url       string
+	queueName string
+	service   *services.RoomService
+	pricing   *services.DynamicPricingService
+}
+
+func NewReservationsConsumer(url, queueName string, service *services.RoomService, pricing *services.DynamicPricingService) *ReservationsConsumer {
+	return &ReservationsConsumer{
+		url:       url,
+		queueName: queueName,
+		service:   service,
+		pricing:   pricing,
+	}
+}
+
+// Start consume hasta que se cancele el contexto y se reconecta con
+// back-off si el broker no está disponible o cierra la conexión
+func (c *ReservationsConsumer) Start(ctx context.Context) {
+	wait := minReconnectDelay
+	for {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			log.Println("Consumer de reservas detenido")
+			return
+		}
+		if err != nil {
+			log.Printf("⚠️  Consumer de reservas desconectado: %v. Reintentando en %s...", err, wait)
+			wait *= 2
+			if wait > maxReconnectDelay {
+				wait = maxReconnectDelay
+			}
+		} else {
+			wait = minReconnectDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (c *ReservationsConsumer) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return fmt.Errorf("error conectando a RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("error abriendo channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := c.setup(ch); err != nil {
+		return err
+	}
+
+	// Un mensaje a la vez para aplicar los cambios de estado en orden
+	if err := ch.Qos(1, 0, false); err != nil {
+		return fmt.Errorf("error configurando QoS: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		c.queueName, // queue
+		consumerTag, // consumer tag
+		false,       // auto-ack
+		false,       // exclusive
+		false,       // no-local
+		false,       // no-wait
+		nil,         // args
+	)
+	if err != nil {
+		return fmt.Errorf("error iniciando consumo: %w", err)
+	}
+
+	log.Printf("✅ Consumer de reservas escuchando en %s", c.queueName)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return fmt.Errorf("canal de mensajes cerrado")
+			}
+			c.handleMessage(ctx, msg)
+		}
+	}
+}
+
+func (c *ReservationsConsumer) setup(ch *amqp.Channel) error {
+	err := ch.ExchangeDeclare(
+		reservationsExchange,
+		"topic",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error declarando exchange: %w", err)
+	}
+
+	queue, err := ch.QueueDeclare(
+		c.queueName,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error declarando queue: %w", err)
+	}
+
+	for _, routingKey := range reservationRoutingKeys {
+		if err := ch.QueueBind(queue.Name, routingKey, reservationsExchange, false, nil); err != nil {
+			return fmt.Errorf("error bindeando %s: %w", routingKey, err)
+		}
+	}
+	return nil
+}
+
+func (c *ReservationsConsumer) handleMessage(ctx context.Context, msg amqp.Delivery) {
+	envelope, err := events.DecodeCloudEvent(msg.Body, domain.ReservationEventSchemaV1)
+	if err != nil {
+		log.Printf("⚠️  Evento de reserva inválido: %v", err)
+		msg.Nack(false, false) // No requeue si el envelope es inválido
+		return
+	}
+
+	var event domain.ReservationEvent
+	if err := json.Unmarshal(envelope.Data, &event); err != nil {
+		log.Printf("⚠️  Evento de reserva inválido: %v", err)
+		msg.Nack(false, false) // No requeue si el JSON es inválido
+		return
+	}
+
+	// Ambos pasos son idempotentes, así que se reintenta el mensaje completo
+	if err := c.service.SyncStatusFromReservation(ctx, event); err != nil {
+		log.Printf("⚠️  Error sincronizando habitación %d (%s): %v", event.RoomID, event.EventType, err)
+		c.retry(ctx, msg)
+		return
+	}
+	if err := c.pricing.RecordReservation(ctx, event); err != nil {
+		log.Printf("⚠️  Error registrando ocupación de la reserva %s (%s): %v", event.ReservationID, event.EventType, err)
+		c.retry(ctx, msg)
+		return
+	}
+
+	msg.Ack(false)
+}
+
+// retry espera un poco y devuelve el mensaje a la cola
+func (c *ReservationsConsumer) retry(ctx context.Context, msg amqp.Delivery) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(retryDelay):
+	}
+	msg.Nack(false, true)
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"net/http"
+	"rooms-api/domain"
+	"rooms-api/services"
+	"rooms-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AmenityController struct {
+	amenityService *services.AmenityService
+}
+
+func NewAmenityController(amenityService *services.AmenityService) *AmenityController {
+	return &AmenityController{
+		amenityService: amenityService,
+	}
+}
+
+// ListAmenities godoc
+// @Summary List amenities
+// @Description Get the amenities catalog, optionally filtered by category
+// @Tags amenities
+// @Produce json
+// @Param category query string false "Category"
+// @Success 200 {array} domain.Amenity
+// @Router /amenities [get]
+func (c *AmenityController) ListAmenities(ctx *gin.Context) {
+	amenities, err := c.amenityService.List(ctx.Request.Context(), ctx.Query("category"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, amenities)
+}
+
+// CreateAmenity godoc
+// @Summary Create an amenity
+// @Tags amenities
+// @Accept json
+// @Produce json
+// @Param amenity body domain.CreateAmenityRequest true "Amenity data"
+// @Success 201 {object} domain.Amenity
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /admin/amenities [post]
+func (c *AmenityController) CreateAmenity(ctx *gin.Context) {
+	var req domain.CreateAmenityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	amenity, err := c.amenityService.Create(ctx.Request.Context(), req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, amenity)
+}
+
+// UpdateAmenity godoc
+// @Summary Update an amenity
+// @Description Update name, icon or category; the code cannot change
+// @Tags amenities
+// @Accept json
+// @Produce json
+// @Param id path string true "Amenity ID"
+// @Param amenity body domain.UpdateAmenityRequest true "Amenity update data"
+// @Success 200 {object} domain.Amenity
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /admin/amenities/{id} [put]
+func (c *AmenityController) UpdateAmenity(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateAmenityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	amenity, err := c.amenityService.Update(ctx.Request.Context(), id, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, amenity)
+}
+
+// DeleteAmenity godoc
+// @Summary Delete an amenity
+// @Description Only amenities not assigned to any room can be deleted
+// @Tags amenities
+// @Param id path string true "Amenity ID"
+// @Success 204 "Amenity deleted successfully"
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /admin/amenities/{id} [delete]
+func (c *AmenityController) DeleteAmenity(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.amenityService.Delete(ctx.Request.Context(), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
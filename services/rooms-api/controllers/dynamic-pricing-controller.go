@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"net/http"
+	"rooms-api/domain"
+	"rooms-api/services"
+	"rooms-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DynamicPricingController struct {
+	pricingService *services.DynamicPricingService
+}
+
+func NewDynamicPricingController(pricingService *services.DynamicPricingService) *DynamicPricingController {
+	return &DynamicPricingController{
+		pricingService: pricingService,
+	}
+}
+
+// ListPolicies godoc
+// @Summary List dynamic pricing policies
+// @Tags dynamic-pricing
+// @Produce json
+// @Success 200 {array} domain.DynamicPricingPolicy
+// @Router /admin/dynamic-pricing/policies [get]
+func (c *DynamicPricingController) ListPolicies(ctx *gin.Context) {
+	policies, err := c.pricingService.List(ctx.Request.Context())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policies)
+}
+
+// GetPolicy godoc
+// @Summary Get a dynamic pricing policy
+// @Tags dynamic-pricing
+// @Produce json
+// @Param id path string true "Policy ID"
+// @Success 200 {object} domain.DynamicPricingPolicy
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /admin/dynamic-pricing/policies/{id} [get]
+func (c *DynamicPricingController) GetPolicy(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	policy, err := c.pricingService.Get(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// CreatePolicy godoc
+// @Summary Create a dynamic pricing policy
+// @Description One policy per room type; the room type needs a rate plan
+// @Tags dynamic-pricing
+// @Accept json
+// @Produce json
+// @Param policy body domain.DynamicPricingPolicyRequest true "Policy"
+// @Success 201 {object} domain.DynamicPricingPolicy
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /admin/dynamic-pricing/policies [post]
+func (c *DynamicPricingController) CreatePolicy(ctx *gin.Context) {
+	var req domain.DynamicPricingPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	policy, err := c.pricingService.Create(ctx.Request.Context(), req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, policy)
+}
+
+// UpdatePolicy godoc
+// @Summary Replace a dynamic pricing policy
+// @Description Replaces the whole policy, including its tiers, and recomputes the prices
+// @Tags dynamic-pricing
+// @Accept json
+// @Produce json
+// @Param id path string true "Policy ID"
+// @Param policy body domain.DynamicPricingPolicyRequest true "Policy"
+// @Success 200 {object} domain.DynamicPricingPolicy
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /admin/dynamic-pricing/policies/{id} [put]
+func (c *DynamicPricingController) UpdatePolicy(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req domain.DynamicPricingPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	policy, err := c.pricingService.Update(ctx.Request.Context(), id, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicy godoc
+// @Summary Delete a dynamic pricing policy
+// @Description Nights go back to the rate plan prices
+// @Tags dynamic-pricing
+// @Param id path string true "Policy ID"
+// @Success 204 "Policy deleted successfully"
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /admin/dynamic-pricing/policies/{id} [delete]
+func (c *DynamicPricingController) DeletePolicy(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.pricingService.Delete(ctx.Request.Context(), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Simulate godoc
+// @Summary Simulate dynamic prices
+// @Description Prices of each night for a given occupancy and/or policy, without saving them
+// @Tags dynamic-pricing
+// @Accept json
+// @Produce json
+// @Param simulation body domain.PricingSimulationRequest true "Simulation"
+// @Success 200 {object} domain.PricingSimulationResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /admin/dynamic-pricing/simulate [post]
+func (c *DynamicPricingController) Simulate(ctx *gin.Context) {
+	var req domain.PricingSimulationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	simulation, err := c.pricingService.Simulate(ctx.Request.Context(), req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, simulation)
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"net/http"
+	"rooms-api/domain"
+	"rooms-api/services"
+	"rooms-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RatePlanController struct {
+	ratePlanService *services.RatePlanService
+}
+
+func NewRatePlanController(ratePlanService *services.RatePlanService) *RatePlanController {
+	return &RatePlanController{
+		ratePlanService: ratePlanService,
+	}
+}
+
+// GetRoomRates godoc
+// @Summary Get nightly rates of a room
+// @Description Price of every night between from (check-in) and to (check-out, excluded), with minimum stay and length-of-stay discount
+// @Tags rates
+// @Produce json
+// @Param id path string true "Room ID"
+// @Param from query string true "Check-in date (YYYY-MM-DD)"
+// @Param to query string true "Check-out date (YYYY-MM-DD)"
+// @Success 200 {object} domain.RoomRatesResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /rooms/{id}/rates [get]
+func (c *RatePlanController) GetRoomRates(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	rates, err := c.ratePlanService.RoomRates(ctx.Request.Context(), id, ctx.Query("from"), ctx.Query("to"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rates)
+}
+
+// ListRatePlans godoc
+// @Summary List rate plans
+// @Tags rates
+// @Produce json
+// @Success 200 {array} domain.RatePlan
+// @Router /admin/rate-plans [get]
+func (c *RatePlanController) ListRatePlans(ctx *gin.Context) {
+	plans, err := c.ratePlanService.List(ctx.Request.Context())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plans)
+}
+
+// GetRatePlan godoc
+// @Summary Get a rate plan
+// @Tags rates
+// @Produce json
+// @Param id path string true "Rate plan ID"
+// @Success 200 {object} domain.RatePlan
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /admin/rate-plans/{id} [get]
+func (c *RatePlanController) GetRatePlan(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	plan, err := c.ratePlanService.Get(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+// CreateRatePlan godoc
+// @Summary Create a rate plan
+// @Description One plan per room type, with its seasons and length-of-stay discounts
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param plan body domain.RatePlanRequest true "Rate plan"
+// @Success 201 {object} domain.RatePlan
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /admin/rate-plans [post]
+func (c *RatePlanController) CreateRatePlan(ctx *gin.Context) {
+	var req domain.RatePlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	plan, err := c.ratePlanService.Create(ctx.Request.Context(), req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, plan)
+}
+
+// UpdateRatePlan godoc
+// @Summary Replace a rate plan
+// @Description Replaces the whole plan, including seasons and discounts
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param id path string true "Rate plan ID"
+// @Param plan body domain.RatePlanRequest true "Rate plan"
+// @Success 200 {object} domain.RatePlan
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /admin/rate-plans/{id} [put]
+func (c *RatePlanController) UpdateRatePlan(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req domain.RatePlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	plan, err := c.ratePlanService.Update(ctx.Request.Context(), id, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+// DeleteRatePlan godoc
+// @Summary Delete a rate plan
+// @Description Rooms of that type go back to their own price
+// @Tags rates
+// @Param id path string true "Rate plan ID"
+// @Success 204 "Rate plan deleted successfully"
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /admin/rate-plans/{id} [delete]
+func (c *RatePlanController) DeleteRatePlan(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.ratePlanService.Delete(ctx.Request.Context(), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
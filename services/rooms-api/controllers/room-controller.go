@@ -155,6 +155,7 @@ func (c *RoomController) GetRoomByNumber(ctx *gin.Context) {
 // @Param has_ac query bool false "Has AC"
 // @Param has_tv query bool false "Has TV"
 // @Param has_minibar query bool false "Has minibar"
+// @Param amenities query []string false "Amenity codes (all must match)" collectionFormat(multi)
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Items per page (default: 10, max: 100)"
 // @Success 200 {object} domain.RoomListResponse
@@ -206,6 +207,8 @@ func (c *RoomController) GetRooms(ctx *gin.Context) {
 			filter.HasMinibar = &hasMinibar
 		}
 	}
+	// amenities se puede repetir o separar por comas: ?amenities=wifi,jacuzzi
+	filter.Amenities = ctx.QueryArray("amenities")
 
 	// Parsear parámetros de paginación
 	page := 1
@@ -511,6 +514,8 @@ func (c *RoomController) GetRoomsViaSearch(ctx *gin.Context) {
 			filter.HasMinibar = &hasMinibar
 		}
 	}
+	// amenities se puede repetir o separar por comas: ?amenities=wifi,jacuzzi
+	filter.Amenities = ctx.QueryArray("amenities")
 	// Parsear parámetros de paginación
 	page := 1
 	if pageStr := ctx.Query("page"); pageStr != "" {
@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"rooms-api/domain"
+	"rooms-api/services"
+	"rooms-api/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomImageController struct {
+	imageService *services.RoomImageService
+}
+
+func NewRoomImageController(imageService *services.RoomImageService) *RoomImageController {
+	return &RoomImageController{
+		imageService: imageService,
+	}
+}
+
+// ListImages godoc
+// @Summary List room images
+// @Description Get the room gallery in display order
+// @Tags images
+// @Produce json
+// @Param id path string true "Room ID"
+// @Success 200 {array} domain.RoomImageResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /rooms/{id}/images [get]
+func (c *RoomImageController) ListImages(ctx *gin.Context) {
+	roomID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	images, err := c.imageService.List(ctx.Request.Context(), roomID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, images)
+}
+
+// UploadImage godoc
+// @Summary Upload a room image
+// @Description Multipart upload (field "image"); JPEG, PNG, GIF or WebP. Use cover=true to make it the cover image
+// @Tags images
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Room ID"
+// @Param image formData file true "Image file"
+// @Param cover formData bool false "Set as cover"
+// @Success 201 {object} domain.RoomImageResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse
+// @Router /admin/rooms/{id}/images [post]
+func (c *RoomImageController) UploadImage(ctx *gin.Context) {
+	roomID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	// Margen para el resto del formulario multipart
+	maxBytes := c.imageService.MaxBytes()
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBytes+1<<20)
+
+	header, err := ctx.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondError(ctx, utils.ErrImageTooLarge)
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: "El archivo de la imagen es obligatorio (campo image)",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if header.Size > maxBytes {
+		respondError(ctx, utils.ErrImageTooLarge)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		respondError(ctx, utils.ErrInvalidImage)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		respondError(ctx, utils.ErrInvalidImage)
+		return
+	}
+
+	makeCover, _ := strconv.ParseBool(ctx.PostForm("cover"))
+	image, err := c.imageService.Upload(ctx.Request.Context(), roomID, data, makeCover)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, image)
+}
+
+// DeleteImage godoc
+// @Summary Delete a room image
+// @Tags images
+// @Param id path string true "Room ID"
+// @Param imageId path string true "Image ID"
+// @Success 204 "Image deleted successfully"
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /admin/rooms/{id}/images/{imageId} [delete]
+func (c *RoomImageController) DeleteImage(ctx *gin.Context) {
+	roomID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	imageID, ok := parseIDParam(ctx, "imageId")
+	if !ok {
+		return
+	}
+
+	if err := c.imageService.Delete(ctx.Request.Context(), roomID, imageID); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ReorderImages godoc
+// @Summary Reorder room images
+// @Description Receives every image ID of the room in the new order
+// @Tags images
+// @Accept json
+// @Produce json
+// @Param id path string true "Room ID"
+// @Param order body domain.ReorderRoomImagesRequest true "Image IDs in order"
+// @Success 200 {array} domain.RoomImageResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /admin/rooms/{id}/images/order [put]
+func (c *RoomImageController) ReorderImages(ctx *gin.Context) {
+	roomID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req domain.ReorderRoomImagesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "Datos de solicitud inválidos",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	images, err := c.imageService.Reorder(ctx.Request.Context(), roomID, req.ImageIDs)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, images)
+}
+
+// SetCoverImage godoc
+// @Summary Set the cover image
+// @Tags images
+// @Produce json
+// @Param id path string true "Room ID"
+// @Param imageId path string true "Image ID"
+// @Success 200 {array} domain.RoomImageResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /admin/rooms/{id}/images/{imageId}/cover [put]
+func (c *RoomImageController) SetCoverImage(ctx *gin.Context) {
+	roomID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	imageID, ok := parseIDParam(ctx, "imageId")
+	if !ok {
+		return
+	}
+
+	images, err := c.imageService.SetCover(ctx.Request.Context(), roomID, imageID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, images)
+}
+
+// ServeMedia sirve los archivos de imágenes del BlobStore. Cada imagen
+// tiene una key nueva, así que se cachean como inmutables.
+func (c *RoomImageController) ServeMedia(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	body, contentType, err := c.imageService.OpenMedia(ctx.Request.Context(), key)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.DataFromReader(http.StatusOK, -1, contentType, body, nil)
+}
+
+// parseIDParam lee un ID numérico de la ruta y responde 400 si es inválido
+func parseIDParam(ctx *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 0)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{
+			Error:   "ID inválido",
+			Message: "El parámetro " + name + " debe ser un número",
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondError(ctx *gin.Context, err error) {
+	statusCode := utils.GetHTTPStatus(err)
+	ctx.JSON(statusCode, utils.ErrorResponse{
+		Error:   err.Error(),
+		Message: err.Error(),
+		Code:    statusCode,
+	})
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Códigos de las cuatro amenities que antes eran columnas booleanas. Las
+// columnas has_* se mantienen sincronizadas con el catálogo para no romper
+// a los clientes que todavía las usan.
+const (
+	AmenityWifi    = "wifi"
+	AmenityAC      = "ac"
+	AmenityTV      = "tv"
+	AmenityMinibar = "minibar"
+)
+
+// LegacyAmenities se crean al iniciar si no existen; no se pueden borrar
+// ni cambiar de código
+var LegacyAmenities = []Amenity{
+	{Code: AmenityWifi, Name: "WiFi", Icon: "wifi", Category: "conectividad"},
+	{Code: AmenityAC, Name: "Aire acondicionado", Icon: "snowflake", Category: "confort"},
+	{Code: AmenityTV, Name: "TV", Icon: "tv", Category: "entretenimiento"},
+	{Code: AmenityMinibar, Name: "Minibar", Icon: "wine", Category: "gastronomia"},
+}
+
+// Amenity es un ítem del catálogo de comodidades
+type Amenity struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string    `gorm:"uniqueIndex;size:50;not null" json:"code"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	Icon      string    `gorm:"size:50" json:"icon"`
+	Category  string    `gorm:"size:50;index" json:"category"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// IsLegacyAmenity indica si el código corresponde a una de las columnas has_*
+func IsLegacyAmenity(code string) bool {
+	for _, amenity := range LegacyAmenities {
+		if amenity.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateAmenityRequest struct {
+	Code     string `json:"code" binding:"required,max=50"`
+	Name     string `json:"name" binding:"required,max=100"`
+	Icon     string `json:"icon" binding:"max=50"`
+	Category string `json:"category" binding:"max=50"`
+}
+
+type UpdateAmenityRequest struct {
+	Name     *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	Icon     *string `json:"icon,omitempty" binding:"omitempty,max=50"`
+	Category *string `json:"category,omitempty" binding:"omitempty,max=50"`
+}
+
+// AmenityResponse es la amenity tal como aparece en una habitación
+type AmenityResponse struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Category string `json:"category"`
+}
@@ -13,6 +13,8 @@ type CreateRoomRequest struct {
 	HasAC       bool     `json:"has_ac"`
 	HasTV       bool     `json:"has_tv"`
 	HasMinibar  bool     `json:"has_minibar"`
+	// Amenities son códigos del catálogo; los has_* se suman a esta lista
+	Amenities []string `json:"amenities"`
 }
 
 type UpdateRoomRequest struct {
@@ -27,6 +29,8 @@ type UpdateRoomRequest struct {
 	HasAC       *bool       `json:"has_ac,omitempty"`
 	HasTV       *bool       `json:"has_tv,omitempty"`
 	HasMinibar  *bool       `json:"has_minibar,omitempty"`
+	// Amenities reemplaza la lista completa; los has_* se aplican después
+	Amenities *[]string `json:"amenities,omitempty"`
 }
 
 type RoomResponse struct {
@@ -42,8 +46,16 @@ type RoomResponse struct {
 	HasAC       bool       `json:"has_ac"`
 	HasTV       bool       `json:"has_tv"`
 	HasMinibar  bool       `json:"has_minibar"`
+	Version     uint64     `json:"version"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Amenities []AmenityResponse `json:"amenities"`
+
+	// Galería ordenada; la portada también se expone aparte para los listados
+	Images            []RoomImageResponse `json:"images"`
+	CoverImageURL     string              `json:"cover_image_url,omitempty"`
+	CoverThumbnailURL string              `json:"cover_thumbnail_url,omitempty"`
 }
 
 type RoomListResponse struct {
@@ -63,4 +75,6 @@ type RoomFilter struct {
 	HasAC      *bool       `json:"has_ac,omitempty"`
 	HasTV      *bool       `json:"has_tv,omitempty"`
 	HasMinibar *bool       `json:"has_minibar,omitempty"`
+	// Amenities filtra las habitaciones que tienen todas las amenities indicadas
+	Amenities []string `json:"amenities,omitempty"`
 }
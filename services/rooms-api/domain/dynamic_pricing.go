@@ -0,0 +1,123 @@
+package domain
+
+import "time"
+
+// OccupancyStay es una reserva vista desde rooms-api: qué tipo de
+// habitación ocupa y qué noches. Se guarda por ReservationID para que
+// reprocesar un evento no cuente dos veces la misma reserva.
+type OccupancyStay struct {
+	ID            uint     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReservationID string   `gorm:"uniqueIndex;size:64;not null" json:"reservation_id"`
+	RoomID        uint     `gorm:"not null" json:"room_id"`
+	RoomType      RoomType `gorm:"type:varchar(20);not null;index:idx_occupancy_type_dates" json:"room_type"`
+	StartDate     string   `gorm:"type:char(10);not null;index:idx_occupancy_type_dates" json:"start_date"`
+	EndDate       string   `gorm:"type:char(10);not null" json:"end_date"`
+	// Counted es falso para holds sin confirmar y reservas canceladas,
+	// vencidas o no-show
+	Counted   bool      `gorm:"not null" json:"counted"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// DynamicPricingPolicy define cómo se ajusta la tarifa de un tipo de
+// habitación según la ocupación y la anticipación. El precio calculado se
+// guarda como RateOverride del plan de tarifas del tipo.
+type DynamicPricingPolicy struct {
+	ID       uint     `gorm:"primaryKey;autoIncrement" json:"id"`
+	RoomType RoomType `gorm:"type:varchar(20);uniqueIndex;not null" json:"room_type"`
+	Enabled  bool     `gorm:"not null;default:true" json:"enabled"`
+	// HorizonDays es cuántas noches hacia adelante se recalculan
+	HorizonDays int `gorm:"not null;default:90" json:"horizon_days"`
+	// FloorRate y CeilingRate acotan el precio final; 0 es sin límite
+	FloorRate   float64   `gorm:"type:decimal(10,2);not null;default:0" json:"floor_rate"`
+	CeilingRate float64   `gorm:"type:decimal(10,2);not null;default:0" json:"ceiling_rate"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// OccupancyTiers se carga ordenada por MinOccupancy
+	OccupancyTiers []OccupancyTier `gorm:"foreignKey:PolicyID" json:"occupancy_tiers"`
+	// LeadTimeTiers se carga ordenada por MinDaysAhead; no se superponen
+	LeadTimeTiers []LeadTimeTier `gorm:"foreignKey:PolicyID" json:"lead_time_tiers"`
+}
+
+// OccupancyTier multiplica la tarifa cuando la ocupación de la noche llega
+// a MinOccupancy (porcentaje); se aplica el escalón más alto alcanzado
+type OccupancyTier struct {
+	ID           uint    `gorm:"primaryKey;autoIncrement" json:"id"`
+	PolicyID     uint    `gorm:"not null;index" json:"-"`
+	MinOccupancy float64 `gorm:"type:decimal(5,2);not null" json:"min_occupancy"`
+	Multiplier   float64 `gorm:"type:decimal(6,3);not null" json:"multiplier"`
+}
+
+// LeadTimeTier multiplica la tarifa de las noches que están entre
+// MinDaysAhead y MaxDaysAhead días de hoy, ambos incluidos. Sin
+// MaxDaysAhead no tiene límite superior.
+type LeadTimeTier struct {
+	ID           uint    `gorm:"primaryKey;autoIncrement" json:"id"`
+	PolicyID     uint    `gorm:"not null;index" json:"-"`
+	MinDaysAhead int     `gorm:"not null" json:"min_days_ahead"`
+	MaxDaysAhead *int    `json:"max_days_ahead,omitempty"`
+	Multiplier   float64 `gorm:"type:decimal(6,3);not null" json:"multiplier"`
+}
+
+// Matches indica si una noche a daysAhead días de hoy cae en el escalón
+func (t LeadTimeTier) Matches(daysAhead int) bool {
+	return daysAhead >= t.MinDaysAhead && (t.MaxDaysAhead == nil || daysAhead <= *t.MaxDaysAhead)
+}
+
+// DynamicPricingPolicyRequest crea o reemplaza una política completa
+type DynamicPricingPolicyRequest struct {
+	RoomType RoomType `json:"room_type" binding:"required,oneof=single double suite deluxe standard"`
+	PricingRulesRequest
+}
+
+// PricingRulesRequest son los parámetros de una política, sin el tipo de
+// habitación
+type PricingRulesRequest struct {
+	Enabled        *bool                  `json:"enabled"`
+	HorizonDays    int                    `json:"horizon_days" binding:"omitempty,min=1,max=366"`
+	FloorRate      float64                `json:"floor_rate" binding:"min=0"`
+	CeilingRate    float64                `json:"ceiling_rate" binding:"min=0"`
+	OccupancyTiers []OccupancyTierRequest `json:"occupancy_tiers" binding:"dive"`
+	LeadTimeTiers  []LeadTimeTierRequest  `json:"lead_time_tiers" binding:"dive"`
+}
+
+type OccupancyTierRequest struct {
+	MinOccupancy float64 `json:"min_occupancy" binding:"min=0,max=100"`
+	Multiplier   float64 `json:"multiplier" binding:"required,gt=0,lte=10"`
+}
+
+type LeadTimeTierRequest struct {
+	MinDaysAhead int     `json:"min_days_ahead" binding:"min=0"`
+	MaxDaysAhead *int    `json:"max_days_ahead,omitempty" binding:"omitempty,min=0"`
+	Multiplier   float64 `json:"multiplier" binding:"required,gt=0,lte=10"`
+}
+
+// PricingSimulationRequest calcula precios sin guardarlos. Occupancy
+// (porcentaje) reemplaza la ocupación real de todas las noches y Policy la
+// política guardada del tipo.
+type PricingSimulationRequest struct {
+	RoomType  RoomType             `json:"room_type" binding:"required,oneof=single double suite deluxe standard"`
+	From      string               `json:"from" binding:"required"`
+	To        string               `json:"to" binding:"required"`
+	Occupancy *float64             `json:"occupancy,omitempty" binding:"omitempty,min=0,max=100"`
+	Policy    *PricingRulesRequest `json:"policy,omitempty"`
+}
+
+// SimulatedNight detalla cómo se llega al precio de una noche
+type SimulatedNight struct {
+	Date                string  `json:"date"`
+	BaseRate            float64 `json:"base_rate"`
+	Occupancy           float64 `json:"occupancy"`
+	OccupancyMultiplier float64 `json:"occupancy_multiplier"`
+	DaysAhead           int     `json:"days_ahead"`
+	LeadTimeMultiplier  float64 `json:"lead_time_multiplier"`
+	Rate                float64 `json:"rate"`
+	// Bound es "floor" o "ceiling" si el precio quedó en un límite
+	Bound string `json:"bound,omitempty"`
+}
+
+type PricingSimulationResponse struct {
+	RoomType RoomType         `json:"room_type"`
+	Capacity int64            `json:"capacity"`
+	Nights   []SimulatedNight `json:"nights"`
+}
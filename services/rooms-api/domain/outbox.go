@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+)
+
+// OutboxMessage es un evento pendiente de publicar. Se escribe en la misma
+// transacción que el cambio de la habitación y lo publica el relay.
+type OutboxMessage struct {
+	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
+	// EventID es el id del envelope CloudEvents, se publica como MessageId
+	EventID    string       `gorm:"size:36;not null" json:"event_id"`
+	RoutingKey string       `gorm:"size:100;not null" json:"routing_key"`
+	Payload    []byte       `gorm:"type:mediumblob;not null" json:"-"`
+	Status     OutboxStatus `gorm:"type:varchar(20);not null;index:idx_outbox_pending,priority:1" json:"status"`
+	Attempts   int          `gorm:"not null;default:0" json:"attempts"`
+	LastError  *string      `gorm:"type:text" json:"last_error,omitempty"`
+	// NextAttemptAt sirve tanto para el back-off como de lease mientras el relay lo publica
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_pending,priority:2" json:"next_attempt_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	SentAt        *time.Time `gorm:"index" json:"sent_at,omitempty"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox_messages"
+}
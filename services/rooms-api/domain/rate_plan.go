@@ -0,0 +1,121 @@
+package domain
+
+import "time"
+
+// DateLayout es el formato de las fechas de tarifas (noches, no instantes)
+const DateLayout = "2006-01-02"
+
+// RatePlan es la tarifa de un tipo de habitación. Sin plan, cada noche
+// cuesta el Price de la habitación.
+type RatePlan struct {
+	ID       uint     `gorm:"primaryKey;autoIncrement" json:"id"`
+	RoomType RoomType `gorm:"type:varchar(20);uniqueIndex;not null" json:"room_type"`
+	Name     string   `gorm:"size:100" json:"name"`
+	BaseRate float64  `gorm:"type:decimal(10,2);not null" json:"base_rate"`
+	// WeekendAdjustment es el porcentaje que se suma (o resta, si es
+	// negativo) a las noches de viernes y sábado
+	WeekendAdjustment float64   `gorm:"type:decimal(5,2);not null;default:0" json:"weekend_adjustment"`
+	MinStay           int       `gorm:"not null;default:1" json:"min_stay"`
+	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Seasons se carga ordenada por StartDate; no se superponen
+	Seasons []RateSeason `gorm:"foreignKey:RatePlanID" json:"seasons"`
+	// StayDiscounts se carga ordenada por MinNights
+	StayDiscounts []StayDiscount `gorm:"foreignKey:RatePlanID" json:"stay_discounts"`
+}
+
+// RateSeason reemplaza la tarifa base entre StartDate y EndDate, ambas
+// noches incluidas. Las fechas se guardan como YYYY-MM-DD para que no
+// dependan de la zona horaria de la conexión.
+type RateSeason struct {
+	ID         uint    `gorm:"primaryKey;autoIncrement" json:"id"`
+	RatePlanID uint    `gorm:"not null;index" json:"-"`
+	Name       string  `gorm:"size:100;not null" json:"name"`
+	StartDate  string  `gorm:"type:char(10);not null" json:"start_date"`
+	EndDate    string  `gorm:"type:char(10);not null" json:"end_date"`
+	Rate       float64 `gorm:"type:decimal(10,2);not null" json:"rate"`
+	// MinStay, si está, reemplaza el del plan para las noches de la temporada;
+	// en una estadía rige el más exigente de sus noches
+	MinStay *int `json:"min_stay,omitempty"`
+}
+
+// Contains indica si la noche date cae dentro de la temporada
+func (s RateSeason) Contains(date string) bool {
+	return date >= s.StartDate && date <= s.EndDate
+}
+
+// StayDiscount descuenta Percent del total cuando la estadía tiene al
+// menos MinNights noches; se aplica solo el de mayor MinNights alcanzado
+type StayDiscount struct {
+	ID         uint    `gorm:"primaryKey;autoIncrement" json:"id"`
+	RatePlanID uint    `gorm:"not null;index" json:"-"`
+	MinNights  int     `gorm:"not null" json:"min_nights"`
+	Percent    float64 `gorm:"type:decimal(5,2);not null" json:"percent"`
+}
+
+// RatePlanRequest crea o reemplaza un plan completo, con sus temporadas y
+// descuentos
+type RatePlanRequest struct {
+	RoomType          RoomType              `json:"room_type" binding:"required,oneof=single double suite deluxe standard"`
+	Name              string                `json:"name" binding:"max=100"`
+	BaseRate          float64               `json:"base_rate" binding:"required,gt=0"`
+	WeekendAdjustment float64               `json:"weekend_adjustment" binding:"gt=-100,lte=300"`
+	MinStay           int                   `json:"min_stay" binding:"omitempty,min=1,max=365"`
+	Seasons           []RateSeasonRequest   `json:"seasons" binding:"dive"`
+	StayDiscounts     []StayDiscountRequest `json:"stay_discounts" binding:"dive"`
+}
+
+type RateSeasonRequest struct {
+	Name      string  `json:"name" binding:"required,max=100"`
+	StartDate string  `json:"start_date" binding:"required"`
+	EndDate   string  `json:"end_date" binding:"required"`
+	Rate      float64 `json:"rate" binding:"required,gt=0"`
+	MinStay   *int    `json:"min_stay,omitempty" binding:"omitempty,min=1,max=365"`
+}
+
+type StayDiscountRequest struct {
+	MinNights int     `json:"min_nights" binding:"required,min=2"`
+	Percent   float64 `json:"percent" binding:"required,gt=0,lt=100"`
+}
+
+// RateOverride es el precio de una noche calculado por la política de
+// precios dinámicos; reemplaza a temporada, tarifa base y ajuste de fin de
+// semana, que ya están incluidos en el cálculo
+type RateOverride struct {
+	ID         uint    `gorm:"primaryKey;autoIncrement" json:"-"`
+	RatePlanID uint    `gorm:"not null;uniqueIndex:idx_rate_override_night" json:"rate_plan_id"`
+	Date       string  `gorm:"type:char(10);not null;uniqueIndex:idx_rate_override_night" json:"date"`
+	Rate       float64 `gorm:"type:decimal(10,2);not null" json:"rate"`
+	// Occupancy es el porcentaje de ocupación con el que se calculó
+	Occupancy float64   `gorm:"type:decimal(5,2);not null" json:"occupancy"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// NightlyRate es el precio de una noche y de dónde sale
+type NightlyRate struct {
+	Date    string  `json:"date"`
+	Rate    float64 `json:"rate"`
+	Weekend bool    `json:"weekend"`
+	// Season es el nombre de la temporada aplicada, vacío si es la tarifa base
+	Season string `json:"season,omitempty"`
+	// Dynamic indica que el precio viene de la política de precios dinámicos
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+// RoomRatesResponse son los precios de las noches entre From (llegada) y
+// To (salida, no incluida), con el descuento por duración de la estadía
+type RoomRatesResponse struct {
+	RoomID          uint          `json:"room_id"`
+	RoomType        RoomType      `json:"room_type"`
+	From            string        `json:"from"`
+	To              string        `json:"to"`
+	RatePlanID      *uint         `json:"rate_plan_id,omitempty"`
+	Nights          []NightlyRate `json:"nights"`
+	MinStay         int           `json:"min_stay"`
+	MinStayMet      bool          `json:"min_stay_met"`
+	Subtotal        float64       `json:"subtotal"`
+	DiscountPercent float64       `json:"discount_percent"`
+	Discount        float64       `json:"discount"`
+	Total           float64       `json:"total"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+// Eventos que publica reservations-api en el exchange "reservations"
+const (
+	ReservationEventCreated    = "reservation.created"
+	ReservationEventConfirmed  = "reservation.confirmed"
+	ReservationEventCanceled   = "reservation.canceled"
+	ReservationEventExpired    = "reservation.expired"
+	ReservationEventRelocated  = "reservation.relocated"
+	ReservationEventCheckedIn  = "reservation.checked_in"
+	ReservationEventCheckedOut = "reservation.checked_out"
+	ReservationEventNoShow     = "reservation.no_show"
+	ReservationEventCompleted  = "reservation.completed"
+	// Cambio de fechas de una reserva existente (grupos, bloqueos iCal)
+	ReservationEventModified = "reservation.modified"
+)
+
+// ReservationEvent son los campos de los eventos de reservations-api que
+// necesita rooms-api para mantener el estado operativo de las habitaciones
+type ReservationEvent struct {
+	EventType      string `json:"event_type"`
+	ReservationID  string `json:"reservation_id"`
+	RoomID         uint   `json:"room_id"`
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+	Status         string `json:"status"`
+	PreviousRoomID *uint  `json:"previous_room_id,omitempty"`
+}
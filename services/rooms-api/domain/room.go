@@ -41,4 +41,39 @@ type Room struct {
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Version aumenta con cada cambio; los consumers de eventos descartan versiones viejas
+	Version uint64 `gorm:"not null;default:1" json:"version"`
+
+	// Images se carga ordenada por Position
+	Images []RoomImage `gorm:"foreignKey:RoomID" json:"images,omitempty"`
+
+	// Amenities del catálogo; HasWifi, HasAC, HasTV y HasMinibar se derivan de ellas
+	Amenities []Amenity `gorm:"many2many:room_amenities" json:"amenities,omitempty"`
+}
+
+// AmenityCodes devuelve los códigos de las amenities de la habitación
+func (r *Room) AmenityCodes() []string {
+	codes := make([]string, len(r.Amenities))
+	for i, amenity := range r.Amenities {
+		codes[i] = amenity.Code
+	}
+	return codes
+}
+
+// SyncAmenityFlags actualiza las columnas has_* a partir de Amenities
+func (r *Room) SyncAmenityFlags() {
+	r.HasWifi, r.HasAC, r.HasTV, r.HasMinibar = false, false, false, false
+	for _, amenity := range r.Amenities {
+		switch amenity.Code {
+		case AmenityWifi:
+			r.HasWifi = true
+		case AmenityAC:
+			r.HasAC = true
+		case AmenityTV:
+			r.HasTV = true
+		case AmenityMinibar:
+			r.HasMinibar = true
+		}
+	}
 }
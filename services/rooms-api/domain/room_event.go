@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Eventos que publica rooms-api en el exchange "rooms"; la routing key es
+// "room." + el tipo
+const (
+	RoomEventCreated       = "created"
+	RoomEventUpdated       = "updated"
+	RoomEventDeleted       = "deleted"
+	RoomEventStatusChanged = "status_changed"
+)
+
+// RoomEvent es el payload v2 de los eventos de habitaciones. Room es el
+// estado después del cambio (en las bajas, el último estado) y Version
+// permite a los consumers descartar eventos que llegan desordenados. Un
+// update que cambia el estado publica updated y status_changed con la misma
+// versión.
+type RoomEvent struct {
+	EventType string       `json:"event_type"`
+	RoomID    uint         `json:"room_id"`
+	Version   uint64       `json:"version"`
+	Room      RoomResponse `json:"room"`
+	// Solo en status_changed
+	OldStatus RoomStatus `json:"old_status,omitempty"`
+	NewStatus RoomStatus `json:"new_status,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+}
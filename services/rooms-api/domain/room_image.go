@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// RoomImage es una foto de la galería de la habitación. El original se
+// guarda tal cual se subió; WebKey y ThumbnailKey son las versiones
+// redimensionadas en JPEG que usa el frontend.
+type RoomImage struct {
+	ID     uint `gorm:"primaryKey;autoIncrement" json:"id"`
+	RoomID uint `gorm:"not null;index:idx_room_images_room,priority:1" json:"room_id"`
+	// Position ordena la galería (0 es la primera)
+	Position int  `gorm:"not null;index:idx_room_images_room,priority:2" json:"position"`
+	IsCover  bool `gorm:"not null;default:false" json:"is_cover"`
+
+	ContentType  string    `gorm:"type:varchar(50);not null" json:"content_type"`
+	SizeBytes    int64     `gorm:"not null" json:"size_bytes"`
+	Width        int       `gorm:"not null" json:"width"`
+	Height       int       `gorm:"not null" json:"height"`
+	OriginalKey  string    `gorm:"size:255;not null" json:"-"`
+	WebKey       string    `gorm:"size:255;not null" json:"-"`
+	ThumbnailKey string    `gorm:"size:255;not null" json:"-"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type RoomImageResponse struct {
+	ID           uint   `json:"id"`
+	URL          string `json:"url"`
+	WebURL       string `json:"web_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Position     int    `json:"position"`
+	IsCover      bool   `json:"is_cover"`
+}
+
+// ReorderRoomImagesRequest lista todas las imágenes de la habitación en el
+// orden deseado
+type ReorderRoomImagesRequest struct {
+	ImageIDs []uint `json:"image_ids" binding:"required,min=1"`
+}
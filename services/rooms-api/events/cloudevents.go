@@ -0,0 +1,117 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"rooms-api/domain"
+)
+
+// EventSource identifica a rooms-api como origen de sus eventos
+const EventSource = "/rooms-api"
+
+var ErrInvalidCloudEvent = errors.New("invalid cloud event")
+
+// NewCloudEvent envuelve `data` en un envelope CloudEvents con un id UUIDv4
+func NewCloudEvent(eventType, subject, schema string, data interface{}) (domain.CloudEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("error marshaling event data: %w", err)
+	}
+	id, err := newEventID()
+	if err != nil {
+		return domain.CloudEvent{}, err
+	}
+	return domain.CloudEvent{
+		SpecVersion:     domain.CloudEventsSpecVersion,
+		ID:              id,
+		Source:          EventSource,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		DataSchema:      schema,
+		Data:            payload,
+	}, nil
+}
+
+// DecodeCloudEvent valida el envelope, que `dataschema` sea uno de los
+// esquemas aceptados y que `data` tenga los campos requeridos por ese
+// esquema. Los mensajes sin envelope (publicados antes de la migración)
+// se leen como la versión 1 del primer esquema.
+func DecodeCloudEvent(body []byte, schemas ...string) (domain.CloudEvent, error) {
+	var probe struct {
+		SpecVersion *string `json:"specversion"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+	}
+	if probe.SpecVersion == nil {
+		if err := validateData(schemas[0], body); err != nil {
+			return domain.CloudEvent{}, err
+		}
+		return domain.CloudEvent{
+			SpecVersion: domain.CloudEventsSpecVersion,
+			DataSchema:  schemas[0],
+			Data:        body,
+		}, nil
+	}
+
+	var event domain.CloudEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+	}
+	switch {
+	case event.SpecVersion != domain.CloudEventsSpecVersion:
+		return domain.CloudEvent{}, fmt.Errorf("%w: specversion %q no soportada", ErrInvalidCloudEvent, event.SpecVersion)
+	case event.ID == "" || event.Source == "" || event.Type == "":
+		return domain.CloudEvent{}, fmt.Errorf("%w: id, source y type son requeridos", ErrInvalidCloudEvent)
+	case len(event.Data) == 0:
+		return domain.CloudEvent{}, fmt.Errorf("%w: data es requerido", ErrInvalidCloudEvent)
+	}
+	for _, schema := range schemas {
+		if event.DataSchema == schema {
+			if err := validateData(schema, event.Data); err != nil {
+				return domain.CloudEvent{}, err
+			}
+			return event, nil
+		}
+	}
+	return domain.CloudEvent{}, fmt.Errorf("%w: dataschema %q no soportado", ErrInvalidCloudEvent, event.DataSchema)
+}
+
+// requiredDataFields son los campos obligatorios de `data` en cada esquema
+// de contracts/events
+var requiredDataFields = map[string][]string{
+	domain.ReservationEventSchemaV1: {"event_type", "reservation_id", "user_id", "room_id", "start_date", "end_date", "status", "timestamp"},
+	domain.RoomEventSchemaV1:        {"event_type", "room_id", "timestamp"},
+	domain.RoomEventSchemaV2:        {"event_type", "room_id", "version", "room", "timestamp"},
+}
+
+// validateData verifica que `data` sea un objeto con los campos requeridos
+// por el esquema; no valida tipos ni campos opcionales
+func validateData(schema string, data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%w: data no es un objeto JSON: %v", ErrInvalidCloudEvent, err)
+	}
+	for _, field := range requiredDataFields[schema] {
+		if value, ok := fields[field]; !ok || string(value) == "null" {
+			return fmt.Errorf("%w: falta %q en data (%s)", ErrInvalidCloudEvent, field, schema)
+		}
+	}
+	return nil
+}
+
+func newEventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("error generando id de evento: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // versión 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variante RFC 4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
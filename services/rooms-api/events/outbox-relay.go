@@ -0,0 +1,116 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"rooms-api/config"
+	"rooms-api/domain"
+	"rooms-api/repositories"
+)
+
+const (
+	relayLease         = 30 * time.Second
+	relayMaxBackoff    = 5 * time.Minute
+	relayPurgeInterval = time.Hour
+)
+
+// OutboxRelay publica los mensajes del outbox en RabbitMQ. Garantiza
+// entrega at-least-once: un mensaje se marca como enviado solo después de
+// que el broker lo confirmó; si falla se reintenta con back-off exponencial.
+type OutboxRelay struct {
+	repository repositories.OutboxRepository
+	publisher  EventPublisher
+	interval   time.Duration
+	retention  time.Duration
+}
+
+func NewOutboxRelay(repository repositories.OutboxRepository, publisher EventPublisher, cfg config.OutboxConfig) *OutboxRelay {
+	return &OutboxRelay{
+		repository: repository,
+		publisher:  publisher,
+		interval:   cfg.RelayInterval,
+		retention:  cfg.Retention,
+	}
+}
+
+// Start corre el relay hasta que se cancele el contexto
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	purge := time.NewTicker(relayPurgeInterval)
+	defer purge.Stop()
+
+	log.Printf("✅ Outbox relay iniciado (cada %s)", r.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Outbox relay detenido")
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		case <-purge.C:
+			r.purge(ctx)
+		}
+	}
+}
+
+// drain publica todos los mensajes listos hasta vaciar el backlog
+func (r *OutboxRelay) drain(ctx context.Context) {
+	// Sin broker los mensajes esperan en el outbox, no consumen intentos
+	if !r.publisher.Connected() {
+		return
+	}
+	for ctx.Err() == nil {
+		message, found, err := r.repository.ClaimNext(ctx, time.Now(), relayLease)
+		if err != nil {
+			log.Printf("⚠️  Error leyendo outbox: %v", err)
+			return
+		}
+		if !found {
+			return
+		}
+		r.publish(ctx, message)
+	}
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, message domain.OutboxMessage) {
+	pubCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := r.publisher.Publish(pubCtx, message); err != nil {
+		next := time.Now().Add(backoff(message.Attempts))
+		log.Printf("⚠️  Error publicando evento %s (intento %d), reintento a las %s: %v",
+			message.RoutingKey, message.Attempts+1, next.Format(time.RFC3339), err)
+		if markErr := r.repository.MarkFailed(ctx, message.ID, err.Error(), next); markErr != nil {
+			log.Printf("⚠️  Error registrando fallo del outbox: %v", markErr)
+		}
+		return
+	}
+
+	if err := r.repository.MarkSent(ctx, message.ID, time.Now()); err != nil {
+		// El mensaje se volverá a publicar al vencer el lease (at-least-once)
+		log.Printf("⚠️  Error marcando evento %d como enviado: %v", message.ID, err)
+	}
+}
+
+// purge borra los mensajes enviados que superaron la retención
+func (r *OutboxRelay) purge(ctx context.Context) {
+	deleted, err := r.repository.PurgeSent(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		log.Printf("⚠️  Error purgando outbox: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("Outbox: %d eventos enviados purgados", deleted)
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	wait := time.Second << uint(attempts)
+	if wait <= 0 || wait > relayMaxBackoff {
+		return relayMaxBackoff
+	}
+	return wait
+}
@@ -1,115 +1,203 @@
 package events
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
+
+	"rooms-api/config"
 	"rooms-api/domain"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-type EventPublisher struct {
-	conn    *amqp.Connection
+const (
+	ExchangeName = "rooms"
+	ExchangeType = "topic"
+)
+
+var (
+	ErrPublisherClosed       = errors.New("publisher closed")
+	ErrPublisherDisconnected = errors.New("publisher not connected to RabbitMQ")
+)
+
+// EventPublisher publica en el exchange de habitaciones los mensajes del
+// outbox. Publish vuelve recién cuando el broker confirmó el mensaje.
+type EventPublisher interface {
+	Publish(ctx context.Context, message domain.OutboxMessage) error
+	// Connected indica si hay una conexión activa con el broker
+	Connected() bool
+	Close() error
+}
+
+// rabbitMQPublisher mantiene la conexión en una goroutine propia y se
+// reconecta con back-off cuando el broker cierra la conexión o el channel
+type rabbitMQPublisher struct {
+	cfg config.RabbitMQConfig
+
+	// mu protege el channel: un channel no admite publicaciones concurrentes
+	mu      sync.Mutex
 	channel *amqp.Channel
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-func NewEventPublisher(conn *amqp.Connection) (*EventPublisher, error) {
-	ch, err := conn.Channel()
-	if err != nil {
-		return nil, err
+// NewEventPublisher no bloquea esperando al broker: el servicio arranca
+// degradado y los eventos esperan en el outbox hasta que RabbitMQ esté listo
+func NewEventPublisher(cfg config.RabbitMQConfig) EventPublisher {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &rabbitMQPublisher{
+		cfg:    cfg,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go p.run(ctx)
+	return p
+}
+
+func (p *rabbitMQPublisher) Publish(ctx context.Context, message domain.OutboxMessage) error {
+	select {
+	case <-p.done:
+		return ErrPublisherClosed
+	default:
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.channel == nil {
+		return ErrPublisherDisconnected
 	}
 
-	// Declarar exchange "rooms" para search-api
-	err = ch.ExchangeDeclare(
-		"rooms",
-		"topic",
-		true,
-		false,
-		false,
-		false,
-		nil,
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		ExchangeName,       // exchange
+		message.RoutingKey, // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  domain.CloudEventsContentType,
+			MessageId:    message.EventID,
+			Type:         message.RoutingKey,
+			Timestamp:    message.CreatedAt,
+			Body:         message.Payload,
+			DeliveryMode: amqp.Persistent,
+		},
 	)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
-	return &EventPublisher{
-		conn:    conn,
-		channel: ch,
-	}, nil
-}
-
-func (p *EventPublisher) PublishRoomCreated(room *domain.Room) error {
-	event := map[string]interface{}{
-		"event_type": "created",
-		"room_id":    room.ID,
-		"timestamp":  time.Now().Format(time.RFC3339),
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed waiting for publisher confirm: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("broker rejected event %s", message.RoutingKey)
 	}
 
-	return p.publish("room.created", event)
+	log.Printf("✅ Evento publicado: %s", message.RoutingKey)
+	return nil
 }
 
-func (p *EventPublisher) PublishRoomUpdated(room *domain.Room) error {
-	event := map[string]interface{}{
-		"event_type": "updated",
-		"room_id":    room.ID,
-		"timestamp":  time.Now().Format(time.RFC3339),
-	}
+func (p *rabbitMQPublisher) Connected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.channel != nil
+}
 
-	return p.publish("room.updated", event)
+func (p *rabbitMQPublisher) Close() error {
+	p.closeOnce.Do(func() {
+		p.cancel()
+		<-p.done
+	})
+	return nil
 }
 
-func (p *EventPublisher) PublishRoomDeleted(roomID uint) error {
-	event := map[string]interface{}{
-		"event_type": "deleted",
-		"room_id":    roomID,
-		"timestamp":  time.Now().Format(time.RFC3339),
+// run conecta, espera a que se pierda la conexión y vuelve a conectar
+func (p *rabbitMQPublisher) run(ctx context.Context) {
+	defer close(p.done)
+
+	wait := p.cfg.ReconnectMin
+	for {
+		conn, channel, err := p.connect()
+		if err != nil {
+			log.Printf("⚠️  RabbitMQ no disponible: %v. Reintentando en %s...", err, wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+			wait = p.cfg.NextBackoff(wait)
+			continue
+		}
+
+		wait = p.cfg.ReconnectMin
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		channelClosed := channel.NotifyClose(make(chan *amqp.Error, 1))
+		p.setChannel(channel)
+		log.Printf("✅ Publisher conectado a RabbitMQ, exchange '%s' declarado", ExchangeName)
+
+		select {
+		case <-ctx.Done():
+		case err := <-connClosed:
+			log.Printf("⚠️  Conexión con RabbitMQ cerrada: %v", err)
+		case err := <-channelClosed:
+			log.Printf("⚠️  Channel de RabbitMQ cerrado: %v", err)
+		}
+
+		p.setChannel(nil)
+		channel.Close()
+		conn.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Println("⚠️  Conexión con RabbitMQ perdida, reconectando...")
 	}
+}
 
-	return p.publish("room.deleted", event)
+func (p *rabbitMQPublisher) setChannel(channel *amqp.Channel) {
+	p.mu.Lock()
+	p.channel = channel
+	p.mu.Unlock()
 }
 
-func (p *EventPublisher) PublishRoomStatusChanged(roomID uint, oldStatus, newStatus string) error {
-	// Cambio de estado también es una actualización, usar routing key room.updated
-	event := map[string]interface{}{
-		"event_type": "updated",
-		"room_id":    roomID,
-		"timestamp":  time.Now().Format(time.RFC3339),
+func (p *rabbitMQPublisher) connect() (*amqp.Connection, *amqp.Channel, error) {
+	conn, err := config.DialRabbitMQ(p.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect: %w", err)
 	}
 
-	return p.publish("room.updated", event)
-}
-
-func (p *EventPublisher) publish(routingKey string, event map[string]interface{}) error {
-	body, err := json.Marshal(event)
+	channel, err := conn.Channel()
 	if err != nil {
-		return fmt.Errorf("error marshaling event: %w", err)
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
-	err = p.channel.Publish(
-		"rooms",
-		routingKey,
-		false,
-		false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		},
+	// Declarar el exchange en cada conexión por si el broker se recreó
+	err = channel.ExchangeDeclare(
+		ExchangeName, // name
+		ExchangeType, // type
+		true,         // durable
+		false,        // auto-deleted
+		false,        // internal
+		false,        // no-wait
+		nil,          // arguments
 	)
-
 	if err != nil {
-		return fmt.Errorf("error publishing event: %w", err)
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	log.Printf("✅ Evento publicado: %s", routingKey)
-	return nil
-}
-
-func (p *EventPublisher) Close() error {
-	if p.channel != nil {
-		return p.channel.Close()
+	// Modo confirm: el broker confirma cada mensaje aceptado
+	if err := channel.Confirm(false); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
 	}
-	return nil
+
+	return conn, channel, nil
 }
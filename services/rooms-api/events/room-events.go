@@ -0,0 +1,34 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"rooms-api/domain"
+)
+
+// NewRoomEventMessage arma el mensaje del outbox para un evento de
+// habitación: el evento va como `data` de un envelope CloudEvents cuyo type
+// es la routing key
+func NewRoomEventMessage(event domain.RoomEvent) (domain.OutboxMessage, error) {
+	event.RoomID = event.Room.ID
+	event.Version = event.Room.Version
+	event.Timestamp = time.Now()
+	routingKey := "room." + event.EventType
+
+	envelope, err := NewCloudEvent(routingKey, fmt.Sprint(event.RoomID), domain.RoomEventSchemaV2, event)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return domain.OutboxMessage{}, fmt.Errorf("error marshaling event: %w", err)
+	}
+
+	return domain.OutboxMessage{
+		EventID:    envelope.ID,
+		RoutingKey: routingKey,
+		Payload:    body,
+	}, nil
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"rooms-api/domain"
+	"rooms-api/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AmenityRepository participa de la transacción del ctx, si la hay
+type AmenityRepository interface {
+	List(ctx context.Context, category string) ([]domain.Amenity, error)
+	GetByID(ctx context.Context, id uint) (*domain.Amenity, error)
+	// GetByCodes devuelve ErrUnknownAmenity si algún código no existe
+	GetByCodes(ctx context.Context, codes []string) ([]domain.Amenity, error)
+	Create(ctx context.Context, amenity *domain.Amenity) error
+	Update(ctx context.Context, amenity *domain.Amenity) error
+	Delete(ctx context.Context, id uint) error
+	// RoomIDs devuelve las habitaciones (no dadas de baja) que tienen la amenity
+	RoomIDs(ctx context.Context, id uint) ([]uint, error)
+	// EnsureLegacy crea las amenities de las columnas has_* y asocia las
+	// habitaciones que ya tenían el flag. Es idempotente.
+	EnsureLegacy(ctx context.Context) error
+}
+
+type amenityRepository struct {
+	db *gorm.DB
+}
+
+func NewAmenityRepository(db *gorm.DB) AmenityRepository {
+	return &amenityRepository{db: db}
+}
+
+func (r *amenityRepository) List(ctx context.Context, category string) ([]domain.Amenity, error) {
+	var amenities []domain.Amenity
+	query := conn(ctx, r.db)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if err := query.Order("category ASC, name ASC").Find(&amenities).Error; err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return amenities, nil
+}
+
+func (r *amenityRepository) GetByID(ctx context.Context, id uint) (*domain.Amenity, error) {
+	var amenity domain.Amenity
+	err := conn(ctx, r.db).Where("id = ?", id).First(&amenity).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, utils.ErrAmenityNotFound
+	}
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return &amenity, nil
+}
+
+func (r *amenityRepository) GetByCodes(ctx context.Context, codes []string) ([]domain.Amenity, error) {
+	if len(codes) == 0 {
+		return []domain.Amenity{}, nil
+	}
+	var amenities []domain.Amenity
+	if err := conn(ctx, r.db).Where("code IN ?", codes).Order("code ASC").Find(&amenities).Error; err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	if len(amenities) != len(codes) {
+		return nil, utils.ErrUnknownAmenity
+	}
+	return amenities, nil
+}
+
+func (r *amenityRepository) Create(ctx context.Context, amenity *domain.Amenity) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&domain.Amenity{}).Where("code = ?", amenity.Code).Count(&count).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	if count > 0 {
+		return utils.ErrAmenityAlreadyExists
+	}
+	if err := conn(ctx, r.db).Create(amenity).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *amenityRepository) Update(ctx context.Context, amenity *domain.Amenity) error {
+	err := conn(ctx, r.db).Model(amenity).Select("name", "icon", "category", "updated_at").Updates(amenity).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *amenityRepository) Delete(ctx context.Context, id uint) error {
+	result := conn(ctx, r.db).Delete(&domain.Amenity{}, id)
+	if result.Error != nil {
+		return utils.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrAmenityNotFound
+	}
+	return nil
+}
+
+func (r *amenityRepository) RoomIDs(ctx context.Context, id uint) ([]uint, error) {
+	var ids []uint
+	err := conn(ctx, r.db).Table("room_amenities").
+		Joins("JOIN rooms ON rooms.id = room_amenities.room_id AND rooms.deleted_at IS NULL").
+		Where("room_amenities.amenity_id = ?", id).
+		Order("room_amenities.room_id ASC").
+		Pluck("room_amenities.room_id", &ids).Error
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return ids, nil
+}
+
+func (r *amenityRepository) EnsureLegacy(ctx context.Context) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, legacy := range domain.LegacyAmenities {
+			amenity := legacy
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&amenity).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Backfill de las habitaciones creadas antes del catálogo
+		flags := map[string]string{
+			domain.AmenityWifi:    "has_wifi",
+			domain.AmenityAC:      "has_ac",
+			domain.AmenityTV:      "has_tv",
+			domain.AmenityMinibar: "has_minibar",
+		}
+		for code, column := range flags {
+			err := tx.Exec(`INSERT IGNORE INTO room_amenities (room_id, amenity_id)
+				SELECT rooms.id, amenities.id FROM rooms JOIN amenities ON amenities.code = ?
+				WHERE rooms.`+column+` = TRUE`, code).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
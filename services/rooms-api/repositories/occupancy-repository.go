@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"rooms-api/domain"
+	"rooms-api/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OccupancyRepository guarda las reservas que consume rooms-api para
+// calcular la ocupación por noche y tipo de habitación
+type OccupancyRepository interface {
+	// GetStay devuelve (nil, nil) si la reserva todavía no se registró
+	GetStay(ctx context.Context, reservationID string) (*domain.OccupancyStay, error)
+	SaveStay(ctx context.Context, stay *domain.OccupancyStay) error
+	// CountByNight devuelve cuántas reservas contadas ocupan cada noche de
+	// [from, to) para el tipo de habitación
+	CountByNight(ctx context.Context, roomType domain.RoomType, from, to string) (map[string]int64, error)
+	// StartingOn devuelve las reservas contadas cuya estadía empieza en date
+	StartingOn(ctx context.Context, date string) ([]domain.OccupancyStay, error)
+}
+
+type occupancyRepository struct {
+	db *gorm.DB
+}
+
+func NewOccupancyRepository(db *gorm.DB) OccupancyRepository {
+	return &occupancyRepository{db: db}
+}
+
+func (r *occupancyRepository) GetStay(ctx context.Context, reservationID string) (*domain.OccupancyStay, error) {
+	var stay domain.OccupancyStay
+	err := conn(ctx, r.db).Where("reservation_id = ?", reservationID).First(&stay).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return &stay, nil
+}
+
+func (r *occupancyRepository) SaveStay(ctx context.Context, stay *domain.OccupancyStay) error {
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reservation_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"room_id", "room_type", "start_date", "end_date", "counted", "updated_at"}),
+	}).Create(stay).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *occupancyRepository) CountByNight(ctx context.Context, roomType domain.RoomType, from, to string) (map[string]int64, error) {
+	var stays []domain.OccupancyStay
+	err := conn(ctx, r.db).
+		Select("start_date", "end_date").
+		Where("room_type = ? AND counted = ? AND start_date < ? AND end_date > ?", roomType, true, to, from).
+		Find(&stays).Error
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+
+	counts := make(map[string]int64)
+	for _, stay := range stays {
+		start, err := time.Parse(domain.DateLayout, stay.StartDate)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(domain.DateLayout, stay.EndDate)
+		if err != nil {
+			continue
+		}
+		for night := start; night.Before(end); night = night.AddDate(0, 0, 1) {
+			date := night.Format(domain.DateLayout)
+			if date >= from && date < to {
+				counts[date]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (r *occupancyRepository) StartingOn(ctx context.Context, date string) ([]domain.OccupancyStay, error) {
+	var stays []domain.OccupancyStay
+	err := conn(ctx, r.db).
+		Where("start_date = ? AND counted = ?", date, true).
+		Find(&stays).Error
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return stays, nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rooms-api/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	// Enqueue participa de la transacción del ctx, si la hay
+	Enqueue(ctx context.Context, message domain.OutboxMessage) error
+	// ClaimNext toma el próximo mensaje listo para publicar y lo bloquea por `lease`
+	ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (domain.OutboxMessage, bool, error)
+	MarkSent(ctx context.Context, id uint, at time.Time) error
+	MarkFailed(ctx context.Context, id uint, reason string, nextAttempt time.Time) error
+	// PurgeSent borra los mensajes enviados antes de `before`
+	PurgeSent(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Enqueue(ctx context.Context, message domain.OutboxMessage) error {
+	message.ID = 0
+	message.Status = domain.OutboxStatusPending
+	message.NextAttemptAt = time.Now()
+
+	if err := conn(ctx, r.db).Create(&message).Error; err != nil {
+		return fmt.Errorf("failed to enqueue event: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (domain.OutboxMessage, bool, error) {
+	var message domain.OutboxMessage
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED: otra réplica del relay toma el siguiente en lugar de esperar.
+		// Se publica en orden de inserción.
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.OutboxStatusPending, now).
+			Order("id ASC").
+			First(&message).Error
+		if err != nil {
+			return err
+		}
+		message.NextAttemptAt = now.Add(lease)
+		return tx.Model(&message).Update("next_attempt_at", message.NextAttemptAt).Error
+	})
+	if err == gorm.ErrRecordNotFound {
+		return domain.OutboxMessage{}, false, nil
+	}
+	if err != nil {
+		return domain.OutboxMessage{}, false, fmt.Errorf("failed to claim outbox message: %w", err)
+	}
+	return message, true, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id uint, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&domain.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     domain.OutboxStatusSent,
+		"sent_at":    at,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": nil,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message as sent: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uint, reason string, nextAttempt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&domain.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_error":      reason,
+		"next_attempt_at": nextAttempt,
+		"attempts":        gorm.Expr("attempts + 1"),
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message as failed: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) PurgeSent(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND sent_at < ?", domain.OutboxStatusSent, before).
+		Delete(&domain.OutboxMessage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge outbox: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
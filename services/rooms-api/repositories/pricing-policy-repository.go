@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"rooms-api/domain"
+	"rooms-api/utils"
+
+	"gorm.io/gorm"
+)
+
+// PricingPolicyRepository participa de la transacción del ctx, si la hay
+type PricingPolicyRepository interface {
+	List(ctx context.Context) ([]domain.DynamicPricingPolicy, error)
+	GetByID(ctx context.Context, id uint) (*domain.DynamicPricingPolicy, error)
+	// GetByRoomType devuelve ErrPricingPolicyNotFound si el tipo no tiene política
+	GetByRoomType(ctx context.Context, roomType domain.RoomType) (*domain.DynamicPricingPolicy, error)
+	Create(ctx context.Context, policy *domain.DynamicPricingPolicy) error
+	// Replace actualiza la política y reemplaza sus escalones
+	Replace(ctx context.Context, policy *domain.DynamicPricingPolicy) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type pricingPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewPricingPolicyRepository(db *gorm.DB) PricingPolicyRepository {
+	return &pricingPolicyRepository{db: db}
+}
+
+// withTiers precarga los escalones en el orden en que se evalúan
+func withTiers(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("OccupancyTiers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_occupancy ASC")
+		}).
+		Preload("LeadTimeTiers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_days_ahead ASC")
+		})
+}
+
+func (r *pricingPolicyRepository) List(ctx context.Context) ([]domain.DynamicPricingPolicy, error) {
+	var policies []domain.DynamicPricingPolicy
+	if err := withTiers(conn(ctx, r.db)).Order("room_type ASC").Find(&policies).Error; err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return policies, nil
+}
+
+func (r *pricingPolicyRepository) GetByID(ctx context.Context, id uint) (*domain.DynamicPricingPolicy, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *pricingPolicyRepository) GetByRoomType(ctx context.Context, roomType domain.RoomType) (*domain.DynamicPricingPolicy, error) {
+	return r.first(ctx, "room_type = ?", roomType)
+}
+
+func (r *pricingPolicyRepository) first(ctx context.Context, query string, arg interface{}) (*domain.DynamicPricingPolicy, error) {
+	var policy domain.DynamicPricingPolicy
+	err := withTiers(conn(ctx, r.db)).Where(query, arg).First(&policy).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, utils.ErrPricingPolicyNotFound
+	}
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return &policy, nil
+}
+
+func (r *pricingPolicyRepository) Create(ctx context.Context, policy *domain.DynamicPricingPolicy) error {
+	var count int64
+	err := conn(ctx, r.db).Model(&domain.DynamicPricingPolicy{}).
+		Where("room_type = ?", policy.RoomType).
+		Count(&count).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+	if count > 0 {
+		return utils.ErrPricingPolicyAlreadyExists
+	}
+
+	// Create guarda también los escalones
+	if err := conn(ctx, r.db).Create(policy).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *pricingPolicyRepository) Replace(ctx context.Context, policy *domain.DynamicPricingPolicy) error {
+	db := conn(ctx, r.db)
+
+	var count int64
+	err := db.Model(&domain.DynamicPricingPolicy{}).
+		Where("room_type = ? AND id <> ?", policy.RoomType, policy.ID).
+		Count(&count).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+	if count > 0 {
+		return utils.ErrPricingPolicyAlreadyExists
+	}
+
+	err = db.Model(&domain.DynamicPricingPolicy{ID: policy.ID}).
+		Select("room_type", "enabled", "horizon_days", "floor_rate", "ceiling_rate", "updated_at").
+		Updates(policy).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+
+	if err := r.deleteTiers(db, policy.ID); err != nil {
+		return err
+	}
+	for i := range policy.OccupancyTiers {
+		policy.OccupancyTiers[i].PolicyID = policy.ID
+	}
+	for i := range policy.LeadTimeTiers {
+		policy.LeadTimeTiers[i].PolicyID = policy.ID
+	}
+	if len(policy.OccupancyTiers) > 0 {
+		if err := db.Create(&policy.OccupancyTiers).Error; err != nil {
+			return utils.ErrDatabaseError
+		}
+	}
+	if len(policy.LeadTimeTiers) > 0 {
+		if err := db.Create(&policy.LeadTimeTiers).Error; err != nil {
+			return utils.ErrDatabaseError
+		}
+	}
+	return nil
+}
+
+func (r *pricingPolicyRepository) Delete(ctx context.Context, id uint) error {
+	db := conn(ctx, r.db)
+	if err := r.deleteTiers(db, id); err != nil {
+		return err
+	}
+
+	result := db.Delete(&domain.DynamicPricingPolicy{}, id)
+	if result.Error != nil {
+		return utils.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrPricingPolicyNotFound
+	}
+	return nil
+}
+
+func (r *pricingPolicyRepository) deleteTiers(db *gorm.DB, policyID uint) error {
+	if err := db.Where("policy_id = ?", policyID).Delete(&domain.OccupancyTier{}).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	if err := db.Where("policy_id = ?", policyID).Delete(&domain.LeadTimeTier{}).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"context"
+	"rooms-api/domain"
+	"rooms-api/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RatePlanRepository participa de la transacción del ctx, si la hay
+type RatePlanRepository interface {
+	List(ctx context.Context) ([]domain.RatePlan, error)
+	GetByID(ctx context.Context, id uint) (*domain.RatePlan, error)
+	// GetByRoomType devuelve ErrRatePlanNotFound si el tipo no tiene plan
+	GetByRoomType(ctx context.Context, roomType domain.RoomType) (*domain.RatePlan, error)
+	Create(ctx context.Context, plan *domain.RatePlan) error
+	// Replace actualiza el plan y reemplaza sus temporadas y descuentos
+	Replace(ctx context.Context, plan *domain.RatePlan) error
+	Delete(ctx context.Context, id uint) error
+
+	// Overrides devuelve los precios dinámicos del plan entre from y to
+	// (excluida), indexados por fecha
+	Overrides(ctx context.Context, planID uint, from, to string) (map[string]domain.RateOverride, error)
+	// SaveOverrides inserta o actualiza los precios por (plan, fecha)
+	SaveOverrides(ctx context.Context, overrides []domain.RateOverride) error
+	// DeleteOverrides borra todos los precios dinámicos del plan
+	DeleteOverrides(ctx context.Context, planID uint) error
+	// PurgeOverrides borra los precios de noches anteriores a before
+	PurgeOverrides(ctx context.Context, before string) (int64, error)
+}
+
+type ratePlanRepository struct {
+	db *gorm.DB
+}
+
+func NewRatePlanRepository(db *gorm.DB) RatePlanRepository {
+	return &ratePlanRepository{db: db}
+}
+
+// withRules precarga temporadas y descuentos en el orden en que se evalúan
+func withRules(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Seasons", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_date ASC")
+		}).
+		Preload("StayDiscounts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_nights ASC")
+		})
+}
+
+func (r *ratePlanRepository) List(ctx context.Context) ([]domain.RatePlan, error) {
+	var plans []domain.RatePlan
+	if err := withRules(conn(ctx, r.db)).Order("room_type ASC").Find(&plans).Error; err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return plans, nil
+}
+
+func (r *ratePlanRepository) GetByID(ctx context.Context, id uint) (*domain.RatePlan, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *ratePlanRepository) GetByRoomType(ctx context.Context, roomType domain.RoomType) (*domain.RatePlan, error) {
+	return r.first(ctx, "room_type = ?", roomType)
+}
+
+func (r *ratePlanRepository) first(ctx context.Context, query string, arg interface{}) (*domain.RatePlan, error) {
+	var plan domain.RatePlan
+	err := withRules(conn(ctx, r.db)).Where(query, arg).First(&plan).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, utils.ErrRatePlanNotFound
+	}
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return &plan, nil
+}
+
+func (r *ratePlanRepository) Create(ctx context.Context, plan *domain.RatePlan) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&domain.RatePlan{}).Where("room_type = ?", plan.RoomType).Count(&count).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	if count > 0 {
+		return utils.ErrRatePlanAlreadyExists
+	}
+
+	// Create guarda también Seasons y StayDiscounts
+	if err := conn(ctx, r.db).Create(plan).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *ratePlanRepository) Replace(ctx context.Context, plan *domain.RatePlan) error {
+	db := conn(ctx, r.db)
+
+	var count int64
+	err := db.Model(&domain.RatePlan{}).
+		Where("room_type = ? AND id <> ?", plan.RoomType, plan.ID).
+		Count(&count).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+	if count > 0 {
+		return utils.ErrRatePlanAlreadyExists
+	}
+
+	err = db.Model(&domain.RatePlan{ID: plan.ID}).
+		Select("room_type", "name", "base_rate", "weekend_adjustment", "min_stay", "updated_at").
+		Updates(plan).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+
+	if err := r.deleteRules(db, plan.ID); err != nil {
+		return err
+	}
+	for i := range plan.Seasons {
+		plan.Seasons[i].RatePlanID = plan.ID
+	}
+	for i := range plan.StayDiscounts {
+		plan.StayDiscounts[i].RatePlanID = plan.ID
+	}
+	if len(plan.Seasons) > 0 {
+		if err := db.Create(&plan.Seasons).Error; err != nil {
+			return utils.ErrDatabaseError
+		}
+	}
+	if len(plan.StayDiscounts) > 0 {
+		if err := db.Create(&plan.StayDiscounts).Error; err != nil {
+			return utils.ErrDatabaseError
+		}
+	}
+	return nil
+}
+
+func (r *ratePlanRepository) Delete(ctx context.Context, id uint) error {
+	db := conn(ctx, r.db)
+	if err := r.deleteRules(db, id); err != nil {
+		return err
+	}
+	if err := r.DeleteOverrides(ctx, id); err != nil {
+		return err
+	}
+
+	result := db.Delete(&domain.RatePlan{}, id)
+	if result.Error != nil {
+		return utils.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrRatePlanNotFound
+	}
+	return nil
+}
+
+func (r *ratePlanRepository) deleteRules(db *gorm.DB, planID uint) error {
+	if err := db.Where("rate_plan_id = ?", planID).Delete(&domain.RateSeason{}).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	if err := db.Where("rate_plan_id = ?", planID).Delete(&domain.StayDiscount{}).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *ratePlanRepository) Overrides(ctx context.Context, planID uint, from, to string) (map[string]domain.RateOverride, error) {
+	var overrides []domain.RateOverride
+	err := conn(ctx, r.db).
+		Where("rate_plan_id = ? AND date >= ? AND date < ?", planID, from, to).
+		Find(&overrides).Error
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+
+	byDate := make(map[string]domain.RateOverride, len(overrides))
+	for _, override := range overrides {
+		byDate[override.Date] = override
+	}
+	return byDate, nil
+}
+
+func (r *ratePlanRepository) SaveOverrides(ctx context.Context, overrides []domain.RateOverride) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "rate_plan_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "occupancy", "updated_at"}),
+	}).CreateInBatches(overrides, 200).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *ratePlanRepository) DeleteOverrides(ctx context.Context, planID uint) error {
+	if err := conn(ctx, r.db).Where("rate_plan_id = ?", planID).Delete(&domain.RateOverride{}).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *ratePlanRepository) PurgeOverrides(ctx context.Context, before string) (int64, error) {
+	result := conn(ctx, r.db).Where("date < ?", before).Delete(&domain.RateOverride{})
+	if result.Error != nil {
+		return 0, utils.ErrDatabaseError
+	}
+	return result.RowsAffected, nil
+}
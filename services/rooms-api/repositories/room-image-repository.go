@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"rooms-api/domain"
+	"rooms-api/utils"
+
+	"gorm.io/gorm"
+)
+
+// RoomImageRepository participa de la transacción del ctx, si la hay
+type RoomImageRepository interface {
+	Create(ctx context.Context, image *domain.RoomImage) error
+	GetByID(ctx context.Context, roomID, imageID uint) (*domain.RoomImage, error)
+	ListByRoom(ctx context.Context, roomID uint) ([]domain.RoomImage, error)
+	Delete(ctx context.Context, image *domain.RoomImage) error
+	// SetPositions asigna la posición i a ids[i]
+	SetPositions(ctx context.Context, roomID uint, ids []uint) error
+	SetCover(ctx context.Context, roomID, imageID uint) error
+}
+
+type roomImageRepository struct {
+	db *gorm.DB
+}
+
+func NewRoomImageRepository(db *gorm.DB) RoomImageRepository {
+	return &roomImageRepository{db: db}
+}
+
+func (r *roomImageRepository) Create(ctx context.Context, image *domain.RoomImage) error {
+	if err := conn(ctx, r.db).Create(image).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *roomImageRepository) GetByID(ctx context.Context, roomID, imageID uint) (*domain.RoomImage, error) {
+	var image domain.RoomImage
+	err := conn(ctx, r.db).Where("id = ? AND room_id = ?", imageID, roomID).First(&image).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, utils.ErrImageNotFound
+	}
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return &image, nil
+}
+
+func (r *roomImageRepository) ListByRoom(ctx context.Context, roomID uint) ([]domain.RoomImage, error) {
+	var images []domain.RoomImage
+	err := conn(ctx, r.db).Where("room_id = ?", roomID).Order("position ASC, id ASC").Find(&images).Error
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+	return images, nil
+}
+
+func (r *roomImageRepository) Delete(ctx context.Context, image *domain.RoomImage) error {
+	if err := conn(ctx, r.db).Delete(image).Error; err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *roomImageRepository) SetPositions(ctx context.Context, roomID uint, ids []uint) error {
+	db := conn(ctx, r.db)
+	for position, id := range ids {
+		err := db.Model(&domain.RoomImage{}).
+			Where("id = ? AND room_id = ?", id, roomID).
+			Update("position", position).Error
+		if err != nil {
+			return utils.ErrDatabaseError
+		}
+	}
+	return nil
+}
+
+func (r *roomImageRepository) SetCover(ctx context.Context, roomID, imageID uint) error {
+	err := conn(ctx, r.db).Model(&domain.RoomImage{}).
+		Where("room_id = ?", roomID).
+		Update("is_cover", gorm.Expr("id = ?", imageID)).Error
+	if err != nil {
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
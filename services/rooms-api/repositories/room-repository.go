@@ -4,8 +4,10 @@ import (
 	"context"
 	"rooms-api/domain"
 	"rooms-api/utils"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RoomRepository struct {
@@ -21,7 +23,7 @@ func NewRoomRepository(db *gorm.DB) *RoomRepository {
 func (r *RoomRepository) Create(ctx context.Context, room *domain.Room) error {
 	// Verificar si ya existe una habitación con el mismo número
 	var existingRoom domain.Room
-	result := r.db.WithContext(ctx).Where("number = ?", room.Number).First(&existingRoom)
+	result := conn(ctx, r.db).Where("number = ?", room.Number).First(&existingRoom)
 
 	if result.Error == nil {
 		return utils.ErrRoomAlreadyExists
@@ -32,7 +34,8 @@ func (r *RoomRepository) Create(ctx context.Context, room *domain.Room) error {
 	}
 
 	// Crear la habitación
-	if err := r.db.WithContext(ctx).Create(room).Error; err != nil {
+	room.Version = 1
+	if err := conn(ctx, r.db).Create(room).Error; err != nil {
 		return utils.ErrDatabaseError
 	}
 
@@ -41,7 +44,7 @@ func (r *RoomRepository) Create(ctx context.Context, room *domain.Room) error {
 
 func (r *RoomRepository) GetByID(ctx context.Context, id uint) (*domain.Room, error) {
 	var room domain.Room
-	result := r.db.WithContext(ctx).Where("id = ?", id).First(&room)
+	result := withDetails(conn(ctx, r.db)).Where("id = ?", id).First(&room)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -55,7 +58,7 @@ func (r *RoomRepository) GetByID(ctx context.Context, id uint) (*domain.Room, er
 
 func (r *RoomRepository) GetByNumber(ctx context.Context, number string) (*domain.Room, error) {
 	var room domain.Room
-	result := r.db.WithContext(ctx).Where("number = ?", number).First(&room)
+	result := withDetails(conn(ctx, r.db)).Where("number = ?", number).First(&room)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -72,7 +75,7 @@ func (r *RoomRepository) GetAll(ctx context.Context, filter domain.RoomFilter, p
 	var total int64
 
 	// Construir query base
-	query := r.db.WithContext(ctx).Model(&domain.Room{})
+	query := conn(ctx, r.db).Model(&domain.Room{})
 
 	// Aplicar filtros
 	if filter.Type != nil {
@@ -102,6 +105,13 @@ func (r *RoomRepository) GetAll(ctx context.Context, filter domain.RoomFilter, p
 	if filter.HasMinibar != nil {
 		query = query.Where("has_minibar = ?", *filter.HasMinibar)
 	}
+	if len(filter.Amenities) > 0 {
+		// La habitación debe tener todas las amenities pedidas
+		query = query.Where(`id IN (SELECT room_amenities.room_id FROM room_amenities
+			JOIN amenities ON amenities.id = room_amenities.amenity_id
+			WHERE amenities.code IN ? GROUP BY room_amenities.room_id
+			HAVING COUNT(DISTINCT amenities.code) = ?)`, filter.Amenities, len(filter.Amenities))
+	}
 
 	// Contar total de registros
 	if err := query.Count(&total).Error; err != nil {
@@ -110,17 +120,19 @@ func (r *RoomRepository) GetAll(ctx context.Context, filter domain.RoomFilter, p
 
 	// Aplicar paginación y ordenamiento
 	offset := (page - 1) * limit
-	if err := query.Order("number ASC").Offset(offset).Limit(limit).Find(&rooms).Error; err != nil {
+	if err := withDetails(query).Order("number ASC").Offset(offset).Limit(limit).Find(&rooms).Error; err != nil {
 		return nil, 0, utils.ErrDatabaseError
 	}
 
 	return rooms, total, nil
 }
 
-func (r *RoomRepository) Update(ctx context.Context, id uint, updateData domain.UpdateRoomRequest) (*domain.Room, error) {
+// Update aplica los campos presentes en updateData. Si amenities no es nil
+// reemplaza las amenities de la habitación y deriva de ellas las columnas has_*.
+func (r *RoomRepository) Update(ctx context.Context, id uint, updateData domain.UpdateRoomRequest, amenities []domain.Amenity) (*domain.Room, error) {
 	// Verificar que la habitación existe
 	var room domain.Room
-	result := r.db.WithContext(ctx).Where("id = ?", id).First(&room)
+	result := conn(ctx, r.db).Where("id = ?", id).First(&room)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -132,7 +144,7 @@ func (r *RoomRepository) Update(ctx context.Context, id uint, updateData domain.
 	// Si se está actualizando el número, verificar que no exista otra habitación con ese número
 	if updateData.Number != nil && *updateData.Number != "" {
 		var existingRoom domain.Room
-		result := r.db.WithContext(ctx).Where("number = ? AND id != ?", *updateData.Number, id).First(&existingRoom)
+		result := conn(ctx, r.db).Where("number = ? AND id != ?", *updateData.Number, id).First(&existingRoom)
 
 		if result.Error == nil {
 			return nil, utils.ErrRoomAlreadyExists
@@ -180,29 +192,133 @@ func (r *RoomRepository) Update(ctx context.Context, id uint, updateData domain.
 		updates["has_minibar"] = *updateData.HasMinibar
 	}
 
-	// Actualizar la habitación
-	if err := r.db.WithContext(ctx).Model(&room).Updates(updates).Error; err != nil {
+	if amenities != nil {
+		flags := domain.Room{Amenities: amenities}
+		flags.SyncAmenityFlags()
+		updates["has_wifi"] = flags.HasWifi
+		updates["has_ac"] = flags.HasAC
+		updates["has_tv"] = flags.HasTV
+		updates["has_minibar"] = flags.HasMinibar
+	}
+
+	// Actualizar la habitación y leerla en la misma transacción: el UPDATE
+	// bloquea la fila, así cada cambio obtiene su propia versión
+	updates["version"] = gorm.Expr("version + 1")
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&room).Updates(updates).Error; err != nil {
+			return err
+		}
+		if amenities != nil {
+			if err := tx.Model(&room).Association("Amenities").Replace(amenities); err != nil {
+				return err
+			}
+		}
+		return withDetails(tx).Where("id = ?", id).First(&room).Error
+	})
+	if err != nil {
 		return nil, utils.ErrDatabaseError
 	}
 
-	// Obtener la habitación actualizada
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&room).Error; err != nil {
+	return &room, nil
+}
+
+// Delete da de baja la habitación y devuelve su último estado, con la
+// versión de la baja
+func (r *RoomRepository) Delete(ctx context.Context, id uint) (*domain.Room, error) {
+	var room domain.Room
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Room{}).Where("id = ?", id).Update("version", gorm.Expr("version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return utils.ErrRoomNotFound
+		}
+		if err := withDetails(tx).Where("id = ?", id).First(&room).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&room).Error
+	})
+	if err == utils.ErrRoomNotFound {
+		return nil, err
+	}
+	if err != nil {
 		return nil, utils.ErrDatabaseError
 	}
 
 	return &room, nil
 }
 
-func (r *RoomRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.Room{})
+// Touch incrementa la versión sin cambiar otros campos, para publicar un
+// nuevo snapshot cuando cambia algo asociado (p. ej. la galería)
+func (r *RoomRepository) Touch(ctx context.Context, id uint) (*domain.Room, error) {
+	var room domain.Room
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Room{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now().UTC(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return utils.ErrRoomNotFound
+		}
+		return withDetails(tx).Where("id = ?", id).First(&room).Error
+	})
+	if err == utils.ErrRoomNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, utils.ErrDatabaseError
+	}
+
+	return &room, nil
+}
 
-	if result.Error != nil {
+// Lock bloquea la fila de la habitación hasta el fin de la transacción del
+// ctx; serializa los cambios de la galería de una misma habitación
+func (r *RoomRepository) Lock(ctx context.Context, id uint) error {
+	var room domain.Room
+	err := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").Where("id = ?", id).First(&room).Error
+	if err == gorm.ErrRecordNotFound {
+		return utils.ErrRoomNotFound
+	}
+	if err != nil {
 		return utils.ErrDatabaseError
 	}
+	return nil
+}
+
+// CountByType devuelve cuántas habitaciones (no dadas de baja) hay del tipo
+func (r *RoomRepository) CountByType(ctx context.Context, roomType domain.RoomType) (int64, error) {
+	var count int64
+	if err := conn(ctx, r.db).Model(&domain.Room{}).Where("type = ?", roomType).Count(&count).Error; err != nil {
+		return 0, utils.ErrDatabaseError
+	}
+	return count, nil
+}
 
-	if result.RowsAffected == 0 {
-		return utils.ErrRoomNotFound
+// GetType devuelve el tipo de la habitación aunque esté dada de baja, para
+// poder procesar eventos de reservas viejas
+func (r *RoomRepository) GetType(ctx context.Context, id uint) (domain.RoomType, error) {
+	var room domain.Room
+	err := conn(ctx, r.db).Unscoped().Select("id", "type").Where("id = ?", id).First(&room).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", utils.ErrRoomNotFound
+	}
+	if err != nil {
+		return "", utils.ErrDatabaseError
 	}
+	return room.Type, nil
+}
 
-	return nil
+// withDetails carga la galería en el orden en que se muestra y las amenities
+func withDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Images", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC, id ASC")
+	}).Preload("Amenities", func(db *gorm.DB) *gorm.DB {
+		return db.Order("category ASC, name ASC")
+	})
 }
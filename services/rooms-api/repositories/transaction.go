@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// TxRunner ejecuta una función dentro de una transacción de MySQL. Los
+// repositorios llamados con el ctx recibido participan de la transacción.
+type TxRunner interface {
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormTxRunner struct {
+	db *gorm.DB
+}
+
+func NewTxRunner(db *gorm.DB) TxRunner {
+	return &gormTxRunner{db: db}
+}
+
+func (r *gormTxRunner) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn devuelve la transacción en curso del ctx o, si no hay, la conexión
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
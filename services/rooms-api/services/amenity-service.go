@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"rooms-api/domain"
+	"rooms-api/repositories"
+	"rooms-api/utils"
+	"strings"
+)
+
+var amenityCodePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// AmenityService administra el catálogo de amenities. Un cambio en una
+// amenity vuelve a publicar las habitaciones que la tienen para que
+// search-api indexe el nombre nuevo.
+type AmenityService struct {
+	rooms       *RoomService
+	amenityRepo repositories.AmenityRepository
+	tx          repositories.TxRunner
+}
+
+func NewAmenityService(rooms *RoomService, amenityRepo repositories.AmenityRepository, tx repositories.TxRunner) *AmenityService {
+	return &AmenityService{
+		rooms:       rooms,
+		amenityRepo: amenityRepo,
+		tx:          tx,
+	}
+}
+
+// EnsureLegacyAmenities crea wifi, ac, tv y minibar y asocia las
+// habitaciones existentes según sus columnas has_*
+func (s *AmenityService) EnsureLegacyAmenities(ctx context.Context) error {
+	return s.amenityRepo.EnsureLegacy(ctx)
+}
+
+func (s *AmenityService) List(ctx context.Context, category string) ([]domain.Amenity, error) {
+	return s.amenityRepo.List(ctx, category)
+}
+
+func (s *AmenityService) Create(ctx context.Context, req domain.CreateAmenityRequest) (*domain.Amenity, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if !amenityCodePattern.MatchString(code) {
+		return nil, utils.ErrInvalidAmenityCode
+	}
+
+	amenity := &domain.Amenity{
+		Code:     code,
+		Name:     strings.TrimSpace(req.Name),
+		Icon:     strings.TrimSpace(req.Icon),
+		Category: strings.ToLower(strings.TrimSpace(req.Category)),
+	}
+	if err := s.amenityRepo.Create(ctx, amenity); err != nil {
+		return nil, err
+	}
+	return amenity, nil
+}
+
+// Update cambia nombre, ícono o categoría; el código es inmutable porque
+// es lo que usan los filtros y el índice
+func (s *AmenityService) Update(ctx context.Context, id uint, req domain.UpdateAmenityRequest) (*domain.Amenity, error) {
+	var amenity *domain.Amenity
+	var roomIDs []uint
+	err := s.tx.Run(ctx, func(ctx context.Context) error {
+		var err error
+		amenity, err = s.amenityRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if req.Name != nil {
+			amenity.Name = strings.TrimSpace(*req.Name)
+		}
+		if req.Icon != nil {
+			amenity.Icon = strings.TrimSpace(*req.Icon)
+		}
+		if req.Category != nil {
+			amenity.Category = strings.ToLower(strings.TrimSpace(*req.Category))
+		}
+		if err := s.amenityRepo.Update(ctx, amenity); err != nil {
+			return err
+		}
+
+		roomIDs, err = s.amenityRepo.RoomIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, roomID := range roomIDs {
+			if err := s.rooms.republish(ctx, roomID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, roomID := range roomIDs {
+		s.rooms.invalidateCache(ctx, roomID)
+	}
+	return amenity, nil
+}
+
+// Delete solo borra amenities que ninguna habitación usa; las cuatro que
+// respaldan las columnas has_* no se borran nunca
+func (s *AmenityService) Delete(ctx context.Context, id uint) error {
+	return s.tx.Run(ctx, func(ctx context.Context) error {
+		amenity, err := s.amenityRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if domain.IsLegacyAmenity(amenity.Code) {
+			return utils.ErrLegacyAmenity
+		}
+
+		roomIDs, err := s.amenityRepo.RoomIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(roomIDs) > 0 {
+			return utils.ErrAmenityInUse
+		}
+		return s.amenityRepo.Delete(ctx, id)
+	})
+}
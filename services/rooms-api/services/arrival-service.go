@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"log"
+	"rooms-api/domain"
+	"rooms-api/repositories"
+	"time"
+)
+
+// ArrivalService marca como reservadas las habitaciones cuya estadía
+// empieza hoy. SyncStatusFromReservation solo lo hace si el evento llega el
+// mismo día de la llegada; las reservas hechas con anticipación se marcan
+// acá, con la ocupación que registra DynamicPricingService.
+type ArrivalService struct {
+	rooms         *RoomService
+	occupancyRepo repositories.OccupancyRepository
+}
+
+func NewArrivalService(rooms *RoomService, occupancyRepo repositories.OccupancyRepository) *ArrivalService {
+	return &ArrivalService{
+		rooms:         rooms,
+		occupancyRepo: occupancyRepo,
+	}
+}
+
+// Start barre las llegadas al iniciar y después cada vez que cambia el día.
+// interval es cada cuánto se verifica el cambio de día.
+func (s *ArrivalService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Barrido de llegadas iniciado (verificación cada %s)", interval)
+	lastSweep := ""
+	for {
+		today := time.Now().Format(domain.DateLayout)
+		if today != lastSweep {
+			if err := s.MarkArrivals(ctx, today); err != nil {
+				log.Printf("⚠️  Error marcando las llegadas del %s: %v", today, err)
+			} else {
+				lastSweep = today
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Barrido de llegadas detenido")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MarkArrivals pasa a reserved las habitaciones disponibles con una reserva
+// que empieza en date. No toca habitaciones ocupadas ni en mantenimiento.
+func (s *ArrivalService) MarkArrivals(ctx context.Context, date string) error {
+	stays, err := s.occupancyRepo.StartingOn(ctx, date)
+	if err != nil {
+		return err
+	}
+
+	for _, stay := range stays {
+		err := s.rooms.transitionStatus(ctx, stay.RoomID, domain.RoomStatusReserved, domain.RoomStatusAvailable)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
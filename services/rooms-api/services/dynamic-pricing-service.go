@@ -0,0 +1,513 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"rooms-api/domain"
+	"rooms-api/repositories"
+	"rooms-api/utils"
+	"sort"
+	"time"
+)
+
+// DynamicPricingService ajusta las tarifas según la ocupación. Lleva la
+// ocupación por noche y tipo de habitación a partir de los eventos de
+// reservations-api y guarda el precio calculado como RateOverride del plan
+// de tarifas del tipo, que es lo que devuelve GET /rooms/:id/rates.
+//
+// Precio de una noche = tarifa del plan (temporada y fin de semana incluidos)
+// × multiplicador de ocupación × multiplicador de anticipación, acotado por
+// floor y ceiling.
+type DynamicPricingService struct {
+	policyRepo    repositories.PricingPolicyRepository
+	occupancyRepo repositories.OccupancyRepository
+	ratePlanRepo  repositories.RatePlanRepository
+	roomRepo      *repositories.RoomRepository
+	tx            repositories.TxRunner
+}
+
+func NewDynamicPricingService(policyRepo repositories.PricingPolicyRepository, occupancyRepo repositories.OccupancyRepository,
+	ratePlanRepo repositories.RatePlanRepository, roomRepo *repositories.RoomRepository, tx repositories.TxRunner) *DynamicPricingService {
+	return &DynamicPricingService{
+		policyRepo:    policyRepo,
+		occupancyRepo: occupancyRepo,
+		ratePlanRepo:  ratePlanRepo,
+		roomRepo:      roomRepo,
+		tx:            tx,
+	}
+}
+
+// Start recalcula todas las políticas al iniciar y después cada interval:
+// la anticipación de cada noche cambia con el paso de los días aunque no
+// lleguen reservas
+func (s *DynamicPricingService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Precios dinámicos iniciados (recálculo cada %s)", interval)
+	for {
+		if err := s.RecomputeAll(ctx); err != nil {
+			log.Printf("⚠️  Error recalculando precios dinámicos: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Precios dinámicos detenidos")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DynamicPricingService) List(ctx context.Context) ([]domain.DynamicPricingPolicy, error) {
+	return s.policyRepo.List(ctx)
+}
+
+func (s *DynamicPricingService) Get(ctx context.Context, id uint) (*domain.DynamicPricingPolicy, error) {
+	return s.policyRepo.GetByID(ctx, id)
+}
+
+// Create guarda la política y calcula los precios de su horizonte. El tipo
+// de habitación tiene que tener plan de tarifas.
+func (s *DynamicPricingService) Create(ctx context.Context, req domain.DynamicPricingPolicyRequest) (*domain.DynamicPricingPolicy, error) {
+	policy, err := buildPricingPolicy(req.RoomType, req.PricingRulesRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireRatePlan(ctx, policy.RoomType); err != nil {
+		return nil, err
+	}
+	if err := s.policyRepo.Create(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	s.recomputeAfterChange(ctx, policy.RoomType)
+	return policy, nil
+}
+
+// Update reemplaza la política completa, escalones incluidos
+func (s *DynamicPricingService) Update(ctx context.Context, id uint, req domain.DynamicPricingPolicyRequest) (*domain.DynamicPricingPolicy, error) {
+	policy, err := buildPricingPolicy(req.RoomType, req.PricingRulesRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireRatePlan(ctx, policy.RoomType); err != nil {
+		return nil, err
+	}
+
+	var previous *domain.DynamicPricingPolicy
+	err = s.tx.Run(ctx, func(ctx context.Context) error {
+		var err error
+		previous, err = s.policyRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		policy.ID = id
+		return s.policyRepo.Replace(ctx, policy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previous.RoomType != policy.RoomType {
+		s.clearOverrides(ctx, previous.RoomType)
+	}
+	s.recomputeAfterChange(ctx, policy.RoomType)
+	return s.policyRepo.GetByID(ctx, id)
+}
+
+// Delete borra la política; las noches vuelven a la tarifa del plan
+func (s *DynamicPricingService) Delete(ctx context.Context, id uint) error {
+	policy, err := s.policyRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.tx.Run(ctx, func(ctx context.Context) error {
+		return s.policyRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.clearOverrides(ctx, policy.RoomType)
+	return nil
+}
+
+// RecordReservation actualiza la ocupación con un evento de reservations-api
+// y recalcula las noches afectadas. Es idempotente: la reserva se guarda
+// por ID, así que un evento repetido no suma ocupación.
+func (s *DynamicPricingService) RecordReservation(ctx context.Context, event domain.ReservationEvent) error {
+	if event.ReservationID == "" {
+		return nil
+	}
+
+	roomType, err := s.roomRepo.GetType(ctx, event.RoomID)
+	if err == utils.ErrRoomNotFound {
+		log.Printf("⚠️  Reserva %s de una habitación desconocida (%d), se ignora para la ocupación", event.ReservationID, event.RoomID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.occupancyRepo.GetStay(ctx, event.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	stay := &domain.OccupancyStay{
+		ReservationID: event.ReservationID,
+		RoomID:        event.RoomID,
+		RoomType:      roomType,
+		StartDate:     event.StartDate,
+		EndDate:       event.EndDate,
+		Counted:       countsAsOccupied(event),
+	}
+	if err := s.occupancyRepo.SaveStay(ctx, stay); err != nil {
+		return err
+	}
+
+	// Si falla el recálculo la ocupación ya quedó guardada: el recálculo
+	// periódico lo corrige
+	if err := s.recomputeStay(ctx, stay.RoomType, stay.StartDate, stay.EndDate); err != nil {
+		log.Printf("⚠️  Error recalculando precios de %s: %v", stay.RoomType, err)
+	}
+	if previous != nil && (previous.RoomType != stay.RoomType || previous.StartDate != stay.StartDate || previous.EndDate != stay.EndDate) {
+		if err := s.recomputeStay(ctx, previous.RoomType, previous.StartDate, previous.EndDate); err != nil {
+			log.Printf("⚠️  Error recalculando precios de %s: %v", previous.RoomType, err)
+		}
+	}
+	return nil
+}
+
+// countsAsOccupied indica si la reserva ocupa inventario. Los holds
+// (pending) todavía pueden vencer y las canceladas, vencidas o no-show
+// liberan la habitación.
+func countsAsOccupied(event domain.ReservationEvent) bool {
+	switch event.EventType {
+	case domain.ReservationEventCanceled, domain.ReservationEventExpired, domain.ReservationEventNoShow:
+		return false
+	}
+	return event.Status == "active" || event.Status == "completed"
+}
+
+// RecomputeAll recalcula el horizonte de todas las políticas activas y
+// borra los precios de noches que ya pasaron
+func (s *DynamicPricingService) RecomputeAll(ctx context.Context) error {
+	policies, err := s.policyRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	today := pricingToday()
+	for i := range policies {
+		policy := &policies[i]
+		if !policy.Enabled {
+			continue
+		}
+		if err := s.recompute(ctx, policy, today, today.AddDate(0, 0, policy.HorizonDays)); err != nil {
+			log.Printf("⚠️  Error recalculando precios de %s: %v", policy.RoomType, err)
+		}
+	}
+
+	if _, err := s.ratePlanRepo.PurgeOverrides(ctx, today.Format(domain.DateLayout)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Simulate calcula los precios de [from, to) sin guardarlos, con la
+// ocupación y la política de la solicitud si vienen
+func (s *DynamicPricingService) Simulate(ctx context.Context, req domain.PricingSimulationRequest) (*domain.PricingSimulationResponse, error) {
+	start, end, err := parseStay(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *domain.DynamicPricingPolicy
+	if req.Policy != nil {
+		policy, err = buildPricingPolicy(req.RoomType, *req.Policy)
+	} else {
+		policy, err = s.policyRepo.GetByRoomType(ctx, req.RoomType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.ratePlanRepo.GetByRoomType(ctx, req.RoomType)
+	if err == utils.ErrRatePlanNotFound {
+		return nil, utils.ErrRatePlanRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	capacity, err := s.roomRepo.CountByType(ctx, req.RoomType)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int64{}
+	if req.Occupancy == nil {
+		counts, err = s.occupancyRepo.CountByNight(ctx, req.RoomType, start.Format(domain.DateLayout), end.Format(domain.DateLayout))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	today := pricingToday()
+	response := &domain.PricingSimulationResponse{
+		RoomType: req.RoomType,
+		Capacity: capacity,
+		Nights:   []domain.SimulatedNight{},
+	}
+	for night := start; night.Before(end); night = night.AddDate(0, 0, 1) {
+		date := night.Format(domain.DateLayout)
+		occupancy := occupancyPercent(counts[date], capacity)
+		if req.Occupancy != nil {
+			occupancy = *req.Occupancy
+		}
+		base := staticRate(plan, night)
+		response.Nights = append(response.Nights, dynamicRate(policy, base, occupancy, daysBetween(today, night), date))
+	}
+	return response, nil
+}
+
+// recomputeStay recalcula las noches de una estadía, si el tipo tiene
+// política activa
+func (s *DynamicPricingService) recomputeStay(ctx context.Context, roomType domain.RoomType, startDate, endDate string) error {
+	start, err := time.Parse(domain.DateLayout, startDate)
+	if err != nil {
+		return nil
+	}
+	end, err := time.Parse(domain.DateLayout, endDate)
+	if err != nil {
+		return nil
+	}
+
+	policy, err := s.policyRepo.GetByRoomType(ctx, roomType)
+	if err == utils.ErrPricingPolicyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !policy.Enabled {
+		return nil
+	}
+	return s.recompute(ctx, policy, start, end)
+}
+
+// recompute calcula y guarda los precios de [start, end) recortado al
+// horizonte de la política
+func (s *DynamicPricingService) recompute(ctx context.Context, policy *domain.DynamicPricingPolicy, start, end time.Time) error {
+	today := pricingToday()
+	if start.Before(today) {
+		start = today
+	}
+	if horizon := today.AddDate(0, 0, policy.HorizonDays); end.After(horizon) {
+		end = horizon
+	}
+	if !start.Before(end) {
+		return nil
+	}
+
+	plan, err := s.ratePlanRepo.GetByRoomType(ctx, policy.RoomType)
+	if err == utils.ErrRatePlanNotFound {
+		// El plan se borró después de crear la política: no hay dónde escribir
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	capacity, err := s.roomRepo.CountByType(ctx, policy.RoomType)
+	if err != nil {
+		return err
+	}
+	from, to := start.Format(domain.DateLayout), end.Format(domain.DateLayout)
+	counts, err := s.occupancyRepo.CountByNight(ctx, policy.RoomType, from, to)
+	if err != nil {
+		return err
+	}
+
+	overrides := []domain.RateOverride{}
+	for night := start; night.Before(end); night = night.AddDate(0, 0, 1) {
+		date := night.Format(domain.DateLayout)
+		occupancy := occupancyPercent(counts[date], capacity)
+		priced := dynamicRate(policy, staticRate(plan, night), occupancy, daysBetween(today, night), date)
+		overrides = append(overrides, domain.RateOverride{
+			RatePlanID: plan.ID,
+			Date:       date,
+			Rate:       priced.Rate,
+			Occupancy:  priced.Occupancy,
+		})
+	}
+	return s.ratePlanRepo.SaveOverrides(ctx, overrides)
+}
+
+// recomputeAfterChange recalcula el horizonte del tipo después de un cambio
+// en su política o en su plan de tarifas
+func (s *DynamicPricingService) recomputeAfterChange(ctx context.Context, roomType domain.RoomType) {
+	policy, err := s.policyRepo.GetByRoomType(ctx, roomType)
+	if err == utils.ErrPricingPolicyNotFound {
+		s.clearOverrides(ctx, roomType)
+		return
+	}
+	if err != nil {
+		log.Printf("⚠️  Error recalculando precios de %s: %v", roomType, err)
+		return
+	}
+	if !policy.Enabled {
+		s.clearOverrides(ctx, roomType)
+		return
+	}
+
+	today := pricingToday()
+	if err := s.recompute(ctx, policy, today, today.AddDate(0, 0, policy.HorizonDays)); err != nil {
+		log.Printf("⚠️  Error recalculando precios de %s: %v", roomType, err)
+	}
+}
+
+// clearOverrides devuelve las noches del tipo a la tarifa del plan
+func (s *DynamicPricingService) clearOverrides(ctx context.Context, roomType domain.RoomType) {
+	plan, err := s.ratePlanRepo.GetByRoomType(ctx, roomType)
+	if err == utils.ErrRatePlanNotFound {
+		return
+	}
+	if err == nil {
+		err = s.ratePlanRepo.DeleteOverrides(ctx, plan.ID)
+	}
+	if err != nil {
+		log.Printf("⚠️  Error borrando precios dinámicos de %s: %v", roomType, err)
+	}
+}
+
+func (s *DynamicPricingService) requireRatePlan(ctx context.Context, roomType domain.RoomType) error {
+	_, err := s.ratePlanRepo.GetByRoomType(ctx, roomType)
+	if err == utils.ErrRatePlanNotFound {
+		return utils.ErrRatePlanRequired
+	}
+	return err
+}
+
+// staticRate es la tarifa del plan para la noche, sin precios dinámicos
+func staticRate(plan *domain.RatePlan, night time.Time) float64 {
+	return priceStay(plan, nil, 0, night, night.AddDate(0, 0, 1)).Nights[0].Rate
+}
+
+// dynamicRate aplica los escalones de la política a la tarifa base
+func dynamicRate(policy *domain.DynamicPricingPolicy, base, occupancy float64, daysAhead int, date string) domain.SimulatedNight {
+	night := domain.SimulatedNight{
+		Date:                date,
+		BaseRate:            base,
+		Occupancy:           occupancy,
+		OccupancyMultiplier: 1,
+		DaysAhead:           daysAhead,
+		LeadTimeMultiplier:  1,
+	}
+
+	// OccupancyTiers está ordenado por MinOccupancy: gana el último alcanzado
+	for _, tier := range policy.OccupancyTiers {
+		if occupancy >= tier.MinOccupancy {
+			night.OccupancyMultiplier = tier.Multiplier
+		}
+	}
+	for _, tier := range policy.LeadTimeTiers {
+		if tier.Matches(daysAhead) {
+			night.LeadTimeMultiplier = tier.Multiplier
+			break
+		}
+	}
+
+	rate := base * night.OccupancyMultiplier * night.LeadTimeMultiplier
+	if policy.FloorRate > 0 && rate < policy.FloorRate {
+		rate = policy.FloorRate
+		night.Bound = "floor"
+	}
+	if policy.CeilingRate > 0 && rate > policy.CeilingRate {
+		rate = policy.CeilingRate
+		night.Bound = "ceiling"
+	}
+	night.Rate = roundMoney(rate)
+	return night
+}
+
+// occupancyPercent devuelve la ocupación de una noche en porcentaje (0-100)
+func occupancyPercent(booked, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	percent := float64(booked) / float64(capacity) * 100
+	return math.Min(100, math.Round(percent*100)/100)
+}
+
+// pricingToday es la fecha de hoy a medianoche UTC, comparable con las
+// noches parseadas de YYYY-MM-DD
+func pricingToday() time.Time {
+	today, _ := time.Parse(domain.DateLayout, time.Now().Format(domain.DateLayout))
+	return today
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// buildPricingPolicy valida la solicitud y ordena los escalones
+func buildPricingPolicy(roomType domain.RoomType, req domain.PricingRulesRequest) (*domain.DynamicPricingPolicy, error) {
+	policy := &domain.DynamicPricingPolicy{
+		RoomType:       roomType,
+		Enabled:        true,
+		HorizonDays:    req.HorizonDays,
+		FloorRate:      req.FloorRate,
+		CeilingRate:    req.CeilingRate,
+		OccupancyTiers: make([]domain.OccupancyTier, 0, len(req.OccupancyTiers)),
+		LeadTimeTiers:  make([]domain.LeadTimeTier, 0, len(req.LeadTimeTiers)),
+	}
+	if req.Enabled != nil {
+		policy.Enabled = *req.Enabled
+	}
+	if policy.HorizonDays == 0 {
+		policy.HorizonDays = 90
+	}
+	if policy.FloorRate > 0 && policy.CeilingRate > 0 && policy.FloorRate > policy.CeilingRate {
+		return nil, utils.ErrInvalidPricingBounds
+	}
+
+	seen := make(map[float64]bool, len(req.OccupancyTiers))
+	for _, tier := range req.OccupancyTiers {
+		if seen[tier.MinOccupancy] {
+			return nil, utils.ErrDuplicateOccupancyTier
+		}
+		seen[tier.MinOccupancy] = true
+		policy.OccupancyTiers = append(policy.OccupancyTiers, domain.OccupancyTier{
+			MinOccupancy: tier.MinOccupancy,
+			Multiplier:   tier.Multiplier,
+		})
+	}
+	sort.Slice(policy.OccupancyTiers, func(i, j int) bool {
+		return policy.OccupancyTiers[i].MinOccupancy < policy.OccupancyTiers[j].MinOccupancy
+	})
+
+	for _, tier := range req.LeadTimeTiers {
+		if tier.MaxDaysAhead != nil && *tier.MaxDaysAhead < tier.MinDaysAhead {
+			return nil, utils.ErrOverlappingLeadTimeTiers
+		}
+		policy.LeadTimeTiers = append(policy.LeadTimeTiers, domain.LeadTimeTier{
+			MinDaysAhead: tier.MinDaysAhead,
+			MaxDaysAhead: tier.MaxDaysAhead,
+			Multiplier:   tier.Multiplier,
+		})
+	}
+	sort.Slice(policy.LeadTimeTiers, func(i, j int) bool {
+		return policy.LeadTimeTiers[i].MinDaysAhead < policy.LeadTimeTiers[j].MinDaysAhead
+	})
+	for i := 1; i < len(policy.LeadTimeTiers); i++ {
+		previous := policy.LeadTimeTiers[i-1]
+		if previous.MaxDaysAhead == nil || *previous.MaxDaysAhead >= policy.LeadTimeTiers[i].MinDaysAhead {
+			return nil, utils.ErrOverlappingLeadTimeTiers
+		}
+	}
+
+	return policy, nil
+}
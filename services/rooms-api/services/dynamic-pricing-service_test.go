@@ -0,0 +1,249 @@
+package services
+
+import (
+	"errors"
+	"rooms-api/domain"
+	"rooms-api/utils"
+	"testing"
+)
+
+func TestDynamicRate(t *testing.T) {
+	tiers := func(floor, ceiling float64) *domain.DynamicPricingPolicy {
+		return &domain.DynamicPricingPolicy{
+			FloorRate:   floor,
+			CeilingRate: ceiling,
+			OccupancyTiers: []domain.OccupancyTier{
+				{MinOccupancy: 50, Multiplier: 1.1},
+				{MinOccupancy: 80, Multiplier: 1.3},
+			},
+			LeadTimeTiers: []domain.LeadTimeTier{
+				{MinDaysAhead: 0, MaxDaysAhead: intPtr(7), Multiplier: 1.2},
+				{MinDaysAhead: 30, Multiplier: 0.9},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		policy        *domain.DynamicPricingPolicy
+		occupancy     float64
+		daysAhead     int
+		wantOccupancy float64
+		wantLeadTime  float64
+		wantRate      float64
+		wantBound     string
+	}{
+		{
+			name:          "sin escalones alcanzados queda la tarifa base",
+			policy:        tiers(0, 0),
+			occupancy:     40,
+			daysAhead:     10,
+			wantOccupancy: 1,
+			wantLeadTime:  1,
+			wantRate:      100,
+		},
+		{
+			name:          "el escalón de ocupación incluye su mínimo",
+			policy:        tiers(0, 0),
+			occupancy:     50,
+			daysAhead:     10,
+			wantOccupancy: 1.1,
+			wantLeadTime:  1,
+			wantRate:      110,
+		},
+		{
+			name:          "gana el escalón de ocupación más alto alcanzado",
+			policy:        tiers(0, 0),
+			occupancy:     95,
+			daysAhead:     10,
+			wantOccupancy: 1.3,
+			wantLeadTime:  1,
+			wantRate:      130,
+		},
+		{
+			name:          "el escalón de anticipación incluye su máximo",
+			policy:        tiers(0, 0),
+			occupancy:     40,
+			daysAhead:     7,
+			wantOccupancy: 1,
+			wantLeadTime:  1.2,
+			wantRate:      120,
+		},
+		{
+			name:          "entre escalones de anticipación no hay ajuste",
+			policy:        tiers(0, 0),
+			occupancy:     40,
+			daysAhead:     8,
+			wantOccupancy: 1,
+			wantLeadTime:  1,
+			wantRate:      100,
+		},
+		{
+			name:          "escalón de anticipación sin máximo",
+			policy:        tiers(0, 0),
+			occupancy:     40,
+			daysAhead:     200,
+			wantOccupancy: 1,
+			wantLeadTime:  0.9,
+			wantRate:      90,
+		},
+		{
+			name:          "ocupación y anticipación se multiplican",
+			policy:        tiers(0, 0),
+			occupancy:     85,
+			daysAhead:     3,
+			wantOccupancy: 1.3,
+			wantLeadTime:  1.2,
+			wantRate:      156,
+		},
+		{
+			name:          "floor por encima del precio calculado",
+			policy:        tiers(120, 0),
+			occupancy:     40,
+			daysAhead:     200,
+			wantOccupancy: 1,
+			wantLeadTime:  0.9,
+			wantRate:      120,
+			wantBound:     "floor",
+		},
+		{
+			name:          "ceiling por debajo del precio calculado",
+			policy:        tiers(0, 140),
+			occupancy:     85,
+			daysAhead:     3,
+			wantOccupancy: 1.3,
+			wantLeadTime:  1.2,
+			wantRate:      140,
+			wantBound:     "ceiling",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			night := dynamicRate(tt.policy, 100, tt.occupancy, tt.daysAhead, "2026-03-02")
+
+			if night.OccupancyMultiplier != tt.wantOccupancy {
+				t.Errorf("multiplicador de ocupación = %v, se esperaba %v", night.OccupancyMultiplier, tt.wantOccupancy)
+			}
+			if night.LeadTimeMultiplier != tt.wantLeadTime {
+				t.Errorf("multiplicador de anticipación = %v, se esperaba %v", night.LeadTimeMultiplier, tt.wantLeadTime)
+			}
+			if night.Rate != tt.wantRate {
+				t.Errorf("precio = %v, se esperaba %v", night.Rate, tt.wantRate)
+			}
+			if night.Bound != tt.wantBound {
+				t.Errorf("límite = %q, se esperaba %q", night.Bound, tt.wantBound)
+			}
+		})
+	}
+}
+
+func TestBuildPricingPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.PricingRulesRequest
+		wantErr error
+	}{
+		{
+			name: "floor sin ceiling",
+			req:  domain.PricingRulesRequest{FloorRate: 80},
+		},
+		{
+			name:    "floor mayor que ceiling",
+			req:     domain.PricingRulesRequest{FloorRate: 200, CeilingRate: 150},
+			wantErr: utils.ErrInvalidPricingBounds,
+		},
+		{
+			name: "dos escalones con la misma ocupación",
+			req: domain.PricingRulesRequest{
+				OccupancyTiers: []domain.OccupancyTierRequest{
+					{MinOccupancy: 80, Multiplier: 1.2},
+					{MinOccupancy: 80, Multiplier: 1.4},
+				},
+			},
+			wantErr: utils.ErrDuplicateOccupancyTier,
+		},
+		{
+			name: "escalones de anticipación contiguos",
+			req: domain.PricingRulesRequest{
+				LeadTimeTiers: []domain.LeadTimeTierRequest{
+					{MinDaysAhead: 8, Multiplier: 0.9},
+					{MinDaysAhead: 0, MaxDaysAhead: intPtr(7), Multiplier: 1.2},
+				},
+			},
+		},
+		{
+			name: "escalones de anticipación superpuestos",
+			req: domain.PricingRulesRequest{
+				LeadTimeTiers: []domain.LeadTimeTierRequest{
+					{MinDaysAhead: 0, MaxDaysAhead: intPtr(10), Multiplier: 1.2},
+					{MinDaysAhead: 10, MaxDaysAhead: intPtr(20), Multiplier: 1.1},
+				},
+			},
+			wantErr: utils.ErrOverlappingLeadTimeTiers,
+		},
+		{
+			name: "escalón sin máximo seguido de otro",
+			req: domain.PricingRulesRequest{
+				LeadTimeTiers: []domain.LeadTimeTierRequest{
+					{MinDaysAhead: 0, Multiplier: 1.2},
+					{MinDaysAhead: 30, Multiplier: 0.9},
+				},
+			},
+			wantErr: utils.ErrOverlappingLeadTimeTiers,
+		},
+		{
+			name: "escalón con máximo menor que el mínimo",
+			req: domain.PricingRulesRequest{
+				LeadTimeTiers: []domain.LeadTimeTierRequest{
+					{MinDaysAhead: 10, MaxDaysAhead: intPtr(5), Multiplier: 1.2},
+				},
+			},
+			wantErr: utils.ErrOverlappingLeadTimeTiers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildPricingPolicy(domain.RoomTypeDouble, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildPricingPolicyDefaultsAndOrder(t *testing.T) {
+	policy, err := buildPricingPolicy(domain.RoomTypeDouble, domain.PricingRulesRequest{
+		OccupancyTiers: []domain.OccupancyTierRequest{
+			{MinOccupancy: 80, Multiplier: 1.3},
+			{MinOccupancy: 50, Multiplier: 1.1},
+		},
+		LeadTimeTiers: []domain.LeadTimeTierRequest{
+			{MinDaysAhead: 30, Multiplier: 0.9},
+			{MinDaysAhead: 0, MaxDaysAhead: intPtr(7), Multiplier: 1.2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if !policy.Enabled || policy.HorizonDays != 90 {
+		t.Errorf("valores por defecto: enabled %v, horizonte %d", policy.Enabled, policy.HorizonDays)
+	}
+	if policy.OccupancyTiers[0].MinOccupancy != 50 || policy.OccupancyTiers[1].MinOccupancy != 80 {
+		t.Errorf("escalones de ocupación desordenados: %+v", policy.OccupancyTiers)
+	}
+	if policy.LeadTimeTiers[0].MinDaysAhead != 0 || policy.LeadTimeTiers[1].MinDaysAhead != 30 {
+		t.Errorf("escalones de anticipación desordenados: %+v", policy.LeadTimeTiers)
+	}
+
+	disabled := false
+	policy, err = buildPricingPolicy(domain.RoomTypeDouble, domain.PricingRulesRequest{Enabled: &disabled, HorizonDays: 30})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if policy.Enabled || policy.HorizonDays != 30 {
+		t.Errorf("enabled %v, horizonte %d; se esperaba false y 30", policy.Enabled, policy.HorizonDays)
+	}
+}
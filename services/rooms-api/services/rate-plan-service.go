@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"math"
+	"rooms-api/domain"
+	"rooms-api/repositories"
+	"rooms-api/utils"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxRateNights limita GET /rooms/:id/rates a un año de noches
+const maxRateNights = 366
+
+// RatePlanService administra los planes de tarifas y calcula el precio de
+// cada noche. La precedencia es: precio dinámico, temporada, tarifa base
+// del plan y, si el tipo de habitación no tiene plan, el Price de la
+// habitación. Sobre la temporada o la tarifa base se aplica el ajuste de fin
+// de semana del plan; el precio dinámico ya lo incluye.
+type RatePlanService struct {
+	pricing      *DynamicPricingService
+	ratePlanRepo repositories.RatePlanRepository
+	roomRepo     *repositories.RoomRepository
+	tx           repositories.TxRunner
+}
+
+func NewRatePlanService(pricing *DynamicPricingService, ratePlanRepo repositories.RatePlanRepository, roomRepo *repositories.RoomRepository, tx repositories.TxRunner) *RatePlanService {
+	return &RatePlanService{
+		pricing:      pricing,
+		ratePlanRepo: ratePlanRepo,
+		roomRepo:     roomRepo,
+		tx:           tx,
+	}
+}
+
+func (s *RatePlanService) List(ctx context.Context) ([]domain.RatePlan, error) {
+	return s.ratePlanRepo.List(ctx)
+}
+
+func (s *RatePlanService) Get(ctx context.Context, id uint) (*domain.RatePlan, error) {
+	return s.ratePlanRepo.GetByID(ctx, id)
+}
+
+func (s *RatePlanService) Create(ctx context.Context, req domain.RatePlanRequest) (*domain.RatePlan, error) {
+	plan, err := buildRatePlan(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ratePlanRepo.Create(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	s.pricing.recomputeAfterChange(ctx, plan.RoomType)
+	return plan, nil
+}
+
+// Update reemplaza el plan completo, temporadas y descuentos incluidos, y
+// recalcula los precios dinámicos con las tarifas nuevas
+func (s *RatePlanService) Update(ctx context.Context, id uint, req domain.RatePlanRequest) (*domain.RatePlan, error) {
+	plan, err := buildRatePlan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Run(ctx, func(ctx context.Context) error {
+		if _, err := s.ratePlanRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		plan.ID = id
+		return s.ratePlanRepo.Replace(ctx, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.pricing.recomputeAfterChange(ctx, plan.RoomType)
+	return s.ratePlanRepo.GetByID(ctx, id)
+}
+
+func (s *RatePlanService) Delete(ctx context.Context, id uint) error {
+	return s.tx.Run(ctx, func(ctx context.Context) error {
+		return s.ratePlanRepo.Delete(ctx, id)
+	})
+}
+
+// RoomRates devuelve el precio de cada noche entre from (llegada) y to
+// (salida), ambas en formato YYYY-MM-DD
+func (s *RatePlanService) RoomRates(ctx context.Context, roomID uint, from, to string) (*domain.RoomRatesResponse, error) {
+	start, end, err := parseStay(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.ratePlanRepo.GetByRoomType(ctx, room.Type)
+	if err == utils.ErrRatePlanNotFound {
+		plan = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var overrides map[string]domain.RateOverride
+	if plan != nil {
+		overrides, err = s.ratePlanRepo.Overrides(ctx, plan.ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rates := priceStay(plan, overrides, room.Price, start, end)
+	rates.RoomID = room.ID
+	rates.RoomType = room.Type
+	return rates, nil
+}
+
+// parseStay valida el rango de una estadía; to no se incluye
+func parseStay(from, to string) (time.Time, time.Time, error) {
+	start, err := time.Parse(domain.DateLayout, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, utils.ErrInvalidDate
+	}
+	end, err := time.Parse(domain.DateLayout, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, utils.ErrInvalidDate
+	}
+
+	nights := int(end.Sub(start).Hours() / 24)
+	if nights < 1 || nights > maxRateNights {
+		return time.Time{}, time.Time{}, utils.ErrInvalidDateRange
+	}
+	return start, end, nil
+}
+
+// priceStay calcula las noches de [start, end). Sin plan cada noche vale
+// fallback y no hay estadía mínima ni descuentos. overrides puede ser nil.
+func priceStay(plan *domain.RatePlan, overrides map[string]domain.RateOverride, fallback float64, start, end time.Time) *domain.RoomRatesResponse {
+	rates := &domain.RoomRatesResponse{
+		From:    start.Format(domain.DateLayout),
+		To:      end.Format(domain.DateLayout),
+		Nights:  []domain.NightlyRate{},
+		MinStay: 1,
+	}
+	if plan != nil {
+		rates.RatePlanID = &plan.ID
+	}
+
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		night := domain.NightlyRate{
+			Date:    date.Format(domain.DateLayout),
+			Rate:    fallback,
+			Weekend: isWeekendNight(date),
+		}
+
+		if plan != nil {
+			night.Rate = plan.BaseRate
+			minStay := plan.MinStay
+			for _, season := range plan.Seasons {
+				if !season.Contains(night.Date) {
+					continue
+				}
+				night.Rate = season.Rate
+				night.Season = season.Name
+				// La estadía mínima de la temporada reemplaza la del plan
+				if season.MinStay != nil {
+					minStay = *season.MinStay
+				}
+				break
+			}
+			// Rige la estadía mínima más exigente de las noches pedidas
+			if minStay > rates.MinStay {
+				rates.MinStay = minStay
+			}
+			if override, ok := overrides[night.Date]; ok {
+				night.Rate = override.Rate
+				night.Dynamic = true
+			} else if night.Weekend && plan.WeekendAdjustment != 0 {
+				night.Rate = night.Rate * (1 + plan.WeekendAdjustment/100)
+			}
+		}
+
+		night.Rate = roundMoney(night.Rate)
+		rates.Subtotal += night.Rate
+		rates.Nights = append(rates.Nights, night)
+	}
+
+	rates.Subtotal = roundMoney(rates.Subtotal)
+	rates.MinStayMet = len(rates.Nights) >= rates.MinStay
+	if plan != nil {
+		// StayDiscounts está ordenado por MinNights: gana el último alcanzado
+		for _, discount := range plan.StayDiscounts {
+			if len(rates.Nights) >= discount.MinNights {
+				rates.DiscountPercent = discount.Percent
+			}
+		}
+	}
+	rates.Discount = roundMoney(rates.Subtotal * rates.DiscountPercent / 100)
+	rates.Total = roundMoney(rates.Subtotal - rates.Discount)
+	return rates
+}
+
+// isWeekendNight indica si la noche es de viernes o sábado
+func isWeekendNight(date time.Time) bool {
+	return date.Weekday() == time.Friday || date.Weekday() == time.Saturday
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// buildRatePlan valida la solicitud y normaliza fechas y orden de las reglas
+func buildRatePlan(req domain.RatePlanRequest) (*domain.RatePlan, error) {
+	plan := &domain.RatePlan{
+		RoomType:          req.RoomType,
+		Name:              strings.TrimSpace(req.Name),
+		BaseRate:          req.BaseRate,
+		WeekendAdjustment: req.WeekendAdjustment,
+		MinStay:           req.MinStay,
+		Seasons:           make([]domain.RateSeason, 0, len(req.Seasons)),
+		StayDiscounts:     make([]domain.StayDiscount, 0, len(req.StayDiscounts)),
+	}
+	if plan.MinStay == 0 {
+		plan.MinStay = 1
+	}
+
+	for _, season := range req.Seasons {
+		start, err := time.Parse(domain.DateLayout, season.StartDate)
+		if err != nil {
+			return nil, utils.ErrInvalidDate
+		}
+		end, err := time.Parse(domain.DateLayout, season.EndDate)
+		if err != nil {
+			return nil, utils.ErrInvalidDate
+		}
+		if end.Before(start) {
+			return nil, utils.ErrInvalidSeason
+		}
+		plan.Seasons = append(plan.Seasons, domain.RateSeason{
+			Name:      strings.TrimSpace(season.Name),
+			StartDate: start.Format(domain.DateLayout),
+			EndDate:   end.Format(domain.DateLayout),
+			Rate:      season.Rate,
+			MinStay:   season.MinStay,
+		})
+	}
+	sort.Slice(plan.Seasons, func(i, j int) bool {
+		return plan.Seasons[i].StartDate < plan.Seasons[j].StartDate
+	})
+	for i := 1; i < len(plan.Seasons); i++ {
+		if plan.Seasons[i].StartDate <= plan.Seasons[i-1].EndDate {
+			return nil, utils.ErrOverlappingSeasons
+		}
+	}
+
+	seen := make(map[int]bool, len(req.StayDiscounts))
+	for _, discount := range req.StayDiscounts {
+		if seen[discount.MinNights] {
+			return nil, utils.ErrDuplicateStayDiscount
+		}
+		seen[discount.MinNights] = true
+		plan.StayDiscounts = append(plan.StayDiscounts, domain.StayDiscount{
+			MinNights: discount.MinNights,
+			Percent:   discount.Percent,
+		})
+	}
+	sort.Slice(plan.StayDiscounts, func(i, j int) bool {
+		return plan.StayDiscounts[i].MinNights < plan.StayDiscounts[j].MinNights
+	})
+
+	return plan, nil
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"errors"
+	"rooms-api/domain"
+	"rooms-api/utils"
+	"testing"
+	"time"
+)
+
+func mustDate(t *testing.T, value string) time.Time {
+	t.Helper()
+	date, err := time.Parse(domain.DateLayout, value)
+	if err != nil {
+		t.Fatalf("fecha inválida %q: %v", value, err)
+	}
+	return date
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+// Marzo de 2026: el 2 es lunes, el 6 viernes y el 7 sábado
+func TestPriceStay(t *testing.T) {
+	tests := []struct {
+		name            string
+		plan            *domain.RatePlan
+		overrides       map[string]domain.RateOverride
+		fallback        float64
+		from, to        string
+		wantRates       []float64
+		wantSubtotal    float64
+		wantDiscountPct float64
+		wantTotal       float64
+		wantMinStay     int
+		wantMinStayMet  bool
+	}{
+		{
+			name:           "sin plan usa el precio de la habitación",
+			fallback:       80,
+			from:           "2026-03-05",
+			to:             "2026-03-08",
+			wantRates:      []float64{80, 80, 80},
+			wantSubtotal:   240,
+			wantTotal:      240,
+			wantMinStay:    1,
+			wantMinStayMet: true,
+		},
+		{
+			name: "la temporada incluye su primera y su última noche",
+			plan: &domain.RatePlan{
+				BaseRate: 100,
+				MinStay:  1,
+				Seasons: []domain.RateSeason{
+					{Name: "Alta", StartDate: "2026-03-03", EndDate: "2026-03-04", Rate: 150},
+				},
+			},
+			from:           "2026-03-02",
+			to:             "2026-03-06",
+			wantRates:      []float64{100, 150, 150, 100},
+			wantSubtotal:   500,
+			wantTotal:      500,
+			wantMinStay:    1,
+			wantMinStayMet: true,
+		},
+		{
+			name:           "ajuste de fin de semana en viernes y sábado",
+			plan:           &domain.RatePlan{BaseRate: 100, WeekendAdjustment: 20, MinStay: 1},
+			from:           "2026-03-05",
+			to:             "2026-03-09",
+			wantRates:      []float64{100, 120, 120, 100},
+			wantSubtotal:   440,
+			wantTotal:      440,
+			wantMinStay:    1,
+			wantMinStayMet: true,
+		},
+		{
+			name: "el ajuste de fin de semana se aplica sobre la temporada",
+			plan: &domain.RatePlan{
+				BaseRate:          100,
+				WeekendAdjustment: 20,
+				MinStay:           1,
+				Seasons: []domain.RateSeason{
+					{Name: "Alta", StartDate: "2026-03-06", EndDate: "2026-03-07", Rate: 150},
+				},
+			},
+			from:           "2026-03-05",
+			to:             "2026-03-07",
+			wantRates:      []float64{100, 180},
+			wantSubtotal:   280,
+			wantTotal:      280,
+			wantMinStay:    1,
+			wantMinStayMet: true,
+		},
+		{
+			name: "el precio dinámico reemplaza temporada y fin de semana",
+			plan: &domain.RatePlan{
+				BaseRate:          100,
+				WeekendAdjustment: 20,
+				MinStay:           1,
+				Seasons: []domain.RateSeason{
+					{Name: "Alta", StartDate: "2026-03-06", EndDate: "2026-03-07", Rate: 150},
+				},
+			},
+			overrides: map[string]domain.RateOverride{
+				"2026-03-06": {Date: "2026-03-06", Rate: 95},
+			},
+			from:           "2026-03-05",
+			to:             "2026-03-07",
+			wantRates:      []float64{100, 95},
+			wantSubtotal:   195,
+			wantTotal:      195,
+			wantMinStay:    1,
+			wantMinStayMet: true,
+		},
+		{
+			name: "descuento del escalón más alto alcanzado",
+			plan: &domain.RatePlan{
+				BaseRate: 100,
+				MinStay:  1,
+				StayDiscounts: []domain.StayDiscount{
+					{MinNights: 3, Percent: 5},
+					{MinNights: 7, Percent: 10},
+				},
+			},
+			from:            "2026-03-02",
+			to:              "2026-03-09",
+			wantRates:       []float64{100, 100, 100, 100, 100, 100, 100},
+			wantSubtotal:    700,
+			wantDiscountPct: 10,
+			wantTotal:       630,
+			wantMinStay:     1,
+			wantMinStayMet:  true,
+		},
+		{
+			name: "descuento entre dos escalones",
+			plan: &domain.RatePlan{
+				BaseRate: 100,
+				MinStay:  1,
+				StayDiscounts: []domain.StayDiscount{
+					{MinNights: 3, Percent: 5},
+					{MinNights: 7, Percent: 10},
+				},
+			},
+			from:            "2026-03-02",
+			to:              "2026-03-07",
+			wantRates:       []float64{100, 100, 100, 100, 100},
+			wantSubtotal:    500,
+			wantDiscountPct: 5,
+			wantTotal:       475,
+			wantMinStay:     1,
+			wantMinStayMet:  true,
+		},
+		{
+			name: "rige la estadía mínima más exigente de las noches",
+			plan: &domain.RatePlan{
+				BaseRate: 100,
+				MinStay:  2,
+				Seasons: []domain.RateSeason{
+					{Name: "Alta", StartDate: "2026-03-06", EndDate: "2026-03-07", Rate: 100, MinStay: intPtr(4)},
+				},
+			},
+			from:           "2026-03-05",
+			to:             "2026-03-08",
+			wantRates:      []float64{100, 100, 100},
+			wantSubtotal:   300,
+			wantTotal:      300,
+			wantMinStay:    4,
+			wantMinStayMet: false,
+		},
+		{
+			name: "la estadía mínima de la temporada reemplaza la del plan",
+			plan: &domain.RatePlan{
+				BaseRate: 100,
+				MinStay:  3,
+				Seasons: []domain.RateSeason{
+					{Name: "Baja", StartDate: "2026-03-02", EndDate: "2026-03-04", Rate: 100, MinStay: intPtr(1)},
+				},
+			},
+			from:           "2026-03-02",
+			to:             "2026-03-03",
+			wantRates:      []float64{100},
+			wantSubtotal:   100,
+			wantTotal:      100,
+			wantMinStay:    1,
+			wantMinStayMet: true,
+		},
+		{
+			name: "estadía que sale de la temporada vuelve al mínimo del plan",
+			plan: &domain.RatePlan{
+				BaseRate: 100,
+				MinStay:  3,
+				Seasons: []domain.RateSeason{
+					{Name: "Baja", StartDate: "2026-03-02", EndDate: "2026-03-03", Rate: 100, MinStay: intPtr(1)},
+				},
+			},
+			from:           "2026-03-03",
+			to:             "2026-03-05",
+			wantRates:      []float64{100, 100},
+			wantSubtotal:   200,
+			wantTotal:      200,
+			wantMinStay:    3,
+			wantMinStayMet: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates := priceStay(tt.plan, tt.overrides, tt.fallback, mustDate(t, tt.from), mustDate(t, tt.to))
+
+			if len(rates.Nights) != len(tt.wantRates) {
+				t.Fatalf("noches = %d, se esperaban %d", len(rates.Nights), len(tt.wantRates))
+			}
+			for i, night := range rates.Nights {
+				if night.Rate != tt.wantRates[i] {
+					t.Errorf("noche %s = %v, se esperaba %v", night.Date, night.Rate, tt.wantRates[i])
+				}
+			}
+			if rates.Subtotal != tt.wantSubtotal {
+				t.Errorf("subtotal = %v, se esperaba %v", rates.Subtotal, tt.wantSubtotal)
+			}
+			if rates.DiscountPercent != tt.wantDiscountPct {
+				t.Errorf("descuento = %v%%, se esperaba %v%%", rates.DiscountPercent, tt.wantDiscountPct)
+			}
+			if rates.Total != tt.wantTotal {
+				t.Errorf("total = %v, se esperaba %v", rates.Total, tt.wantTotal)
+			}
+			if rates.MinStay != tt.wantMinStay || rates.MinStayMet != tt.wantMinStayMet {
+				t.Errorf("estadía mínima = %d (cumplida %v), se esperaba %d (cumplida %v)",
+					rates.MinStay, rates.MinStayMet, tt.wantMinStay, tt.wantMinStayMet)
+			}
+		})
+	}
+}
+
+func TestBuildRatePlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.RatePlanRequest
+		wantErr error
+	}{
+		{
+			name: "temporadas contiguas",
+			req: domain.RatePlanRequest{
+				Seasons: []domain.RateSeasonRequest{
+					{Name: "Alta", StartDate: "2026-12-20", EndDate: "2027-01-06", Rate: 180},
+					{Name: "Media", StartDate: "2026-12-01", EndDate: "2026-12-19", Rate: 130},
+				},
+			},
+		},
+		{
+			name: "temporadas que comparten una noche",
+			req: domain.RatePlanRequest{
+				Seasons: []domain.RateSeasonRequest{
+					{Name: "Media", StartDate: "2026-12-01", EndDate: "2026-12-20", Rate: 130},
+					{Name: "Alta", StartDate: "2026-12-20", EndDate: "2027-01-06", Rate: 180},
+				},
+			},
+			wantErr: utils.ErrOverlappingSeasons,
+		},
+		{
+			name: "temporada que termina antes de empezar",
+			req: domain.RatePlanRequest{
+				Seasons: []domain.RateSeasonRequest{
+					{Name: "Alta", StartDate: "2026-12-20", EndDate: "2026-12-19", Rate: 180},
+				},
+			},
+			wantErr: utils.ErrInvalidSeason,
+		},
+		{
+			name: "fecha con formato inválido",
+			req: domain.RatePlanRequest{
+				Seasons: []domain.RateSeasonRequest{
+					{Name: "Alta", StartDate: "20/12/2026", EndDate: "2027-01-06", Rate: 180},
+				},
+			},
+			wantErr: utils.ErrInvalidDate,
+		},
+		{
+			name: "dos descuentos con el mismo mínimo de noches",
+			req: domain.RatePlanRequest{
+				StayDiscounts: []domain.StayDiscountRequest{
+					{MinNights: 7, Percent: 10},
+					{MinNights: 7, Percent: 15},
+				},
+			},
+			wantErr: utils.ErrDuplicateStayDiscount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildRatePlan(tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildRatePlanOrdersRules(t *testing.T) {
+	plan, err := buildRatePlan(domain.RatePlanRequest{
+		Seasons: []domain.RateSeasonRequest{
+			{Name: "Alta", StartDate: "2026-12-20", EndDate: "2027-01-06", Rate: 180},
+			{Name: "Media", StartDate: "2026-12-01", EndDate: "2026-12-19", Rate: 130},
+		},
+		StayDiscounts: []domain.StayDiscountRequest{
+			{MinNights: 7, Percent: 10},
+			{MinNights: 3, Percent: 5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if plan.MinStay != 1 {
+		t.Errorf("estadía mínima = %d, se esperaba 1 por defecto", plan.MinStay)
+	}
+	if plan.Seasons[0].Name != "Media" || plan.Seasons[1].Name != "Alta" {
+		t.Errorf("temporadas desordenadas: %s, %s", plan.Seasons[0].Name, plan.Seasons[1].Name)
+	}
+	if plan.StayDiscounts[0].MinNights != 3 || plan.StayDiscounts[1].MinNights != 7 {
+		t.Errorf("descuentos desordenados: %d, %d", plan.StayDiscounts[0].MinNights, plan.StayDiscounts[1].MinNights)
+	}
+}
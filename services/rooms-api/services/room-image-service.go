@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"rooms-api/domain"
+	"rooms-api/repositories"
+	"rooms-api/storage"
+	"rooms-api/utils"
+)
+
+// RoomImageService administra la galería de fotos de las habitaciones. Los
+// archivos van al BlobStore y los metadatos a MySQL; cada cambio publica un
+// room.updated con el snapshot para que search-api indexe las URLs.
+type RoomImageService struct {
+	rooms     *RoomService
+	roomRepo  *repositories.RoomRepository
+	imageRepo repositories.RoomImageRepository
+	blobs     storage.BlobStore
+	tx        repositories.TxRunner
+	maxBytes  int64
+	maxImages int
+}
+
+func NewRoomImageService(
+	rooms *RoomService,
+	roomRepo *repositories.RoomRepository,
+	imageRepo repositories.RoomImageRepository,
+	blobs storage.BlobStore,
+	tx repositories.TxRunner,
+	maxBytes int64,
+	maxImages int,
+) *RoomImageService {
+	return &RoomImageService{
+		rooms:     rooms,
+		roomRepo:  roomRepo,
+		imageRepo: imageRepo,
+		blobs:     blobs,
+		tx:        tx,
+		maxBytes:  maxBytes,
+		maxImages: maxImages,
+	}
+}
+
+// MaxBytes es el tamaño máximo aceptado para un archivo
+func (s *RoomImageService) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+func (s *RoomImageService) List(ctx context.Context, roomID uint) ([]domain.RoomImageResponse, error) {
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return s.rooms.roomToResponse(room).Images, nil
+}
+
+// Upload valida la imagen, genera las versiones web y miniatura y la agrega
+// al final de la galería. La primera imagen de una habitación es la portada.
+func (s *RoomImageService) Upload(ctx context.Context, roomID uint, data []byte, makeCover bool) (*domain.RoomImageResponse, error) {
+	if int64(len(data)) > s.maxBytes {
+		return nil, utils.ErrImageTooLarge
+	}
+	if _, err := s.roomRepo.GetByID(ctx, roomID); err != nil {
+		return nil, err
+	}
+
+	processed, err := utils.ProcessImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := newImagePrefix(roomID)
+	if err != nil {
+		return nil, err
+	}
+	image := &domain.RoomImage{
+		RoomID:       roomID,
+		ContentType:  processed.ContentType,
+		SizeBytes:    int64(len(data)),
+		Width:        processed.Width,
+		Height:       processed.Height,
+		OriginalKey:  prefix + "/original." + processed.Extension,
+		WebKey:       prefix + "/web.jpg",
+		ThumbnailKey: prefix + "/thumb.jpg",
+	}
+
+	// Los archivos se suben antes de la transacción; si algo falla se borran
+	if err := s.storeBlobs(ctx, image, data, processed); err != nil {
+		s.deleteBlobs(image)
+		return nil, err
+	}
+
+	err = s.tx.Run(ctx, func(ctx context.Context) error {
+		if err := s.roomRepo.Lock(ctx, roomID); err != nil {
+			return err
+		}
+		images, err := s.imageRepo.ListByRoom(ctx, roomID)
+		if err != nil {
+			return err
+		}
+		if len(images) >= s.maxImages {
+			return utils.ErrTooManyImages
+		}
+
+		image.Position = len(images)
+		image.IsCover = len(images) == 0
+		if err := s.imageRepo.Create(ctx, image); err != nil {
+			return err
+		}
+		if makeCover && !image.IsCover {
+			if err := s.imageRepo.SetCover(ctx, roomID, image.ID); err != nil {
+				return err
+			}
+			image.IsCover = true
+		}
+		return s.rooms.republish(ctx, roomID)
+	})
+	if err != nil {
+		s.deleteBlobs(image)
+		return nil, err
+	}
+
+	s.rooms.invalidateCache(ctx, roomID)
+	response := s.rooms.imageToResponse(*image)
+	return &response, nil
+}
+
+// Delete quita la imagen de la galería, compacta las posiciones y, si era
+// la portada, pasa la portada a la primera imagen restante
+func (s *RoomImageService) Delete(ctx context.Context, roomID, imageID uint) error {
+	var deleted *domain.RoomImage
+	err := s.tx.Run(ctx, func(ctx context.Context) error {
+		if err := s.roomRepo.Lock(ctx, roomID); err != nil {
+			return err
+		}
+		image, err := s.imageRepo.GetByID(ctx, roomID, imageID)
+		if err != nil {
+			return err
+		}
+		if err := s.imageRepo.Delete(ctx, image); err != nil {
+			return err
+		}
+
+		remaining, err := s.imageRepo.ListByRoom(ctx, roomID)
+		if err != nil {
+			return err
+		}
+		if err := s.imageRepo.SetPositions(ctx, roomID, imageIDs(remaining)); err != nil {
+			return err
+		}
+		if image.IsCover && len(remaining) > 0 {
+			if err := s.imageRepo.SetCover(ctx, roomID, remaining[0].ID); err != nil {
+				return err
+			}
+		}
+		deleted = image
+		return s.rooms.republish(ctx, roomID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.rooms.invalidateCache(ctx, roomID)
+	// Los archivos se borran recién después del commit
+	s.deleteBlobs(deleted)
+	return nil
+}
+
+// Reorder recibe todas las imágenes de la habitación en el nuevo orden
+func (s *RoomImageService) Reorder(ctx context.Context, roomID uint, ids []uint) ([]domain.RoomImageResponse, error) {
+	err := s.tx.Run(ctx, func(ctx context.Context) error {
+		if err := s.roomRepo.Lock(ctx, roomID); err != nil {
+			return err
+		}
+		images, err := s.imageRepo.ListByRoom(ctx, roomID)
+		if err != nil {
+			return err
+		}
+		if !sameImageSet(images, ids) {
+			return utils.ErrInvalidImageOrder
+		}
+		if err := s.imageRepo.SetPositions(ctx, roomID, ids); err != nil {
+			return err
+		}
+		return s.rooms.republish(ctx, roomID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.rooms.invalidateCache(ctx, roomID)
+	return s.List(ctx, roomID)
+}
+
+func (s *RoomImageService) SetCover(ctx context.Context, roomID, imageID uint) ([]domain.RoomImageResponse, error) {
+	err := s.tx.Run(ctx, func(ctx context.Context) error {
+		if err := s.roomRepo.Lock(ctx, roomID); err != nil {
+			return err
+		}
+		if _, err := s.imageRepo.GetByID(ctx, roomID, imageID); err != nil {
+			return err
+		}
+		if err := s.imageRepo.SetCover(ctx, roomID, imageID); err != nil {
+			return err
+		}
+		return s.rooms.republish(ctx, roomID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.rooms.invalidateCache(ctx, roomID)
+	return s.List(ctx, roomID)
+}
+
+// OpenMedia devuelve el archivo de una key de imagen para servirlo en /media
+func (s *RoomImageService) OpenMedia(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	body, contentType, err := s.blobs.Get(ctx, key)
+	if err == storage.ErrBlobNotFound {
+		return nil, "", utils.ErrImageNotFound
+	}
+	if err != nil {
+		log.Printf("⚠️  Error leyendo imagen %s: %v", key, err)
+		return nil, "", utils.ErrStorageError
+	}
+	return body, contentType, nil
+}
+
+func (s *RoomImageService) storeBlobs(ctx context.Context, image *domain.RoomImage, original []byte, processed *utils.ProcessedImage) error {
+	blobs := []struct {
+		key         string
+		body        []byte
+		contentType string
+	}{
+		{image.OriginalKey, original, processed.ContentType},
+		{image.WebKey, processed.Web, "image/jpeg"},
+		{image.ThumbnailKey, processed.Thumbnail, "image/jpeg"},
+	}
+	for _, blob := range blobs {
+		if err := s.blobs.Put(ctx, blob.key, blob.body, blob.contentType); err != nil {
+			log.Printf("⚠️  Error guardando imagen %s: %v", blob.key, err)
+			return utils.ErrStorageError
+		}
+	}
+	return nil
+}
+
+// deleteBlobs es best-effort: un archivo huérfano no afecta a la galería
+func (s *RoomImageService) deleteBlobs(image *domain.RoomImage) {
+	for _, key := range []string{image.OriginalKey, image.WebKey, image.ThumbnailKey} {
+		if err := s.blobs.Delete(context.Background(), key); err != nil {
+			log.Printf("⚠️  Error borrando imagen %s: %v", key, err)
+		}
+	}
+}
+
+// newImagePrefix genera un directorio nuevo por imagen: las URLs nunca se
+// reutilizan, así que se pueden cachear indefinidamente
+func newImagePrefix(roomID uint) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("error generando id de imagen: %w", err)
+	}
+	return fmt.Sprintf("rooms/%d/%s", roomID, hex.EncodeToString(b[:])), nil
+}
+
+func imageIDs(images []domain.RoomImage) []uint {
+	ids := make([]uint, len(images))
+	for i, image := range images {
+		ids[i] = image.ID
+	}
+	return ids
+}
+
+func sameImageSet(images []domain.RoomImage, ids []uint) bool {
+	if len(images) != len(ids) {
+		return false
+	}
+	pending := make(map[uint]bool, len(images))
+	for _, image := range images {
+		pending[image.ID] = true
+	}
+	for _, id := range ids {
+		if !pending[id] {
+			return false
+		}
+		delete(pending, id)
+	}
+	return true
+}
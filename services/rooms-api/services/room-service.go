@@ -3,31 +3,46 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/url"
 	"rooms-api/config"
 	"rooms-api/domain"
 	"rooms-api/events"
 	"rooms-api/repositories"
+	"rooms-api/storage"
+	"rooms-api/utils"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type RoomService struct {
 	roomRepo        *repositories.RoomRepository
-	publisher       *events.EventPublisher
+	amenityRepo     repositories.AmenityRepository
+	outbox          repositories.OutboxRepository
+	tx              repositories.TxRunner
 	cache           repositories.RoomCacheRepository
+	blobs           storage.BlobStore
 	searchAPIClient *config.SearchAPIClient // 🔹 NUEVO
 }
 
 func NewRoomService(
 	roomRepo *repositories.RoomRepository,
-	publisher *events.EventPublisher,
+	amenityRepo repositories.AmenityRepository,
+	outbox repositories.OutboxRepository,
+	tx repositories.TxRunner,
 	cache repositories.RoomCacheRepository,
+	blobs storage.BlobStore,
 	searchAPIClient *config.SearchAPIClient, // 🔹 NUEVO
 ) *RoomService {
 	return &RoomService{
 		roomRepo:        roomRepo,
-		publisher:       publisher,
+		amenityRepo:     amenityRepo,
+		outbox:          outbox,
+		tx:              tx,
 		cache:           cache,
+		blobs:           blobs,
 		searchAPIClient: searchAPIClient, // 🔹 NUEVO
 	}
 }
@@ -47,19 +62,34 @@ func (s *RoomService) CreateRoom(ctx context.Context, req domain.CreateRoomReque
 		HasMinibar:  req.HasMinibar,
 	}
 
-	err := s.roomRepo.Create(ctx, room)
+	// Los has_* se suman a las amenities pedidas y después se derivan de ellas
+	codes := applyAmenityFlags(req.Amenities, map[string]*bool{
+		domain.AmenityWifi:    &req.HasWifi,
+		domain.AmenityAC:      &req.HasAC,
+		domain.AmenityTV:      &req.HasTV,
+		domain.AmenityMinibar: &req.HasMinibar,
+	}, true)
+	amenities, err := s.amenityRepo.GetByCodes(ctx, codes)
 	if err != nil {
 		return nil, err
 	}
+	room.Amenities = amenities
+	room.SyncAmenityFlags()
 
-	err = s.cache.Set(ctx, room.ID, *s.roomToResponse(room))
+	// El evento se escribe en el outbox en la misma transacción que el alta
+	err = s.tx.Run(ctx, func(ctx context.Context) error {
+		if err := s.roomRepo.Create(ctx, room); err != nil {
+			return err
+		}
+		return s.enqueueEvent(ctx, domain.RoomEvent{EventType: domain.RoomEventCreated, Room: *s.roomToResponse(room)})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Publicar evento de creación
-	if s.publisher != nil {
-		go s.publisher.PublishRoomCreated(room)
+	err = s.cache.Set(ctx, room.ID, *s.roomToResponse(room))
+	if err != nil {
+		return nil, err
 	}
 
 	return s.roomToResponse(room), nil
@@ -93,6 +123,7 @@ func (s *RoomService) GetRoomByNumber(ctx context.Context, number string) (*doma
 
 // ✅ MODIFICADO: Ahora usa Search API en lugar de consulta directa a MySQL
 func (s *RoomService) GetRooms(ctx context.Context, filter domain.RoomFilter, page, limit int) (*domain.RoomListResponse, error) {
+	filter.Amenities = normalizeAmenityCodes(filter.Amenities)
 	if page < 1 {
 		page = 1
 	}
@@ -146,7 +177,8 @@ func (s *RoomService) hasComplexFilters(filter domain.RoomFilter) bool {
 	if filter.MinPrice != nil || filter.MaxPrice != nil {
 		filterCount++
 	}
-	if filter.HasWifi != nil || filter.HasAC != nil || filter.HasTV != nil || filter.HasMinibar != nil {
+	if filter.HasWifi != nil || filter.HasAC != nil || filter.HasTV != nil || filter.HasMinibar != nil ||
+		len(filter.Amenities) > 0 {
 		return true // Siempre usar Solr para filtros de amenities
 	}
 
@@ -155,15 +187,43 @@ func (s *RoomService) hasComplexFilters(filter domain.RoomFilter) bool {
 }
 
 func (s *RoomService) UpdateRoom(ctx context.Context, id uint, req domain.UpdateRoomRequest) (*domain.RoomResponse, error) {
-	// Obtener estado anterior para detectar cambios de status
-	oldRoom, err := s.roomRepo.GetByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-	oldStatus := oldRoom.Status
+	var room *domain.Room
+	err := s.tx.Run(ctx, func(ctx context.Context) error {
+		// Obtener estado anterior para detectar cambios de status
+		oldRoom, err := s.roomRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		oldStatus := oldRoom.Status
+
+		amenities, err := s.updatedAmenities(ctx, oldRoom, req)
+		if err != nil {
+			return err
+		}
 
-	// Actualizar
-	room, err := s.roomRepo.Update(ctx, id, req)
+		// Actualizar
+		room, err = s.roomRepo.Update(ctx, id, req, amenities)
+		if err != nil {
+			return err
+		}
+
+		// Evento de actualización con el snapshot completo
+		snapshot := *s.roomToResponse(room)
+		if err := s.enqueueEvent(ctx, domain.RoomEvent{EventType: domain.RoomEventUpdated, Room: snapshot}); err != nil {
+			return err
+		}
+
+		// Si cambió el status, evento específico (después del update)
+		if oldStatus == snapshot.Status {
+			return nil
+		}
+		return s.enqueueEvent(ctx, domain.RoomEvent{
+			EventType: domain.RoomEventStatusChanged,
+			Room:      snapshot,
+			OldStatus: oldStatus,
+			NewStatus: snapshot.Status,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -171,38 +231,25 @@ func (s *RoomService) UpdateRoom(ctx context.Context, id uint, req domain.Update
 	// Invalidar caché
 	_ = s.cache.Delete(ctx, room.ID)
 
-	// Publicar evento de actualización
-	if s.publisher != nil {
-		go s.publisher.PublishRoomUpdated(room)
-
-		// Si cambió el status, publicar evento específico
-		if oldStatus != room.Status {
-			go s.publisher.PublishRoomStatusChanged(room.ID, string(oldStatus), string(room.Status))
-		}
-	}
-
 	return s.roomToResponse(room), nil
 }
 
 func (s *RoomService) DeleteRoom(ctx context.Context, id uint) error {
-	// Obtener ID antes de eliminar
-	room, err := s.roomRepo.GetByID(ctx, id)
-	if err != nil {
-		return err
-	}
-
-	err = s.roomRepo.Delete(ctx, id)
+	// El evento lleva el último estado: reservations-api necesita tipo y
+	// capacidad para reubicar reservas aunque la habitación ya no se pueda consultar
+	err := s.tx.Run(ctx, func(ctx context.Context) error {
+		room, err := s.roomRepo.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.enqueueEvent(ctx, domain.RoomEvent{EventType: domain.RoomEventDeleted, Room: *s.roomToResponse(room)})
+	})
 	if err != nil {
 		return err
 	}
 
 	// Invalidar caché
-	_ = s.cache.Delete(ctx, room.ID)
-
-	// Publicar evento de eliminación
-	if s.publisher != nil {
-		go s.publisher.PublishRoomDeleted(room.ID)
-	}
+	_ = s.cache.Delete(ctx, id)
 
 	return nil
 }
@@ -214,10 +261,76 @@ func (s *RoomService) UpdateRoomStatus(ctx context.Context, id uint, status doma
 	return s.UpdateRoom(ctx, id, updateReq)
 }
 
+// SyncStatusFromReservation actualiza el estado operativo de la habitación
+// según el evento de reserva: check-in la ocupa, check-out (o el cierre
+// automático de la estadía) la libera, un no-show libera la reserva y una
+// reserva que empieza hoy la deja reservada; las que empiezan otro día las
+// marca ArrivalService. Nunca pisa un mantenimiento.
+func (s *RoomService) SyncStatusFromReservation(ctx context.Context, event domain.ReservationEvent) error {
+	today := time.Now().Format("2006-01-02")
+
+	switch event.EventType {
+	case domain.ReservationEventCheckedIn:
+		return s.transitionStatus(ctx, event.RoomID, domain.RoomStatusOccupied,
+			domain.RoomStatusAvailable, domain.RoomStatusReserved)
+	case domain.ReservationEventCheckedOut, domain.ReservationEventCompleted:
+		return s.transitionStatus(ctx, event.RoomID, domain.RoomStatusAvailable, domain.RoomStatusOccupied)
+	case domain.ReservationEventNoShow:
+		// El no-show se marca después del día de llegada: la habitación sigue reservada
+		return s.transitionStatus(ctx, event.RoomID, domain.RoomStatusAvailable, domain.RoomStatusReserved)
+	case domain.ReservationEventCreated, domain.ReservationEventConfirmed, domain.ReservationEventModified:
+		// Los holds (pending) no reservan la habitación hasta confirmarse. Una
+		// reserva que se adelanta a hoy se marca acá; las que se mueven a otro
+		// día las toma el barrido de llegadas con la ocupación actualizada.
+		if event.Status != "active" || event.StartDate != today {
+			return nil
+		}
+		return s.transitionStatus(ctx, event.RoomID, domain.RoomStatusReserved, domain.RoomStatusAvailable)
+	case domain.ReservationEventCanceled, domain.ReservationEventExpired:
+		if event.StartDate != today {
+			return nil
+		}
+		return s.transitionStatus(ctx, event.RoomID, domain.RoomStatusAvailable, domain.RoomStatusReserved)
+	case domain.ReservationEventRelocated:
+		if event.StartDate != today {
+			return nil
+		}
+		if event.PreviousRoomID != nil {
+			if err := s.transitionStatus(ctx, *event.PreviousRoomID, domain.RoomStatusAvailable, domain.RoomStatusReserved); err != nil {
+				return err
+			}
+		}
+		return s.transitionStatus(ctx, event.RoomID, domain.RoomStatusReserved, domain.RoomStatusAvailable)
+	}
+	return nil
+}
+
+// transitionStatus cambia el estado solo si el actual es uno de `from`.
+// UpdateRoomStatus invalida la caché y publica room.updated para search-api.
+func (s *RoomService) transitionStatus(ctx context.Context, roomID uint, to domain.RoomStatus, from ...domain.RoomStatus) error {
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err == utils.ErrRoomNotFound {
+		// La habitación fue dada de baja: no hay estado que sincronizar
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, status := range from {
+		if room.Status == status {
+			_, err := s.UpdateRoomStatus(ctx, roomID, to)
+			return err
+		}
+	}
+	return nil
+}
+
 // ✅ MODIFICADO: GetAvailableRooms ahora usa Search API
 func (s *RoomService) GetAvailableRooms(ctx context.Context, filter domain.RoomFilter, page, limit int) (*domain.RoomListResponse, error) {
 	availableStatus := domain.RoomStatusAvailable
 	filter.Status = &availableStatus
+	filter.Amenities = normalizeAmenityCodes(filter.Amenities)
 
 	// Siempre usar Search API para habitaciones disponibles (consulta frecuente)
 	return s.searchAPIClient.SearchRooms(filter, page, limit)
@@ -243,8 +356,104 @@ func (s *RoomService) SearchRooms(ctx context.Context, query string, page, limit
 	return s.searchAPIClient.SearchRooms(filter, page, limit)
 }
 
+// updatedAmenities calcula las amenities después del update: la lista
+// pedida (o la actual) con los has_* aplicados. nil si no cambian.
+func (s *RoomService) updatedAmenities(ctx context.Context, room *domain.Room, req domain.UpdateRoomRequest) ([]domain.Amenity, error) {
+	flags := map[string]*bool{
+		domain.AmenityWifi:    req.HasWifi,
+		domain.AmenityAC:      req.HasAC,
+		domain.AmenityTV:      req.HasTV,
+		domain.AmenityMinibar: req.HasMinibar,
+	}
+	if req.Amenities == nil && req.HasWifi == nil && req.HasAC == nil && req.HasTV == nil && req.HasMinibar == nil {
+		return nil, nil
+	}
+
+	codes := room.AmenityCodes()
+	if req.Amenities != nil {
+		codes = *req.Amenities
+	}
+	return s.amenityRepo.GetByCodes(ctx, applyAmenityFlags(codes, flags, false))
+}
+
+// applyAmenityFlags normaliza los códigos y agrega o quita las amenities
+// de los has_* presentes. Con onlyTrue los flags en false no quitan nada
+// (en el alta un false es el valor por defecto, no un pedido de quitar).
+func applyAmenityFlags(codes []string, flags map[string]*bool, onlyTrue bool) []string {
+	set := make(map[string]bool, len(codes))
+	for _, code := range normalizeAmenityCodes(codes) {
+		set[code] = true
+	}
+	for code, flag := range flags {
+		switch {
+		case flag == nil:
+		case *flag:
+			set[code] = true
+		case !onlyTrue:
+			delete(set, code)
+		}
+	}
+
+	result := make([]string, 0, len(set))
+	for code := range set {
+		result = append(result, code)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// normalizeAmenityCodes pasa a minúsculas, descarta vacíos y duplicados y
+// acepta listas separadas por comas (?amenities=wifi,tv)
+func normalizeAmenityCodes(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	result := []string{}
+	for _, raw := range codes {
+		for _, code := range strings.Split(raw, ",") {
+			code = strings.ToLower(strings.TrimSpace(code))
+			if code == "" || seen[code] {
+				continue
+			}
+			seen[code] = true
+			result = append(result, code)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// republish incrementa la versión de la habitación y encola room.updated
+// con el snapshot nuevo. Se llama dentro de la transacción del ctx cuando
+// cambia algo que no es una columna de la habitación (p. ej. la galería).
+func (s *RoomService) republish(ctx context.Context, roomID uint) error {
+	room, err := s.roomRepo.Touch(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	return s.enqueueEvent(ctx, domain.RoomEvent{EventType: domain.RoomEventUpdated, Room: *s.roomToResponse(room)})
+}
+
+// invalidateCache descarta la habitación cacheada; un fallo solo deja la
+// caché vieja hasta que venza el TTL
+func (s *RoomService) invalidateCache(ctx context.Context, roomID uint) {
+	_ = s.cache.Delete(ctx, roomID)
+}
+
+// enqueueEvent escribe el evento en el outbox dentro de la transacción del
+// ctx; el relay lo publica en RabbitMQ
+func (s *RoomService) enqueueEvent(ctx context.Context, event domain.RoomEvent) error {
+	message, err := events.NewRoomEventMessage(event)
+	if err != nil {
+		return err
+	}
+	if err := s.outbox.Enqueue(ctx, message); err != nil {
+		log.Printf("⚠️  Error escribiendo evento room.%s en el outbox: %v", event.EventType, err)
+		return utils.ErrDatabaseError
+	}
+	return nil
+}
+
 func (s *RoomService) roomToResponse(room *domain.Room) *domain.RoomResponse {
-	return &domain.RoomResponse{
+	response := &domain.RoomResponse{
 		ID:          room.ID,
 		Number:      room.Number,
 		Type:        room.Type,
@@ -257,13 +466,48 @@ func (s *RoomService) roomToResponse(room *domain.Room) *domain.RoomResponse {
 		HasAC:       room.HasAC,
 		HasTV:       room.HasTV,
 		HasMinibar:  room.HasMinibar,
+		Version:     room.Version,
 		CreatedAt:   room.CreatedAt,
 		UpdatedAt:   room.UpdatedAt,
 	}
+
+	response.Amenities = make([]domain.AmenityResponse, len(room.Amenities))
+	for i, amenity := range room.Amenities {
+		response.Amenities[i] = domain.AmenityResponse{
+			Code:     amenity.Code,
+			Name:     amenity.Name,
+			Icon:     amenity.Icon,
+			Category: amenity.Category,
+		}
+	}
+
+	response.Images = make([]domain.RoomImageResponse, len(room.Images))
+	for i, image := range room.Images {
+		response.Images[i] = s.imageToResponse(image)
+		if image.IsCover {
+			response.CoverImageURL = response.Images[i].WebURL
+			response.CoverThumbnailURL = response.Images[i].ThumbnailURL
+		}
+	}
+	return response
+}
+
+func (s *RoomService) imageToResponse(image domain.RoomImage) domain.RoomImageResponse {
+	return domain.RoomImageResponse{
+		ID:           image.ID,
+		URL:          s.blobs.URL(image.OriginalKey),
+		WebURL:       s.blobs.URL(image.WebKey),
+		ThumbnailURL: s.blobs.URL(image.ThumbnailKey),
+		Width:        image.Width,
+		Height:       image.Height,
+		Position:     image.Position,
+		IsCover:      image.IsCover,
+	}
 }
 
 // GetRoomsViaSearch busca habitaciones usando Search API (Solr)
 func (s *RoomService) GetRoomsViaSearch(ctx context.Context, filter domain.RoomFilter, page, limit int) (*domain.RoomListResponse, error) {
+	filter.Amenities = normalizeAmenityCodes(filter.Amenities)
 	if page < 1 {
 		page = 1
 	}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore guarda archivos binarios (las imágenes de las habitaciones)
+// identificados por una key con forma de path, p. ej. "rooms/12/abc/web.jpg"
+type BlobStore interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Get devuelve el contenido y su content type; ErrBlobNotFound si no existe
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	// Delete no falla si la key no existe
+	Delete(ctx context.Context, key string) error
+	// URL es la dirección pública desde la que el frontend descarga el blob
+	URL(key string) string
+}
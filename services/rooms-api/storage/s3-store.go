@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3SignedHeaders = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// S3Config configura un almacenamiento compatible con S3. Se usa
+// direccionamiento por path (endpoint/bucket/key), que es el que soportan
+// MinIO y la mayoría de los reemplazos locales.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL es la base pública de los objetos. Vacía, las imágenes se
+	// sirven a través de rooms-api (MediaBaseURL) y el bucket puede ser privado.
+	PublicURL    string
+	MediaBaseURL string
+}
+
+// s3Store implementa BlobStore firmando los requests con AWS Signature V4
+type s3Store struct {
+	cfg     S3Config
+	baseURL string
+	client  *http.Client
+}
+
+// NewS3Store crea el bucket si todavía no existe
+func NewS3Store(ctx context.Context, cfg S3Config) (BlobStore, error) {
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("s3 endpoint and bucket are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	baseURL := cfg.PublicURL
+	if baseURL == "" {
+		baseURL = cfg.MediaBaseURL
+	}
+	s := &s3Store{
+		cfg:     cfg,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+	if err := s.ensureBucket(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, s.objectPath(key), body, contentType)
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s.unexpected("put object", resp)
+	}
+	return nil
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.objectPath(key), nil, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get object: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, resp.Header.Get("Content-Type"), nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, "", ErrBlobNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, "", s.unexpected("get object", resp)
+	}
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.objectPath(key), nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.unexpected("delete object", resp)
+	}
+	return nil
+}
+
+func (s *s3Store) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+func (s *s3Store) ensureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "/"+s.cfg.Bucket, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to check bucket: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to check bucket %s: status %d", s.cfg.Bucket, resp.StatusCode)
+	}
+
+	resp, err = s.do(ctx, http.MethodPut, "/"+s.cfg.Bucket, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to create bucket: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s.unexpected("create bucket", resp)
+	}
+	return nil
+}
+
+func (s *s3Store) objectPath(key string) string {
+	return "/" + s.cfg.Bucket + "/" + key
+}
+
+func (s *s3Store) do(ctx context.Context, method, objectPath string, body []byte, contentType string) (*http.Response, error) {
+	canonicalURI := escapePath(objectPath)
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.Endpoint+canonicalURI, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, canonicalURI, body, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// sign agrega la firma AWS Signature V4 (header Authorization)
+func (s *s3Store) sign(req *http.Request, canonicalURI string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		"", // query string
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		s3SignedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.cfg.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKey, scope, s3SignedHeaders, signature))
+}
+
+func (s *s3Store) unexpected(operation string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("failed to %s: status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// escapePath codifica cada segmento como exige la firma de S3: todo lo que
+// no sea un carácter no reservado de RFC 3986
+func escapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -11,6 +11,35 @@ var (
 	ErrInvalidRoomData   = errors.New("datos de habitación inválidos")
 	ErrDatabaseError     = errors.New("error de base de datos")
 	ErrInvalidID         = errors.New("ID de habitación inválido")
+
+	ErrImageNotFound     = errors.New("imagen no encontrada")
+	ErrInvalidImage      = errors.New("la imagen debe ser JPEG, PNG, GIF o WebP")
+	ErrImageTooLarge     = errors.New("la imagen supera el tamaño máximo permitido")
+	ErrTooManyImages     = errors.New("la habitación alcanzó el máximo de imágenes")
+	ErrInvalidImageOrder = errors.New("el orden debe incluir todas las imágenes de la habitación una sola vez")
+	ErrStorageError      = errors.New("error de almacenamiento de imágenes")
+
+	ErrAmenityNotFound      = errors.New("amenity no encontrada")
+	ErrAmenityAlreadyExists = errors.New("ya existe una amenity con ese código")
+	ErrUnknownAmenity       = errors.New("código de amenity desconocido")
+	ErrInvalidAmenityCode   = errors.New("el código de amenity solo admite minúsculas, números, '-' y '_'")
+	ErrAmenityInUse         = errors.New("la amenity está asignada a habitaciones")
+	ErrLegacyAmenity        = errors.New("las amenities wifi, ac, tv y minibar no se pueden borrar")
+
+	ErrRatePlanNotFound      = errors.New("plan de tarifas no encontrado")
+	ErrRatePlanAlreadyExists = errors.New("ya existe un plan de tarifas para ese tipo de habitación")
+	ErrInvalidDate           = errors.New("las fechas deben tener formato YYYY-MM-DD")
+	ErrInvalidDateRange      = errors.New("rango de fechas inválido: to debe ser posterior a from y abarcar como máximo 366 noches")
+	ErrInvalidSeason         = errors.New("la temporada debe terminar en o después de su fecha de inicio")
+	ErrOverlappingSeasons    = errors.New("las temporadas de un plan no pueden superponerse")
+	ErrDuplicateStayDiscount = errors.New("hay más de un descuento con la misma cantidad mínima de noches")
+
+	ErrPricingPolicyNotFound      = errors.New("política de precios dinámicos no encontrada")
+	ErrPricingPolicyAlreadyExists = errors.New("ya existe una política de precios dinámicos para ese tipo de habitación")
+	ErrRatePlanRequired           = errors.New("el tipo de habitación necesita un plan de tarifas para usar precios dinámicos")
+	ErrInvalidPricingBounds       = errors.New("floor_rate no puede ser mayor que ceiling_rate")
+	ErrDuplicateOccupancyTier     = errors.New("hay más de un escalón con la misma ocupación mínima")
+	ErrOverlappingLeadTimeTiers   = errors.New("los escalones de anticipación no pueden superponerse y max_days_ahead no puede ser menor que min_days_ahead")
 )
 
 type ErrorResponse struct {
@@ -21,13 +50,19 @@ type ErrorResponse struct {
 
 func GetHTTPStatus(err error) int {
 	switch err {
-	case ErrRoomNotFound:
+	case ErrRoomNotFound, ErrImageNotFound, ErrAmenityNotFound, ErrRatePlanNotFound, ErrPricingPolicyNotFound:
 		return http.StatusNotFound
-	case ErrRoomAlreadyExists:
+	case ErrRoomAlreadyExists, ErrTooManyImages, ErrAmenityAlreadyExists, ErrAmenityInUse, ErrLegacyAmenity,
+		ErrRatePlanAlreadyExists, ErrPricingPolicyAlreadyExists, ErrRatePlanRequired:
 		return http.StatusConflict
-	case ErrInvalidRoomData, ErrInvalidID:
+	case ErrImageTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrInvalidRoomData, ErrInvalidID, ErrInvalidImage, ErrInvalidImageOrder,
+		ErrUnknownAmenity, ErrInvalidAmenityCode, ErrInvalidDate, ErrInvalidDateRange, ErrInvalidSeason,
+		ErrOverlappingSeasons, ErrDuplicateStayDiscount, ErrInvalidPricingBounds, ErrDuplicateOccupancyTier,
+		ErrOverlappingLeadTimeTiers:
 		return http.StatusBadRequest
-	case ErrDatabaseError:
+	case ErrDatabaseError, ErrStorageError:
 		return http.StatusInternalServerError
 	default:
 		return http.StatusInternalServerError
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	WebImageMaxSide = 1600
+	ThumbnailWidth  = 400
+	ThumbnailHeight = 300
+
+	// maxImagePixels evita decodificar imágenes gigantes (decompression bombs)
+	maxImagePixels   = 40_000_000
+	webJPEGQuality   = 85
+	thumbJPEGQuality = 80
+)
+
+// imageExtensions son los formatos aceptados y la extensión del original
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// ProcessedImage es una imagen validada con sus versiones derivadas en JPEG
+type ProcessedImage struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Web         []byte
+	Thumbnail   []byte
+}
+
+// ProcessImage valida el formato por contenido (no por el nombre del
+// archivo) y genera la versión web (lado mayor WebImageMaxSide) y la
+// miniatura (recorte centrado de ThumbnailWidth x ThumbnailHeight)
+func ProcessImage(data []byte) (*ProcessedImage, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, ErrInvalidImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		return nil, ErrInvalidImage
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	web, err := encodeJPEG(fit(src, WebImageMaxSide, WebImageMaxSide), webJPEGQuality)
+	if err != nil {
+		return nil, err
+	}
+	thumbnail, err := encodeJPEG(cover(src, ThumbnailWidth, ThumbnailHeight), thumbJPEGQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessedImage{
+		ContentType: contentType,
+		Extension:   extension,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Web:         web,
+		Thumbnail:   thumbnail,
+	}, nil
+}
+
+// fit reduce la imagen para que entre en maxW x maxH manteniendo la proporción
+func fit(src image.Image, maxW, maxH int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > maxW || h > maxH {
+		if w*maxH > h*maxW {
+			w, h = maxW, max(1, h*maxW/w)
+		} else {
+			w, h = max(1, w*maxH/h), maxH
+		}
+	}
+	return scale(src, b, w, h)
+}
+
+// cover recorta el centro de la imagen con la proporción w:h y lo escala a w x h
+func cover(src image.Image, w, h int) image.Image {
+	b := src.Bounds()
+	crop := b
+	if b.Dx()*h > b.Dy()*w {
+		cropW := b.Dy() * w / h
+		crop.Min.X = b.Min.X + (b.Dx()-cropW)/2
+		crop.Max.X = crop.Min.X + cropW
+	} else {
+		cropH := b.Dx() * h / w
+		crop.Min.Y = b.Min.Y + (b.Dy()-cropH)/2
+		crop.Max.Y = crop.Min.Y + cropH
+	}
+	return scale(src, crop, w, h)
+}
+
+// scale dibuja sobre fondo blanco: JPEG no tiene transparencia
+func scale(src image.Image, from image.Rectangle, w, h int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, from, xdraw.Over, nil)
+	return dst
+}
+
+func encodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,78 @@
+package consumers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"search-api/domain"
+)
+
+var ErrInvalidCloudEvent = errors.New("invalid cloud event")
+
+// decodeCloudEvent valida el envelope, que `dataschema` sea uno de los
+// esquemas aceptados y que `data` tenga los campos requeridos por ese
+// esquema. Los mensajes sin envelope (publicados antes de la migración)
+// se leen como la versión 1 del primer esquema.
+func decodeCloudEvent(body []byte, schemas ...string) (domain.CloudEvent, error) {
+	var probe struct {
+		SpecVersion *string `json:"specversion"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+	}
+	if probe.SpecVersion == nil {
+		if err := validateData(schemas[0], body); err != nil {
+			return domain.CloudEvent{}, err
+		}
+		return domain.CloudEvent{
+			SpecVersion: domain.CloudEventsSpecVersion,
+			DataSchema:  schemas[0],
+			Data:        body,
+		}, nil
+	}
+
+	var event domain.CloudEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return domain.CloudEvent{}, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+	}
+	switch {
+	case event.SpecVersion != domain.CloudEventsSpecVersion:
+		return domain.CloudEvent{}, fmt.Errorf("%w: unsupported specversion %q", ErrInvalidCloudEvent, event.SpecVersion)
+	case event.ID == "" || event.Source == "" || event.Type == "":
+		return domain.CloudEvent{}, fmt.Errorf("%w: id, source and type are required", ErrInvalidCloudEvent)
+	case len(event.Data) == 0:
+		return domain.CloudEvent{}, fmt.Errorf("%w: data is required", ErrInvalidCloudEvent)
+	}
+	for _, schema := range schemas {
+		if event.DataSchema == schema {
+			if err := validateData(schema, event.Data); err != nil {
+				return domain.CloudEvent{}, err
+			}
+			return event, nil
+		}
+	}
+	return domain.CloudEvent{}, fmt.Errorf("%w: unsupported dataschema %q", ErrInvalidCloudEvent, event.DataSchema)
+}
+
+// requiredDataFields son los campos obligatorios de `data` en cada esquema
+// de contracts/events
+var requiredDataFields = map[string][]string{
+	domain.RoomEventSchemaV1: {"event_type", "room_id", "timestamp"},
+	domain.RoomEventSchemaV2: {"event_type", "room_id", "version", "room", "timestamp"},
+}
+
+// validateData verifica que `data` sea un objeto con los campos requeridos
+// por el esquema; no valida tipos ni campos opcionales
+func validateData(schema string, data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%w: data is not a JSON object: %v", ErrInvalidCloudEvent, err)
+	}
+	for _, field := range requiredDataFields[schema] {
+		if value, ok := fields[field]; !ok || string(value) == "null" {
+			return fmt.Errorf("%w: data is missing %q (%s)", ErrInvalidCloudEvent, field, schema)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package consumers
 import (
 	"encoding/json"
 	"log"
+	"strings"
 	"time"
 
 	"search-api/config"
@@ -97,17 +98,23 @@ func (c *RoomsConsumer) processMessages(msgs <-chan amqp.Delivery) {
 func (c *RoomsConsumer) handleMessage(msg amqp.Delivery) {
 	log.Printf("Received message: %s", string(msg.Body))
 
+	envelope, err := decodeCloudEvent(msg.Body, domain.RoomEventSchemaV1, domain.RoomEventSchemaV2)
+	if err != nil {
+		log.Printf("Invalid event envelope: %v", err)
+		msg.Nack(false, false) // No requeue si el envelope es inválido
+		return
+	}
+
 	var event domain.RoomEvent
-	if err := json.Unmarshal(msg.Body, &event); err != nil {
+	if err := json.Unmarshal(envelope.Data, &event); err != nil {
 		log.Printf("Failed to unmarshal event: %v", err)
 		msg.Nack(false, false) // No requeue si el JSON es inválido
 		return
 	}
 
 	// Procesar según el tipo de evento
-	var err error
 	switch event.EventType {
-	case "created", "updated":
+	case "created", "updated", "status_changed":
 		err = c.handleCreateOrUpdate(event)
 	case "deleted":
 		err = c.handleDelete(event)
@@ -129,12 +136,38 @@ func (c *RoomsConsumer) handleMessage(msg amqp.Delivery) {
 	log.Printf("Event processed successfully: %s for room %d", event.EventType, event.RoomID)
 }
 
-// handleCreateOrUpdate maneja eventos de creación/actualización
+// handleCreateOrUpdate maneja eventos de creación/actualización. Los eventos
+// v2 traen el snapshot y se indexan sin consultar a rooms-api; se descartan
+// los que son más viejos que lo indexado.
 func (c *RoomsConsumer) handleCreateOrUpdate(event domain.RoomEvent) error {
-	// Obtener datos completos de la room desde rooms-api
-	room, err := c.roomsAPIClient.GetRoomByID(event.RoomID)
-	if err != nil {
-		return err
+	room := event.Room
+	if room != nil {
+		indexed, found, err := c.searchService.IndexedVersion(event.RoomID)
+		if err != nil {
+			return err
+		}
+		if found && indexed > event.Version {
+			log.Printf("Skipping stale event for room %d (version %d < %d)", event.RoomID, event.Version, indexed)
+			return nil
+		}
+		if !found && event.EventType != "created" {
+			// Sin documento puede ser un update atrasado de una habitación
+			// ya borrada: confirmar con rooms-api antes de reindexar
+			room = nil
+		}
+	}
+
+	if room == nil {
+		// Obtener datos completos de la room desde rooms-api
+		fetched, err := c.roomsAPIClient.GetRoomByID(event.RoomID)
+		if err != nil && strings.Contains(err.Error(), "not found") {
+			log.Printf("Room %d no longer exists, skipping %s event", event.RoomID, event.EventType)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		room = fetched
 	}
 
 	// Indexar en Solr
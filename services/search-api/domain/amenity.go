@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var amenityCodePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// LegacyAmenityFields mapea las amenities que en rooms-api eran columnas
+// booleanas a su campo en Solr. Los documentos indexados antes del catálogo
+// no tienen amenity_codes, así que para estas se filtra por el booleano.
+var LegacyAmenityFields = map[string]string{
+	"wifi":    "has_wifi",
+	"ac":      "has_ac",
+	"tv":      "has_tv",
+	"minibar": "has_minibar",
+}
+
+// Amenity es una amenity del catálogo de rooms-api
+type Amenity struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Category string `json:"category"`
+}
+
+// NormalizeAmenityCodes pasa a minúsculas, separa listas con comas y
+// descarta vacíos y duplicados. El resultado queda ordenado para que la
+// clave de caché no dependa del orden de los parámetros.
+func NormalizeAmenityCodes(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	result := []string{}
+	for _, raw := range codes {
+		for _, code := range strings.Split(raw, ",") {
+			code = strings.ToLower(strings.TrimSpace(code))
+			if code == "" || seen[code] {
+				continue
+			}
+			seen[code] = true
+			result = append(result, code)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ValidAmenityCode evita inyectar sintaxis de Solr en el filtro
+func ValidAmenityCode(code string) bool {
+	return amenityCodePattern.MatchString(code)
+}
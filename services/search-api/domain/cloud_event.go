@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const CloudEventsSpecVersion = "1.0"
+
+// Esquemas de `data` de los eventos de rooms-api (ver contracts/events). v1
+// coincide con el payload previo al envelope; v2 trae el snapshot y la versión.
+const (
+	RoomEventSchemaV1 = "urn:hotel:events:room:v1"
+	RoomEventSchemaV2 = "urn:hotel:events:room:v2"
+)
+
+// CloudEvent es el envelope CloudEvents 1.0 de todos los eventos del sistema
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema"`
+	Data            json.RawMessage `json:"data"`
+}
@@ -12,6 +12,7 @@ type SearchRoomsRequest struct {
 	HasAC      *bool   `form:"has_ac"`      // Filtro AC
 	HasTV      *bool   `form:"has_tv"`      // Filtro TV
 	HasMinibar *bool   `form:"has_minibar"` // Filtro Minibar
+	Amenities  []string `form:"amenities"`  // Códigos de amenities (todas deben estar)
 	Sort       string  `form:"sort"`        // Campo de ordenamiento
 	Page       int     `form:"page"`        // Página (default 1)
 	Limit      int     `form:"limit"`       // Tamaño página (default 10, max 50)
@@ -24,6 +24,15 @@ type SolrRoomDocument struct {
 	HasMinibar  []bool    `json:"has_minibar"`           // Array en Solr
 	CreatedAt   []string  `json:"created_at"`            // Array de strings RFC3339
 	UpdatedAt   []string  `json:"updated_at"`            // Array de strings RFC3339
+
+	CoverImageURL     []string `json:"cover_image_url,omitempty"`
+	CoverThumbnailURL []string `json:"cover_thumbnail_url,omitempty"`
+	ImageURLs         []string `json:"image_urls,omitempty"` // Multivaluado: galería en orden
+	ImageCount        []int    `json:"image_count,omitempty"`
+
+	// Códigos y nombres de las amenities, en el mismo orden
+	AmenityCodes []string `json:"amenity_codes,omitempty"`
+	Amenities    []string `json:"amenities,omitempty"`
 }
 
 // UnmarshalJSON implementa custom unmarshaling para manejar tanto strings como arrays de Solr
@@ -44,6 +53,13 @@ func (d *SolrRoomDocument) UnmarshalJSON(data []byte) error {
 		HasMinibar  interface{} `json:"has_minibar"`
 		CreatedAt   interface{} `json:"created_at"`
 		UpdatedAt   interface{} `json:"updated_at"`
+
+		CoverImageURL     interface{} `json:"cover_image_url"`
+		CoverThumbnailURL interface{} `json:"cover_thumbnail_url"`
+		ImageURLs         interface{} `json:"image_urls"`
+		ImageCount        interface{} `json:"image_count"`
+		AmenityCodes      interface{} `json:"amenity_codes"`
+		Amenities         interface{} `json:"amenities"`
 		*Alias
 	}{
 		Alias: (*Alias)(d),
@@ -123,6 +139,26 @@ func (d *SolrRoomDocument) UnmarshalJSON(data []byte) error {
 		d.UpdatedAt = toStringArray(aux.UpdatedAt)
 	}
 
+	// Campos de la galería (documentos indexados antes no los tienen)
+	if aux.CoverImageURL != nil {
+		d.CoverImageURL = toStringArray(aux.CoverImageURL)
+	}
+	if aux.CoverThumbnailURL != nil {
+		d.CoverThumbnailURL = toStringArray(aux.CoverThumbnailURL)
+	}
+	if aux.ImageURLs != nil {
+		d.ImageURLs = toStringArray(aux.ImageURLs)
+	}
+	if aux.ImageCount != nil {
+		d.ImageCount = toIntArray(aux.ImageCount)
+	}
+	if aux.AmenityCodes != nil {
+		d.AmenityCodes = toStringArray(aux.AmenityCodes)
+	}
+	if aux.Amenities != nil {
+		d.Amenities = toStringArray(aux.Amenities)
+	}
+
 	return nil
 }
 
@@ -230,14 +266,31 @@ type Room struct {
 	HasAC       bool      `json:"has_ac"`
 	HasTV       bool      `json:"has_tv"`
 	HasMinibar  bool      `json:"has_minibar"`
+	Version     uint64    `json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	Amenities         []Amenity   `json:"amenities"`
+	Images            []RoomImage `json:"images"`
+	CoverImageURL     string      `json:"cover_image_url"`
+	CoverThumbnailURL string      `json:"cover_thumbnail_url"`
+}
+
+// RoomImage es una foto de la galería de rooms-api, ya en orden
+type RoomImage struct {
+	ID           uint   `json:"id"`
+	WebURL       string `json:"web_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	IsCover      bool   `json:"is_cover"`
 }
 
-// RoomEvent representa un evento de RabbitMQ para rooms
+// RoomEvent representa un evento de RabbitMQ para rooms. Version y Room
+// (snapshot completo) vienen desde la v2 del esquema.
 type RoomEvent struct {
-	EventType string `json:"event_type"` // "created", "updated", "deleted"
+	EventType string `json:"event_type"` // "created", "updated", "deleted", "status_changed"
 	RoomID    uint   `json:"room_id"`
+	Version   uint64 `json:"version,omitempty"`
+	Room      *Room  `json:"room,omitempty"`
 	Timestamp string `json:"timestamp"` // RFC3339 format
 }
 
@@ -255,8 +308,16 @@ type SolrRoomWrite struct {
 	HasAC       bool    `json:"has_ac"`
 	HasTV       bool    `json:"has_tv"`
 	HasMinibar  bool    `json:"has_minibar"`
+	RoomVersion uint64  `json:"room_version,omitempty"`
 	CreatedAt   string  `json:"created_at"` // RFC3339
 	UpdatedAt   string  `json:"updated_at"` // RFC3339
+
+	CoverImageURL     string   `json:"cover_image_url,omitempty"`
+	CoverThumbnailURL string   `json:"cover_thumbnail_url,omitempty"`
+	ImageURLs         []string `json:"image_urls,omitempty"`
+	ImageCount        int      `json:"image_count"`
+	AmenityCodes      []string `json:"amenity_codes,omitempty"`
+	Amenities         []string `json:"amenities,omitempty"`
 }
 
 // ToSolrDocument convierte Room a SolrRoomWrite para escritura en Solr
@@ -264,6 +325,18 @@ func (r *Room) ToSolrDocument() *SolrRoomWrite {
 	// Convertir Number string a int
 	numberInt, _ := strconv.Atoi(r.Number)
 
+	amenityCodes := make([]string, len(r.Amenities))
+	amenityNames := make([]string, len(r.Amenities))
+	for i, amenity := range r.Amenities {
+		amenityCodes[i] = amenity.Code
+		amenityNames[i] = amenity.Name
+	}
+
+	imageURLs := make([]string, len(r.Images))
+	for i, image := range r.Images {
+		imageURLs[i] = image.WebURL
+	}
+
 	return &SolrRoomWrite{
 		ID:          fmt.Sprintf("%d", r.ID), // Convertir uint a string
 		Number:      numberInt,
@@ -277,7 +350,15 @@ func (r *Room) ToSolrDocument() *SolrRoomWrite {
 		HasAC:       r.HasAC,
 		HasTV:       r.HasTV,
 		HasMinibar:  r.HasMinibar,
+		RoomVersion: r.Version,
 		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+
+		CoverImageURL:     r.CoverImageURL,
+		CoverThumbnailURL: r.CoverThumbnailURL,
+		ImageURLs:         imageURLs,
+		ImageCount:        len(r.Images),
+		AmenityCodes:      amenityCodes,
+		Amenities:         amenityNames,
 	}
 }
@@ -130,6 +130,15 @@ func (r *SolrRepository) buildSolrQuery(req *domain.SearchRoomsRequest) string {
 		filters = append(filters, fmt.Sprintf("has_minibar:%t", *req.HasMinibar))
 	}
 
+	// Todas las amenities pedidas; las cuatro históricas usan su booleano
+	for _, code := range req.Amenities {
+		if field, ok := domain.LegacyAmenityFields[code]; ok {
+			filters = append(filters, fmt.Sprintf("%s:true", field))
+		} else {
+			filters = append(filters, fmt.Sprintf("amenity_codes:%q", code))
+		}
+	}
+
 	if len(filters) > 0 {
 		params.Add("fq", strings.Join(filters, " AND "))
 	}
@@ -208,6 +217,49 @@ func (r *SolrRepository) IndexDocument(doc *domain.SolrRoomWrite) error {
 	return nil
 }
 
+// GetRoomVersion devuelve la versión de rooms-api del documento indexado;
+// found es false si el documento no existe
+func (r *SolrRepository) GetRoomVersion(id string) (uint64, bool, error) {
+	params := url.Values{}
+	params.Set("q", fmt.Sprintf("id:%q", id))
+	params.Set("fl", "room_version")
+	params.Set("rows", "1")
+	params.Set("wt", "json")
+
+	resp, err := r.httpClient.Get(r.config.GetSelectURL() + "?" + params.Encode())
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to query Solr: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, false, fmt.Errorf("Solr returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Response struct {
+			Docs []struct {
+				// Solr puede devolver el valor simple o como array
+				RoomVersion interface{} `json:"room_version"`
+			} `json:"docs"`
+		} `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, false, fmt.Errorf("failed to decode Solr response: %w", err)
+	}
+	if len(result.Response.Docs) == 0 {
+		return 0, false, nil
+	}
+
+	value := result.Response.Docs[0].RoomVersion
+	if values, ok := value.([]interface{}); ok && len(values) > 0 {
+		value = values[0]
+	}
+	version, _ := value.(float64) // Documentos indexados antes de la v2 no tienen versión
+	return uint64(version), true, nil
+}
+
 // DeleteDocument elimina un documento de Solr por ID
 func (r *SolrRepository) DeleteDocument(id string) error {
 	payload := map[string]interface{}{
@@ -105,6 +105,11 @@ func (s *SearchService) IndexRoom(room *domain.Room) error {
 	return nil
 }
 
+// IndexedVersion devuelve la versión de la habitación indexada en Solr
+func (s *SearchService) IndexedVersion(roomID uint) (uint64, bool, error) {
+	return s.solrRepo.GetRoomVersion(fmt.Sprintf("%d", roomID))
+}
+
 // DeleteRoom elimina una habitación del índice de Solr
 func (s *SearchService) DeleteRoom(roomID uint) error {
 	// Convertir ID a string
@@ -139,6 +144,12 @@ func (s *SearchService) validateSearchRequest(req *domain.SearchRoomsRequest) er
 		return fmt.Errorf("min_price cannot be greater than max_price")
 	}
 
+	for _, code := range domain.NormalizeAmenityCodes(req.Amenities) {
+		if !domain.ValidAmenityCode(code) {
+			return fmt.Errorf("invalid amenity code %q", code)
+		}
+	}
+
 	return nil
 }
 
@@ -151,6 +162,8 @@ func (s *SearchService) normalizeSearchRequest(req *domain.SearchRoomsRequest) {
 	if req.Limit == 0 {
 		req.Limit = 10
 	}
+
+	req.Amenities = domain.NormalizeAmenityCodes(req.Amenities)
 }
 
 // generateCacheKey genera una clave de caché única basada en los parámetros de búsqueda
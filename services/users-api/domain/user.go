@@ -20,5 +20,6 @@ type UserRole string
 
 const (
 	RoleNormal UserRole = "normal"
+	RoleStaff  UserRole = "staff"
 	RoleAdmin  UserRole = "admin"
 )